@@ -2,19 +2,31 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"go-exercise/internal/adapters/aggregate"
+	"go-exercise/internal/adapters/binance"
 	"go-exercise/internal/adapters/cache"
+	"go-exercise/internal/adapters/fake"
 	httphandler "go-exercise/internal/adapters/http"
 	"go-exercise/internal/adapters/kraken"
+	"go-exercise/internal/adapters/tracing"
 	"go-exercise/internal/application/service"
-	
+	"go-exercise/internal/config"
+	"go-exercise/internal/domain"
+	"go-exercise/internal/ports"
+
 	_ "go-exercise/docs" // Swagger documentation
 )
 
@@ -32,35 +44,262 @@ import (
 
 // @host localhost:8080
 // @BasePath /
+
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description Required on /api/v1 routes when the server is started with API_KEYS
 func main() {
+	// Every invalid setting is reported at once, before anything starts
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to start: %v", err)
+	}
+
+	// Tracing stays a no-op unless an OTLP endpoint is configured
+	shutdownTracing := func(context.Context) error { return nil }
+	if cfg.OTLPEndpoint != "" {
+		shutdown, err := tracing.Setup(context.Background(), httphandler.ServiceName)
+		if err != nil {
+			log.Fatalf("Failed to set up tracing: %v", err)
+		}
+		shutdownTracing = shutdown
+		log.Printf("Tracing enabled, exporting to %s", cfg.OTLPEndpoint)
+	}
+
+	// The pairs of PAIRS_CONFIG, if any, replace the compiled-in ones
+	var krakenOpts []kraken.Option
+	if defs := cfg.PairDefinitions; defs != nil {
+		if err := domain.SetValidPairs(config.Names(defs)); err != nil {
+			log.Fatalf("Failed to apply pairs from %s: %v", cfg.PairsConfig, err)
+		}
+		krakenOpts = append(krakenOpts, kraken.WithSymbols(config.Symbols(defs)))
+		log.Printf("Loaded %d pairs from %s", len(defs), cfg.PairsConfig)
+	}
+
 	// Initialize adapters
-	krakenClient := kraken.NewKrakenClient("")
-	cacheRepo := cache.NewInMemoryCache()
+	if cfg.KrakenTimeout > 0 {
+		krakenOpts = append(krakenOpts, kraken.WithTimeout(cfg.KrakenTimeout))
+	}
+	if cfg.UpstreamTimeout > 0 {
+		krakenOpts = append(krakenOpts, kraken.WithUpstreamTimeout(cfg.UpstreamTimeout))
+	}
+	if cfg.KrakenBatchSize > 0 {
+		krakenOpts = append(krakenOpts, kraken.WithBatchSize(cfg.KrakenBatchSize))
+	}
+	if cfg.KrakenPartialBatches {
+		krakenOpts = append(krakenOpts, kraken.WithPartialBatches())
+	}
+	if cfg.KrakenUserAgent != "" {
+		krakenOpts = append(krakenOpts, kraken.WithUserAgent(cfg.KrakenUserAgent))
+	}
+	// Let operators correct a mismatched Kraken symbol without a deploy
+	if overrides := cfg.SymbolOverrides; overrides != nil {
+		krakenOpts = append(krakenOpts, kraken.WithSymbolOverrides(overrides))
+		log.Printf("Overriding Kraken symbols: %v", overrides)
+	}
+	source := cfg.PriceSource
+	// Every provider can be selected per request; the primary one also fills the cache
+	providers := make(map[string]ports.External)
+	if source == "fake" {
+		// Offline prices for local development and demos, without touching any real provider
+		providers["fake"] = fake.NewFakeExternal(fake.WithRandomWalk(0.001, time.Now().UnixNano()))
+	} else {
+		client := kraken.NewKrakenClient("", krakenOpts...).(*kraken.KrakenClient)
+		// Learn Kraken's own symbols once; the compiled-in mapping remains the fallback
+		if err := client.LoadAssetPairs(context.Background()); err != nil {
+			log.Printf("Failed to load Kraken asset pairs, falling back to configured symbols: %v", err)
+		}
+		providers["kraken"] = client
 
-	// Initialize application service
-	ltpService := service.NewLTPService(cacheRepo, krakenClient)
+		var binanceOpts []binance.Option
+		if cfg.BinanceUSDTAsUSD {
+			binanceOpts = append(binanceOpts, binance.WithUSDTAsUSD())
+		}
+		providers["binance"] = binance.NewBinanceClient("", binanceOpts...)
+
+		// Optionally combine the prices of every real provider instead of using one
+		if source == "aggregate" {
+			mode, err := aggregate.ParseMode(cfg.AggregateMode)
+			if err != nil {
+				log.Fatalf("Invalid AGGREGATE_MODE: %v", err)
+			}
+			providers["aggregate"] = aggregate.NewAggregateExternal(mode, providers["kraken"], providers["binance"])
+			log.Printf("Aggregating Kraken and Binance prices by %s", mode)
+		}
+	}
+	external, ok := providers[source]
+	if !ok {
+		log.Fatalf("Invalid PRICE_SOURCE %q: must be kraken, binance, aggregate or fake", source)
+	}
+	// Optionally check the provider and its config with one fetch before serving
+	if mode := cfg.StartupSelfTest; mode != "off" {
+		ctx, cancel := context.WithTimeout(context.Background(), selfTestTimeout)
+		err := selfTest(ctx, external)
+		cancel()
+		switch {
+		case err == nil:
+			log.Printf("Startup self-test against %s passed", source)
+		case mode == "fail":
+			log.Fatalf("Startup self-test against %s failed: %v", source, err)
+		default:
+			log.Printf("WARNING: startup self-test against %s failed, serving anyway: %v", source, err)
+		}
+	}
+	cacheOpts := []cache.Option{cache.WithTTL(cfg.CacheTTL)}
+	if floors := cfg.CacheMinTTL; floors != nil {
+		cacheOpts = append(cacheOpts, cache.WithMinTTL(floors))
+	}
+	cacheOpts = append(cacheOpts, cache.WithMaxEntries(cfg.CacheMaxEntries), cache.WithHistory(cfg.HistorySize))
+	cacheRepo := cache.NewInMemoryCache(cacheOpts...)
+	// Optionally restore the prices saved on the last shutdown instead of fetching them again
+	snapshotFile := cfg.CacheSnapshotFile
+	if snapshotFile != "" {
+		n, err := cacheRepo.(*cache.InMemoryCache).Load(snapshotFile)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			log.Printf("No cache snapshot at %s yet", snapshotFile)
+		case err != nil:
+			log.Printf("Failed to load cache snapshot, starting empty: %v", err)
+		default:
+			log.Printf("Restored %d cached prices from %s", n, snapshotFile)
+		}
+	}
+
+	// Initialize application service, rejecting pairs no provider supports
+	serviceOpts := []service.Option{service.WithProviderName(source), service.WithProviders(providers)}
+	if supporter, ok := external.(ports.PairSupporter); ok {
+		serviceOpts = append(serviceOpts, service.WithSupportedPairs(supporter))
+	}
+	if maxAges := cfg.FreshnessSLA; maxAges != nil {
+		serviceOpts = append(serviceOpts, service.WithFreshnessSLA(maxAges))
+	}
+	if bounds := cfg.PriceBounds; bounds != nil {
+		serviceOpts = append(serviceOpts, service.WithPriceBounds(bounds))
+	}
+	if cfg.AllowStale {
+		serviceOpts = append(serviceOpts, service.WithAllowStale())
+	}
+	if cfg.PairsSpaceSeparated {
+		serviceOpts = append(serviceOpts, service.WithSpaceSeparatedPairs())
+	}
+	if cfg.MaxPairs > 0 {
+		serviceOpts = append(serviceOpts, service.WithMaxPairs(cfg.MaxPairs))
+	}
+	defaultPairs, _ := domain.ParsePairs("")
+	if pairs := cfg.DefaultPairs; pairs != nil {
+		defaultPairs = pairs
+		serviceOpts = append(serviceOpts, service.WithDefaultPairs(pairs))
+	}
+	if candles, ok := external.(ports.CandleProvider); ok {
+		serviceOpts = append(serviceOpts, service.WithCandleProvider(candles))
+	}
+	if cfg.SlowUpstreamThreshold > 0 {
+		serviceOpts = append(serviceOpts, service.WithSlowUpstreamThreshold(cfg.SlowUpstreamThreshold))
+	}
+	ltpService := service.NewLTPService(cacheRepo, external, serviceOpts...)
+
+	// Optionally keep the cache warm in the background
+	var refresherOpts []service.RefresherOption
+	if cfg.RefreshFailureThreshold > 0 {
+		refresherOpts = append(refresherOpts, service.WithFailureThreshold(cfg.RefreshFailureThreshold))
+	}
+	refresher := service.NewRefresherController(ltpService, refresherOpts...)
+	defer refresher.Stop()
+
+	// Only the default pairs are kept warm, unless a reload says otherwise
+	state := &runtimeState{pairs: defaultPairs}
+	if cfg.RefreshInterval > 0 {
+		state.interval = cfg.RefreshInterval
+		refresher.Reload(state.pairs, state.interval)
+		log.Printf("Background refresher started with interval %s", cfg.RefreshInterval)
+	}
+	if reloadConfig := cfg.ReloadConfig; reloadConfig != "" {
+		hup := make(chan os.Signal, 1)
+		signal.Notify(hup, syscall.SIGHUP)
+		defer signal.Stop(hup)
+		go func() {
+			for range hup {
+				if err := reloadRuntime(reloadConfig, state, refresher, cacheRepo.(*cache.InMemoryCache)); err != nil {
+					log.Printf("Failed to reload %s: %v", reloadConfig, err)
+				}
+			}
+		}()
+		log.Printf("Refresher and cache TTL settings reload from %s on SIGHUP", reloadConfig)
+	}
 
 	// Initialize HTTP handler
-	handler := httphandler.NewHandler(ltpService)
+	var handlerOpts []httphandler.HandlerOption
+	if cfg.EventsInterval > 0 {
+		handlerOpts = append(handlerOpts, httphandler.WithEventsInterval(cfg.EventsInterval))
+	}
+	if resolver, ok := external.(ports.SymbolResolver); ok {
+		handlerOpts = append(handlerOpts, httphandler.WithSymbolResolver(resolver))
+	}
+	handlerOpts = append(handlerOpts, httphandler.WithDiagnostics(external))
+	handlerOpts = append(handlerOpts, httphandler.WithConfig(cfg))
+	if ttls, ok := cacheRepo.(ports.TTLSource); ok {
+		handlerOpts = append(handlerOpts, httphandler.WithCacheTTL(ttls))
+	}
+	// Without its price provider the service cannot serve fresh prices, so it is required
+	if checker, ok := external.(ports.HealthChecker); ok {
+		handlerOpts = append(handlerOpts, httphandler.WithHealthCheck(checker, true))
+	}
+	// Prices can still be fetched on demand, so a failing refresher only degrades readiness
+	if cfg.RefreshInterval > 0 || cfg.ReloadConfig != "" {
+		handlerOpts = append(handlerOpts,
+			httphandler.WithHealthCheck(refresher, false),
+			httphandler.WithRefresherStatus(refresher),
+		)
+	}
+	handler := httphandler.NewHandler(ltpService, handlerOpts...)
 
 	// Setup router
-	var e *echo.Echo = httphandler.SetupRouter(handler)
+	var routerOpts []httphandler.RouterOption
+	if cfg.CompressionMinLength != nil {
+		routerOpts = append(routerOpts, httphandler.WithCompressionMinLength(*cfg.CompressionMinLength))
+	}
+	if len(cfg.APIKeys) > 0 {
+		routerOpts = append(routerOpts, httphandler.WithAPIKeys(cfg.APIKeys))
+		log.Printf("API key authentication enabled with %d keys", len(cfg.APIKeys))
+	}
+	// CORS stays permissive unless restricted
+	if len(cfg.CORSAllowedOrigins) > 0 {
+		routerOpts = append(routerOpts, httphandler.WithCORSAllowedOrigins(cfg.CORSAllowedOrigins))
+	}
+	if len(cfg.CORSAllowedMethods) > 0 {
+		routerOpts = append(routerOpts, httphandler.WithCORSAllowedMethods(cfg.CORSAllowedMethods))
+	}
+	if len(cfg.CORSAllowedHeaders) > 0 {
+		routerOpts = append(routerOpts, httphandler.WithCORSAllowedHeaders(cfg.CORSAllowedHeaders))
+	}
+	if cfg.DebugHTTP {
+		routerOpts = append(routerOpts, httphandler.WithDebugLogging(log.Default()))
+		log.Printf("Debug logging of requests and response bodies enabled")
+	}
+	var e *echo.Echo = httphandler.SetupRouter(handler, routerOpts...)
 
-	// Start server
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	// Start server, on a Unix domain socket when one is configured
+	listen := listenConfig{socket: cfg.ListenSocket, port: cfg.Port}
+	listener, err := newListener(listen)
+	if err != nil {
+		log.Fatalf("Failed to listen: %v", err)
 	}
+	e.Listener = listener
 
 	// Start server in a goroutine
 	go func() {
-		if err := e.Start(fmt.Sprintf(":%s", port)); err != nil && err != http.ErrServerClosed {
+		if err := e.Start(""); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("Failed to start server: %v", err)
 		}
 	}()
 
-	log.Printf("Server started on port %s", port)
-	log.Printf("Swagger documentation available at http://localhost:%s/swagger/index.html", port)
+	if listen.socket != "" {
+		log.Printf("Server started on socket %s", listen.socket)
+	} else {
+		log.Printf("Server started on port %s", listen.port)
+		log.Printf("Swagger documentation available at http://localhost:%s/swagger/index.html", listen.port)
+	}
 
 	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
@@ -69,15 +308,117 @@ func main() {
 
 	log.Println("Shutting down server...")
 
+	// Stop the background refresher before the server
+	refresher.Stop()
+
+	// End open event streams cleanly, since the server shutdown waits for them to return
+	handler.Shutdown()
+
 	// Graceful shutdown with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	// Closing the listener also removes the socket file
 	if err := e.Shutdown(ctx); err != nil {
 		log.Printf("Server forced to shutdown: %v", err)
 		os.Exit(1)
 	}
 
+	// The server no longer writes to the cache, so its prices can be saved for the next start
+	if snapshotFile != "" {
+		if err := cacheRepo.(*cache.InMemoryCache).Save(snapshotFile); err != nil {
+			log.Printf("Failed to save cache snapshot: %v", err)
+		} else {
+			log.Printf("Saved cache snapshot to %s", snapshotFile)
+		}
+	}
+
+	// Flush any spans still buffered
+	if err := shutdownTracing(ctx); err != nil {
+		log.Printf("Failed to flush traces: %v", err)
+	}
+
 	log.Println("Server exited")
 }
 
+// selfTestTimeout bounds the startup self-test fetch
+const selfTestTimeout = 10 * time.Second
+
+// selfTest fetches the first valid pair once to check that the provider is reachable and
+// configured to serve it
+func selfTest(ctx context.Context, external ports.External) error {
+	pair, err := domain.NewPair(domain.ValidPairs()[0])
+	if err != nil {
+		return err
+	}
+	ltp, err := external.GetTicker(ctx, pair)
+	if err != nil {
+		return fmt.Errorf("fetching %s: %w", pair, err)
+	}
+	if ltp.Amount <= 0 {
+		return fmt.Errorf("fetching %s: got non-positive price %v", pair, ltp.Amount)
+	}
+	return nil
+}
+
+// listenConfig selects where the server listens
+type listenConfig struct {
+	// socket is the path of a Unix domain socket; the TCP port is used when empty
+	socket string
+	port   string
+}
+
+// newListener listens on the configured Unix domain socket, replacing a socket file left
+// behind by an earlier run, or else on the TCP port. A socket listener removes its file
+// when closed.
+func newListener(cfg listenConfig) (net.Listener, error) {
+	if cfg.socket == "" {
+		return net.Listen("tcp", ":"+cfg.port)
+	}
+	if info, err := os.Stat(cfg.socket); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("%s exists and is not a socket", cfg.socket)
+		}
+		if err := os.Remove(cfg.socket); err != nil {
+			return nil, fmt.Errorf("removing stale socket: %w", err)
+		}
+	}
+	return net.Listen("unix", cfg.socket)
+}
+
+// runtimeState is the reloadable refresher configuration currently applied
+type runtimeState struct {
+	pairs    []domain.Pair
+	interval time.Duration
+}
+
+// reloadRuntime applies the settings of a runtime config file. The refresher is only
+// restarted, after draining the running one, when its pairs or interval are given.
+func reloadRuntime(path string, state *runtimeState, refresher *service.RefresherController, cacheRepo *cache.InMemoryCache) error {
+	settings, err := config.LoadRuntime(path)
+	if err != nil {
+		return err
+	}
+
+	pairs := state.pairs
+	if len(settings.RefreshPairs) > 0 {
+		pairs, err = domain.ParsePairs(strings.Join(settings.RefreshPairs, ","))
+		if err != nil {
+			return fmt.Errorf("invalid refresh_pairs: %w", err)
+		}
+	}
+
+	if settings.CacheTTL > 0 {
+		cacheRepo.SetTTL(settings.CacheTTL)
+		log.Printf("Cache TTL reloaded: %s", settings.CacheTTL)
+	}
+	if len(settings.RefreshPairs) > 0 || settings.RefreshInterval > 0 {
+		state.pairs = pairs
+		if settings.RefreshInterval > 0 {
+			state.interval = settings.RefreshInterval
+		}
+		refresher.Reload(state.pairs, state.interval)
+		log.Printf("Background refresher reloaded with interval %s for %d pairs", state.interval, len(state.pairs))
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-exercise/internal/adapters/cache"
+	"go-exercise/internal/application/service"
+	"go-exercise/internal/domain"
+	"go-exercise/internal/ports/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelfTest(t *testing.T) {
+	pair, _ := domain.NewPair(domain.ValidPairs()[0])
+
+	tests := []struct {
+		name        string
+		ltp         domain.LTP
+		err         error
+		expectedErr string
+	}{
+		{name: "provider reachable", ltp: domain.LTP{Pair: pair, Amount: 52000.12}},
+		{name: "provider unreachable", err: errors.New("connection refused"), expectedErr: "connection refused"},
+		{name: "non-positive price", ltp: domain.LTP{Pair: pair}, expectedErr: "non-positive price"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			external := new(mocks.External)
+			external.On("GetTicker", mock.Anything, pair).Return(tt.ltp, tt.err).Once()
+
+			// Act
+			err := selfTest(context.Background(), external)
+
+			// Assert
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Contains(t, err.Error(), pair.Value())
+			} else {
+				assert.NoError(t, err)
+			}
+			external.AssertExpectations(t)
+		})
+	}
+}
+
+// socketPath returns a socket path in a fresh temporary directory, kept short since socket
+// paths are limited to about a hundred bytes
+func socketPath(t *testing.T) string {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "ltp")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return filepath.Join(dir, "server.sock")
+}
+
+func TestNewListener_UnixSocket(t *testing.T) {
+	// Arrange
+	path := socketPath(t)
+
+	// Act
+	listener, err := newListener(listenConfig{socket: path})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "unix", listener.Addr().Network())
+
+	accepted := make(chan error, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			conn.Close()
+		}
+		accepted <- err
+	}()
+	conn, err := net.Dial("unix", path)
+	require.NoError(t, err)
+	conn.Close()
+	require.NoError(t, <-accepted)
+
+	require.NoError(t, listener.Close())
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err), "closing the listener should remove the socket file")
+}
+
+func TestNewListener_ReplacesStaleSocket(t *testing.T) {
+	// Arrange
+	path := socketPath(t)
+	stale, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	// Leave the file behind as a crashed process would
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+
+	// Act
+	listener, err := newListener(listenConfig{socket: path})
+
+	// Assert
+	require.NoError(t, err)
+	assert.NoError(t, listener.Close())
+}
+
+func TestNewListener_RefusesToReplaceOtherFiles(t *testing.T) {
+	// Arrange
+	path := socketPath(t)
+	require.NoError(t, os.WriteFile(path, []byte("keep me"), 0o600))
+
+	// Act
+	listener, err := newListener(listenConfig{socket: path})
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, listener)
+	assert.Contains(t, err.Error(), "is not a socket")
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "keep me", string(content))
+}
+
+func TestNewListener_TCP(t *testing.T) {
+	// Arrange
+	cfg := listenConfig{port: "0"}
+
+	// Act
+	listener, err := newListener(cfg)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "tcp", listener.Addr().Network())
+	assert.NoError(t, listener.Close())
+}
+
+func TestReloadRuntime(t *testing.T) {
+	// Arrange
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	ltpService := new(mocks.LTPService)
+	ltpService.On("RefreshLTPs", mock.Anything, []domain.Pair{btcEUR}).Return(nil)
+	refresher := service.NewRefresherController(ltpService)
+	defer refresher.Stop()
+	cacheRepo := cache.NewInMemoryCache(cache.WithTTL(time.Hour)).(*cache.InMemoryCache)
+	cacheRepo.SetLTP(btcUSD, domain.LTP{Pair: btcUSD, Amount: 52000.12})
+	state := &runtimeState{pairs: []domain.Pair{btcUSD}}
+
+	path := filepath.Join(t.TempDir(), "runtime.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("refresh_interval: 1h\nrefresh_pairs: [BTC/EUR]\ncache_ttl: 1ns\n"), 0o600))
+
+	// Act
+	err := reloadRuntime(path, state, refresher, cacheRepo)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, &runtimeState{pairs: []domain.Pair{btcEUR}, interval: time.Hour}, state)
+	require.Eventually(t, func() bool {
+		return !refresher.RefresherStatus().LastSuccess.IsZero()
+	}, time.Second, 5*time.Millisecond, "the reloaded refresher runs for the new pairs")
+	_, found := cacheRepo.GetLTP(btcUSD)
+	assert.False(t, found, "the new cache TTL applies")
+}
+
+func TestReloadRuntime_KeepsStateOnError(t *testing.T) {
+	// Arrange
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	refresher := service.NewRefresherController(new(mocks.LTPService))
+	cacheRepo := cache.NewInMemoryCache().(*cache.InMemoryCache)
+	state := &runtimeState{pairs: []domain.Pair{btcUSD}, interval: time.Minute}
+
+	path := filepath.Join(t.TempDir(), "runtime.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("refresh_interval: 1s\nrefresh_pairs: [DOGE/XYZ]\n"), 0o600))
+
+	// Act
+	err := reloadRuntime(path, state, refresher, cacheRepo)
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid refresh_pairs")
+	assert.Equal(t, &runtimeState{pairs: []domain.Pair{btcUSD}, interval: time.Minute}, state)
+	assert.True(t, refresher.RefresherStatus().LastSuccess.IsZero(), "no refresher was started")
+}
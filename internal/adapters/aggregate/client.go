@@ -0,0 +1,231 @@
+package aggregate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go-exercise/internal/domain"
+	"go-exercise/internal/ports"
+)
+
+// Mode is how the prices of several providers are combined into one
+type Mode string
+
+const (
+	// ModeMean averages the prices of all providers that returned the pair
+	ModeMean Mode = "mean"
+	// ModeMedian takes the middle price, so a single outlying provider cannot skew it
+	ModeMedian Mode = "median"
+)
+
+// ParseMode parses an aggregation mode, case-insensitively
+func ParseMode(value string) (Mode, error) {
+	switch mode := Mode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case ModeMean, ModeMedian:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown aggregation mode %q: must be mean or median", value)
+	}
+}
+
+// Ensure AggregateExternal reports the pairs it can fetch and its health
+var (
+	_ ports.PairSupporter = (*AggregateExternal)(nil)
+	_ ports.HealthChecker = (*AggregateExternal)(nil)
+)
+
+// AggregateExternal implements the External port by querying several providers
+// concurrently and combining their prices per pair. Providers that fail are ignored as
+// long as at least one succeeds. Providers do not report traded volumes, so prices are
+// combined without weights.
+type AggregateExternal struct {
+	mode      Mode
+	providers []ports.External
+}
+
+// NewAggregateExternal creates a provider combining the prices of the given providers
+func NewAggregateExternal(mode Mode, providers ...ports.External) ports.External {
+	return &AggregateExternal{
+		mode:      mode,
+		providers: providers,
+	}
+}
+
+// SupportedPairs returns the pairs any provider supports, in the order of the valid pairs.
+// A provider that does not report its pairs is assumed to support every valid pair.
+func (a *AggregateExternal) SupportedPairs() []domain.Pair {
+	var pairs []domain.Pair
+	for _, value := range domain.ValidPairs() {
+		pair, err := domain.NewPair(value)
+		if err != nil {
+			continue
+		}
+		for _, provider := range a.providers {
+			if supports(provider, pair) {
+				pairs = append(pairs, pair)
+				break
+			}
+		}
+	}
+	return pairs
+}
+
+// supports reports whether the provider can fetch the pair
+func supports(provider ports.External, pair domain.Pair) bool {
+	supporter, ok := provider.(ports.PairSupporter)
+	if !ok {
+		return true
+	}
+	for _, supported := range supporter.SupportedPairs() {
+		if supported == pair {
+			return true
+		}
+	}
+	return false
+}
+
+// Name identifies the aggregate in readiness responses
+func (a *AggregateExternal) Name() string {
+	return "aggregate"
+}
+
+// CheckHealth checks every provider that reports its health. Prices are available as
+// long as one of them is up, so the aggregate is only down when all of them are.
+func (a *AggregateExternal) CheckHealth(ctx context.Context) domain.HealthStatus {
+	var checked, down int
+	for _, provider := range a.providers {
+		checker, ok := provider.(ports.HealthChecker)
+		if !ok {
+			continue
+		}
+		checked++
+		if checker.CheckHealth(ctx) != domain.HealthOK {
+			down++
+		}
+	}
+	switch {
+	case down == 0:
+		return domain.HealthOK
+	case down == checked:
+		return domain.HealthDown
+	default:
+		return domain.HealthDegraded
+	}
+}
+
+// GetTicker retrieves the combined price of a single pair
+func (a *AggregateExternal) GetTicker(ctx context.Context, pair domain.Pair) (domain.LTP, error) {
+	ltps, err := a.GetTickers(ctx, []domain.Pair{pair})
+	if err != nil {
+		return domain.LTP{}, err
+	}
+	return ltps[0], nil
+}
+
+// GetTickers queries every provider concurrently for the pairs it supports and combines
+// the prices per pair, in the requested order
+func (a *AggregateExternal) GetTickers(ctx context.Context, pairs []domain.Pair) ([]domain.LTP, error) {
+	if len(pairs) == 0 {
+		return nil, fmt.Errorf("no pairs provided")
+	}
+
+	results := make([][]domain.LTP, len(a.providers))
+	errs := make([]error, len(a.providers))
+	var wg sync.WaitGroup
+	for i, provider := range a.providers {
+		var supported []domain.Pair
+		for _, pair := range pairs {
+			if supports(provider, pair) {
+				supported = append(supported, pair)
+			}
+		}
+		if len(supported) == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, provider ports.External, supported []domain.Pair) {
+			defer wg.Done()
+			results[i], errs[i] = provider.GetTickers(ctx, supported)
+		}(i, provider, supported)
+	}
+	wg.Wait()
+
+	byPair := make(map[domain.Pair][]domain.LTP, len(pairs))
+	for _, ltps := range results {
+		for _, ltp := range ltps {
+			byPair[ltp.Pair] = append(byPair[ltp.Pair], ltp)
+		}
+	}
+	if len(byPair) == 0 {
+		if err := errors.Join(errs...); err != nil {
+			return nil, fmt.Errorf("all providers failed: %w", err)
+		}
+	}
+
+	combined := make([]domain.LTP, len(pairs))
+	for i, pair := range pairs {
+		ltps := byPair[pair]
+		if len(ltps) == 0 {
+			return nil, fmt.Errorf("no provider returned a price for %s", pair.Value())
+		}
+		combined[i] = a.combine(pair, ltps)
+	}
+	return combined, nil
+}
+
+// combine merges the prices of a pair reported by several providers. The exact amount of
+// a provider is only kept when it is the only one reporting the pair. The source time is
+// the oldest one reported, as the combined price is no fresher than its inputs.
+func (a *AggregateExternal) combine(pair domain.Pair, ltps []domain.LTP) domain.LTP {
+	if len(ltps) == 1 {
+		return ltps[0]
+	}
+	var amounts, bids, asks []float64
+	var at time.Time
+	for _, ltp := range ltps {
+		amounts = append(amounts, ltp.Amount)
+		if !ltp.At.IsZero() && (at.IsZero() || ltp.At.Before(at)) {
+			at = ltp.At
+		}
+		// Zero means the provider did not report a bid or ask
+		if ltp.Bid != 0 {
+			bids = append(bids, ltp.Bid)
+		}
+		if ltp.Ask != 0 {
+			asks = append(asks, ltp.Ask)
+		}
+	}
+	return domain.LTP{
+		Pair:   pair,
+		Amount: a.reduce(amounts),
+		Bid:    a.reduce(bids),
+		Ask:    a.reduce(asks),
+		At:     at,
+	}
+}
+
+// reduce combines values according to the mode; zero when there are none
+func (a *AggregateExternal) reduce(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	if a.mode == ModeMedian {
+		sorted := append([]float64(nil), values...)
+		sort.Float64s(sorted)
+		middle := len(sorted) / 2
+		if len(sorted)%2 == 0 {
+			return (sorted[middle-1] + sorted[middle]) / 2
+		}
+		return sorted[middle]
+	}
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
@@ -0,0 +1,230 @@
+package aggregate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-exercise/internal/adapters/fake"
+	"go-exercise/internal/domain"
+	"go-exercise/internal/ports/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregateExternal_GetTickers_CombinesPrices(t *testing.T) {
+	// Arrange
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	pairs := []domain.Pair{btcUSD, btcEUR}
+
+	first := new(mocks.External)
+	first.On("GetTickers", mock.Anything, pairs).Return([]domain.LTP{
+		{Pair: btcUSD, Amount: 52000, RawAmount: "52000.0", Bid: 51990, Ask: 52010},
+		{Pair: btcEUR, Amount: 50000},
+	}, nil)
+	second := new(mocks.External)
+	second.On("GetTickers", mock.Anything, pairs).Return([]domain.LTP{
+		{Pair: btcUSD, Amount: 52100, Bid: 52010, Ask: 52190},
+		{Pair: btcEUR, Amount: 50200},
+	}, nil)
+	client := NewAggregateExternal(ModeMean, first, second)
+
+	// Act
+	ltps, err := client.GetTickers(context.Background(), pairs)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []domain.LTP{
+		{Pair: btcUSD, Amount: 52050, Bid: 52000, Ask: 52100},
+		{Pair: btcEUR, Amount: 50100},
+	}, ltps)
+	first.AssertExpectations(t)
+	second.AssertExpectations(t)
+}
+
+func TestAggregateExternal_GetTickers_KeepsOldestSourceTime(t *testing.T) {
+	// Arrange
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	pairs := []domain.Pair{btcUSD, btcEUR}
+	older := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	newer := older.Add(2 * time.Second)
+
+	first := new(mocks.External)
+	first.On("GetTickers", mock.Anything, pairs).Return([]domain.LTP{
+		{Pair: btcUSD, Amount: 52000, At: newer},
+		{Pair: btcEUR, Amount: 50000},
+	}, nil)
+	second := new(mocks.External)
+	second.On("GetTickers", mock.Anything, pairs).Return([]domain.LTP{
+		{Pair: btcUSD, Amount: 52100, At: older},
+		{Pair: btcEUR, Amount: 50200, At: newer},
+	}, nil)
+	client := NewAggregateExternal(ModeMean, first, second)
+
+	// Act
+	ltps, err := client.GetTickers(context.Background(), pairs)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, ltps, 2)
+	assert.Equal(t, older, ltps[0].At)
+	assert.Equal(t, newer, ltps[1].At, "providers without a time are ignored")
+}
+
+func TestAggregateExternal_GetTickers_MedianIgnoresOutlier(t *testing.T) {
+	// Arrange
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	pairs := []domain.Pair{btcUSD}
+	var providers []*mocks.External
+	for _, amount := range []float64{52000, 52100, 99999} {
+		provider := new(mocks.External)
+		provider.On("GetTickers", mock.Anything, pairs).Return([]domain.LTP{{Pair: btcUSD, Amount: amount}}, nil)
+		providers = append(providers, provider)
+	}
+	median := NewAggregateExternal(ModeMedian, providers[0], providers[1], providers[2])
+	mean := NewAggregateExternal(ModeMean, providers[0], providers[1], providers[2])
+
+	// Act
+	medianLTP, medianErr := median.GetTicker(context.Background(), btcUSD)
+	meanLTP, meanErr := mean.GetTicker(context.Background(), btcUSD)
+
+	// Assert
+	require.NoError(t, medianErr)
+	require.NoError(t, meanErr)
+	assert.Equal(t, 52100.0, medianLTP.Amount)
+	assert.InDelta(t, 68033, meanLTP.Amount, 1)
+}
+
+func TestAggregateExternal_GetTickers_IgnoresFailingProvider(t *testing.T) {
+	// Arrange
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	pairs := []domain.Pair{btcUSD}
+	healthy := new(mocks.External)
+	healthy.On("GetTickers", mock.Anything, pairs).Return([]domain.LTP{
+		{Pair: btcUSD, Amount: 52000.12, RawAmount: "52000.12"},
+	}, nil)
+	failing := new(mocks.External)
+	failing.On("GetTickers", mock.Anything, pairs).Return(nil, errors.New("upstream down"))
+	client := NewAggregateExternal(ModeMean, failing, healthy)
+
+	// Act
+	ltps, err := client.GetTickers(context.Background(), pairs)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []domain.LTP{{Pair: btcUSD, Amount: 52000.12, RawAmount: "52000.12"}}, ltps,
+		"a single price is passed through exactly")
+}
+
+func TestAggregateExternal_GetTickers_Errors(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+
+	t.Run("no pairs", func(t *testing.T) {
+		client := NewAggregateExternal(ModeMean, new(mocks.External))
+
+		_, err := client.GetTickers(context.Background(), nil)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("all providers fail", func(t *testing.T) {
+		rateLimitErr := &domain.RateLimitError{RetryAfter: time.Minute}
+		first := new(mocks.External)
+		first.On("GetTickers", mock.Anything, mock.Anything).Return(nil, errors.New("upstream down"))
+		second := new(mocks.External)
+		second.On("GetTickers", mock.Anything, mock.Anything).Return(nil, rateLimitErr)
+		client := NewAggregateExternal(ModeMean, first, second)
+
+		_, err := client.GetTickers(context.Background(), []domain.Pair{btcUSD})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "all providers failed")
+		assert.Contains(t, err.Error(), "upstream down")
+		assert.ErrorIs(t, err, rateLimitErr)
+	})
+
+	t.Run("pair missing from every result", func(t *testing.T) {
+		provider := new(mocks.External)
+		provider.On("GetTickers", mock.Anything, mock.Anything).Return([]domain.LTP{{Pair: btcUSD, Amount: 52000}}, nil)
+		client := NewAggregateExternal(ModeMean, provider)
+
+		_, err := client.GetTickers(context.Background(), []domain.Pair{btcUSD, btcEUR})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no provider returned a price for BTC/EUR")
+	})
+}
+
+// pairsOnly is a provider that supports only the given pairs
+type pairsOnly struct {
+	*mocks.External
+	pairs []domain.Pair
+}
+
+func (p pairsOnly) SupportedPairs() []domain.Pair {
+	return p.pairs
+}
+
+func TestAggregateExternal_GetTickers_OnlyRequestsSupportedPairs(t *testing.T) {
+	// Arrange
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcCHF, _ := domain.NewPair(domain.BTCCHF)
+	usdOnly := pairsOnly{External: new(mocks.External), pairs: []domain.Pair{btcUSD}}
+	usdOnly.On("GetTickers", mock.Anything, []domain.Pair{btcUSD}).Return([]domain.LTP{{Pair: btcUSD, Amount: 52000}}, nil)
+	everything := new(mocks.External)
+	everything.On("GetTickers", mock.Anything, []domain.Pair{btcUSD, btcCHF}).Return([]domain.LTP{
+		{Pair: btcUSD, Amount: 52200},
+		{Pair: btcCHF, Amount: 49000},
+	}, nil)
+	client := NewAggregateExternal(ModeMean, usdOnly, everything)
+
+	// Act
+	ltps, err := client.GetTickers(context.Background(), []domain.Pair{btcUSD, btcCHF})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []domain.LTP{{Pair: btcUSD, Amount: 52100}, {Pair: btcCHF, Amount: 49000}}, ltps)
+	usdOnly.AssertExpectations(t)
+	everything.AssertExpectations(t)
+}
+
+func TestAggregateExternal_CheckHealth(t *testing.T) {
+	up := fake.NewFakeExternal()
+	down := new(mocks.HealthChecker)
+	down.On("CheckHealth", mock.Anything).Return(domain.HealthDown)
+	downProvider := struct {
+		*mocks.External
+		*mocks.HealthChecker
+	}{new(mocks.External), down}
+
+	tests := []struct {
+		name     string
+		client   *AggregateExternal
+		expected domain.HealthStatus
+	}{
+		{"all up", NewAggregateExternal(ModeMean, up, up).(*AggregateExternal), domain.HealthOK},
+		{"some down", NewAggregateExternal(ModeMean, up, downProvider).(*AggregateExternal), domain.HealthDegraded},
+		{"all down", NewAggregateExternal(ModeMean, downProvider).(*AggregateExternal), domain.HealthDown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.client.CheckHealth(context.Background()))
+		})
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	mode, err := ParseMode(" Median ")
+	require.NoError(t, err)
+	assert.Equal(t, ModeMedian, mode)
+
+	_, err = ParseMode("weighted")
+	assert.ErrorContains(t, err, "must be mean or median")
+}
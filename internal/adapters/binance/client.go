@@ -0,0 +1,221 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"go-exercise/internal/domain"
+	"go-exercise/internal/ports"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer creates the outbound call spans; it is a no-op unless a tracer provider is installed
+var tracer = otel.Tracer("go-exercise/internal/adapters/binance")
+
+// BinanceClient implements the External port for Binance API
+type BinanceClient struct {
+	baseURL    string
+	httpClient *http.Client
+	// symbols maps domain pair values to Binance symbols
+	symbols map[string]string
+}
+
+// BinanceMiniTicker represents a single entry of the /api/v3/ticker response with
+// type=MINI; only the fields used are decoded
+type BinanceMiniTicker struct {
+	Symbol    string `json:"symbol"`
+	LastPrice string `json:"lastPrice"`
+	// CloseTime is when Binance computed the ticker, in Unix milliseconds
+	CloseTime int64 `json:"closeTime"`
+}
+
+// ErrInvalidPrice is returned when Binance reports a price that is not a finite,
+// non-negative number
+var ErrInvalidPrice = errors.New("invalid price")
+
+// DefaultTimeout is the HTTP client timeout used unless configured otherwise
+const DefaultTimeout = 10 * time.Second
+
+// binanceSymbols maps domain pairs to the Binance symbols used in API requests.
+// Binance has no direct BTC/USD market; see WithUSDTAsUSD.
+var binanceSymbols = map[string]string{
+	domain.BTCCHF: "BTCCHF",
+	domain.BTCEUR: "BTCEUR",
+}
+
+// Option configures optional BinanceClient behavior
+type Option func(*BinanceClient)
+
+// WithTimeout sets the timeout of the underlying HTTP client
+func WithTimeout(timeout time.Duration) Option {
+	return func(b *BinanceClient) {
+		b.httpClient.Timeout = timeout
+	}
+}
+
+// WithUSDTAsUSD serves BTC/USD from the BTCUSDT market, treating USDT as equivalent to USD
+func WithUSDTAsUSD() Option {
+	return func(b *BinanceClient) {
+		b.symbols[domain.BTCUSD] = "BTCUSDT"
+	}
+}
+
+// NewBinanceClient creates a new Binance client
+func NewBinanceClient(baseURL string, opts ...Option) ports.External {
+	if baseURL == "" {
+		baseURL = "https://api.binance.com"
+	}
+	b := &BinanceClient{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout: DefaultTimeout,
+		},
+		symbols: make(map[string]string, len(binanceSymbols)+1),
+	}
+	for pair, symbol := range binanceSymbols {
+		b.symbols[pair] = symbol
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// SupportedPairs returns the pairs that have a Binance symbol mapping
+func (b *BinanceClient) SupportedPairs() []domain.Pair {
+	pairs := make([]domain.Pair, 0, len(b.symbols))
+	for value := range b.symbols {
+		if pair, err := domain.NewPair(value); err == nil {
+			pairs = append(pairs, pair)
+		}
+	}
+	return pairs
+}
+
+// Symbol returns the Binance symbol configured for the pair
+func (b *BinanceClient) Symbol(pair domain.Pair) (string, bool) {
+	symbol, ok := b.symbols[pair.Value()]
+	return symbol, ok
+}
+
+// GetTicker retrieves ticker information for a single pair
+func (b *BinanceClient) GetTicker(ctx context.Context, pair domain.Pair) (domain.LTP, error) {
+	ltps, err := b.GetTickers(ctx, []domain.Pair{pair})
+	if err != nil {
+		return domain.LTP{}, err
+	}
+	if len(ltps) == 0 {
+		return domain.LTP{}, fmt.Errorf("no data returned for pair %s", pair.Value())
+	}
+	return ltps[0], nil
+}
+
+// GetTickers retrieves ticker information for multiple pairs in a single request
+func (b *BinanceClient) GetTickers(ctx context.Context, pairs []domain.Pair) (_ []domain.LTP, err error) {
+	if len(pairs) == 0 {
+		return nil, fmt.Errorf("no pairs provided")
+	}
+
+	// Convert pairs to Binance symbols
+	symbols := make([]string, len(pairs))
+	for i, pair := range pairs {
+		symbol, ok := b.symbols[pair.Value()]
+		if !ok {
+			return nil, fmt.Errorf("no Binance symbol for pair %s", pair.Value())
+		}
+		symbols[i] = symbol
+	}
+
+	// Binance expects the symbols as a JSON array, e.g. symbols=["BTCEUR","BTCUSDT"]. The
+	// mini ticker is used rather than /api/v3/ticker/price as it also reports a time.
+	symbolsParam, err := json.Marshal(symbols)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode symbols: %w", err)
+	}
+	requestURL := fmt.Sprintf("%s/api/v3/ticker?type=MINI&symbols=%s", b.baseURL, url.QueryEscape(string(symbolsParam)))
+
+	ctx, span := tracer.Start(ctx, "binance.GetTickers", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("url.full", requestURL),
+		attribute.StringSlice("binance.symbols", symbols),
+	))
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build Binance request: %w", err)
+	}
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call Binance API: %w", err)
+	}
+	defer resp.Body.Close()
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("binance API returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var tickers []BinanceMiniTicker
+	if err := json.Unmarshal(body, &tickers); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	bySymbol := make(map[string]BinanceMiniTicker, len(tickers))
+	for _, ticker := range tickers {
+		bySymbol[ticker.Symbol] = ticker
+	}
+
+	// Map response to domain LTPs
+	result := make([]domain.LTP, 0, len(pairs))
+	for i, pair := range pairs {
+		ticker, ok := bySymbol[symbols[i]]
+		if !ok {
+			return nil, fmt.Errorf("no data found for symbol %s (tried %s)", pair.Value(), symbols[i])
+		}
+
+		amount, err := strconv.ParseFloat(ticker.LastPrice, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse amount for %s (found as %s): %w", pair.Value(), symbols[i], err)
+		}
+		if math.IsNaN(amount) || math.IsInf(amount, 0) || amount < 0 {
+			return nil, fmt.Errorf("%w for %s (found as %s): %s", ErrInvalidPrice, pair.Value(), symbols[i], ticker.LastPrice)
+		}
+
+		ltp := domain.LTP{
+			Pair:      pair,
+			Amount:    amount,
+			RawAmount: domain.ReportedAmount(ticker.LastPrice, amount),
+			Symbol:    symbols[i],
+		}
+		if ticker.CloseTime > 0 {
+			ltp.At = time.UnixMilli(ticker.CloseTime).UTC()
+		}
+		result = append(result, ltp)
+	}
+
+	return result, nil
+}
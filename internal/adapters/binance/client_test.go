@@ -0,0 +1,307 @@
+package binance
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"go-exercise/internal/domain"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// symbolsParam returns a gock-safe pattern for the JSON-encoded symbols query parameter
+func symbolsParam(value string) string {
+	return "^" + regexp.QuoteMeta(value) + "$"
+}
+
+// pairValues returns the string values of the given pairs
+func pairValues(pairs []domain.Pair) []string {
+	values := make([]string, len(pairs))
+	for i, pair := range pairs {
+		values[i] = pair.Value()
+	}
+	return values
+}
+
+func TestNewBinanceClient(t *testing.T) {
+	t.Run("with empty baseURL uses default", func(t *testing.T) {
+		client := NewBinanceClient("")
+		binanceClient, ok := client.(*BinanceClient)
+		require.True(t, ok)
+		assert.Equal(t, "https://api.binance.com", binanceClient.baseURL)
+		assert.Equal(t, DefaultTimeout, binanceClient.httpClient.Timeout)
+	})
+
+	t.Run("with timeout", func(t *testing.T) {
+		binanceClient := NewBinanceClient("", WithTimeout(3*time.Second)).(*BinanceClient)
+		assert.Equal(t, 3*time.Second, binanceClient.httpClient.Timeout)
+	})
+}
+
+func TestBinanceClient_SupportedPairs(t *testing.T) {
+	t.Run("without USDT mapping BTC/USD is unsupported", func(t *testing.T) {
+		pairs := NewBinanceClient("").(*BinanceClient).SupportedPairs()
+		values := pairValues(pairs)
+		assert.ElementsMatch(t, []string{domain.BTCCHF, domain.BTCEUR}, values)
+	})
+
+	t.Run("with USDT mapping BTC/USD is supported", func(t *testing.T) {
+		pairs := NewBinanceClient("", WithUSDTAsUSD()).(*BinanceClient).SupportedPairs()
+		values := pairValues(pairs)
+		assert.ElementsMatch(t, []string{domain.BTCCHF, domain.BTCEUR, domain.BTCUSD}, values)
+	})
+}
+
+func TestBinanceClient_Symbol(t *testing.T) {
+	usd, _ := domain.NewPair(domain.BTCUSD)
+
+	_, ok := NewBinanceClient("").(*BinanceClient).Symbol(usd)
+	assert.False(t, ok)
+
+	symbol, ok := NewBinanceClient("", WithUSDTAsUSD()).(*BinanceClient).Symbol(usd)
+	assert.True(t, ok)
+	assert.Equal(t, "BTCUSDT", symbol)
+}
+
+func TestBinanceClient_GetTicker_Success(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.binance.com").
+		Get("/api/v3/ticker").
+		MatchParam("symbols", symbolsParam(`["BTCEUR"]`)).
+		Reply(200).
+		JSON(`[{"symbol":"BTCEUR","lastPrice":"48000.50000000"}]`)
+
+	client := NewBinanceClient("").(*BinanceClient)
+	pair, _ := domain.NewPair(domain.BTCEUR)
+
+	ltp, err := client.GetTicker(context.Background(), pair)
+
+	require.NoError(t, err)
+	assert.Equal(t, pair, ltp.Pair)
+	assert.Equal(t, 48000.5, ltp.Amount)
+	assert.True(t, gock.IsDone())
+}
+
+func TestBinanceClient_GetTickers_Success_MultiplePairs(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.binance.com").
+		Get("/api/v3/ticker").
+		MatchParam("symbols", symbolsParam(`["BTCUSDT","BTCEUR"]`)).
+		Reply(200).
+		JSON(`[{"symbol":"BTCEUR","lastPrice":"48000.5"},{"symbol":"BTCUSDT","lastPrice":"52000.12"}]`)
+
+	client := NewBinanceClient("", WithUSDTAsUSD()).(*BinanceClient)
+	usd, _ := domain.NewPair(domain.BTCUSD)
+	eur, _ := domain.NewPair(domain.BTCEUR)
+
+	ltps, err := client.GetTickers(context.Background(), []domain.Pair{usd, eur})
+
+	require.NoError(t, err)
+	require.Len(t, ltps, 2)
+	assert.Equal(t, usd, ltps[0].Pair)
+	assert.Equal(t, 52000.12, ltps[0].Amount)
+	assert.Equal(t, "BTCUSDT", ltps[0].Symbol)
+	assert.Equal(t, eur, ltps[1].Pair)
+	assert.Equal(t, 48000.5, ltps[1].Amount)
+	assert.Equal(t, "BTCEUR", ltps[1].Symbol)
+	assert.True(t, gock.IsDone())
+}
+
+func TestBinanceClient_GetTickers_SourceTime(t *testing.T) {
+	defer gock.Off()
+
+	// A full type=MINI response, as Binance sends it
+	gock.New("https://api.binance.com").
+		Get("/api/v3/ticker").
+		MatchParam("type", "^MINI$").
+		MatchParam("symbols", symbolsParam(`["BTCCHF","BTCEUR"]`)).
+		Reply(200).
+		JSON(`[
+			{"symbol":"BTCCHF","openPrice":"46500.00000000","highPrice":"47200.00000000","lowPrice":"46100.00000000","lastPrice":"47000.10000000","volume":"12.50000000","quoteVolume":"584375.00000000","openTime":1704024000000,"closeTime":1704110400123,"firstId":100,"lastId":200,"count":101},
+			{"symbol":"BTCEUR","openPrice":"47500.00000000","highPrice":"48200.00000000","lowPrice":"47100.00000000","lastPrice":"48000.50000000","volume":"30.00000000","quoteVolume":"1440015.00000000","openTime":1704024000000,"closeTime":0,"firstId":-1,"lastId":-1,"count":0}
+		]`)
+
+	client := NewBinanceClient("").(*BinanceClient)
+	btcCHF, _ := domain.NewPair(domain.BTCCHF)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+
+	ltps, err := client.GetTickers(context.Background(), []domain.Pair{btcCHF, btcEUR})
+
+	require.NoError(t, err)
+	require.Len(t, ltps, 2)
+	assert.Equal(t, 47000.1, ltps[0].Amount)
+	assert.Equal(t, "47000.10000000", ltps[0].RawAmount)
+	assert.Equal(t, time.Date(2024, 1, 1, 12, 0, 0, 123_000_000, time.UTC), ltps[0].At)
+	assert.True(t, ltps[1].At.IsZero(), "prices reported without a time keep a zero At")
+	assert.True(t, gock.IsDone())
+}
+
+func TestBinanceClient_GetTickers_USDWithoutUSDTMapping(t *testing.T) {
+	client := NewBinanceClient("").(*BinanceClient)
+	pair, _ := domain.NewPair(domain.BTCUSD)
+
+	_, err := client.GetTickers(context.Background(), []domain.Pair{pair})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no Binance symbol for pair BTC/USD")
+}
+
+func TestBinanceClient_GetTickers_EmptyPairs(t *testing.T) {
+	client := NewBinanceClient("").(*BinanceClient)
+
+	_, err := client.GetTickers(context.Background(), []domain.Pair{})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no pairs provided")
+}
+
+func TestBinanceClient_GetTickers_HTTPError(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.binance.com").
+		Get("/api/v3/ticker").
+		Reply(500).
+		BodyString("Internal Server Error")
+
+	client := NewBinanceClient("").(*BinanceClient)
+	pair, _ := domain.NewPair(domain.BTCEUR)
+
+	_, err := client.GetTickers(context.Background(), []domain.Pair{pair})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "status 500")
+	assert.True(t, gock.IsDone())
+}
+
+func TestBinanceClient_GetTickers_InvalidJSON(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.binance.com").
+		Get("/api/v3/ticker").
+		Reply(200).
+		BodyString("not json")
+
+	client := NewBinanceClient("").(*BinanceClient)
+	pair, _ := domain.NewPair(domain.BTCEUR)
+
+	_, err := client.GetTickers(context.Background(), []domain.Pair{pair})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to unmarshal response")
+	assert.True(t, gock.IsDone())
+}
+
+func TestBinanceClient_GetTickers_NoDataForSymbol(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.binance.com").
+		Get("/api/v3/ticker").
+		Reply(200).
+		JSON(`[{"symbol":"ETHEUR","lastPrice":"3000.0"}]`)
+
+	client := NewBinanceClient("").(*BinanceClient)
+	pair, _ := domain.NewPair(domain.BTCEUR)
+
+	_, err := client.GetTickers(context.Background(), []domain.Pair{pair})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no data found for symbol")
+	assert.True(t, gock.IsDone())
+}
+
+func TestBinanceClient_GetTickers_InvalidAmount(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.binance.com").
+		Get("/api/v3/ticker").
+		Reply(200).
+		JSON(`[{"symbol":"BTCEUR","lastPrice":"abc"}]`)
+
+	client := NewBinanceClient("").(*BinanceClient)
+	pair, _ := domain.NewPair(domain.BTCEUR)
+
+	_, err := client.GetTickers(context.Background(), []domain.Pair{pair})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse amount")
+	assert.True(t, gock.IsDone())
+}
+
+func TestBinanceClient_GetTickers_RejectsInvalidPrices(t *testing.T) {
+	for _, price := range []string{"NaN", "Inf", "-5"} {
+		t.Run(price, func(t *testing.T) {
+			defer gock.Off()
+
+			gock.New("https://api.binance.com").
+				Get("/api/v3/ticker").
+				Reply(200).
+				JSON(`[{"symbol":"BTCEUR","lastPrice":"` + price + `"}]`)
+
+			client := NewBinanceClient("").(*BinanceClient)
+			pair, _ := domain.NewPair(domain.BTCEUR)
+
+			ltps, err := client.GetTickers(context.Background(), []domain.Pair{pair})
+
+			require.ErrorIs(t, err, ErrInvalidPrice)
+			assert.Contains(t, err.Error(), "invalid price for BTC/EUR")
+			assert.Nil(t, ltps)
+			assert.True(t, gock.IsDone())
+		})
+	}
+}
+
+func TestBinanceClient_GetTickers_NormalizesNonJSONRawAmount(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.binance.com").
+		Get("/api/v3/ticker").
+		Reply(200).
+		JSON(`[{"symbol":"BTCEUR","lastPrice":".5"}]`)
+
+	client := NewBinanceClient("").(*BinanceClient)
+	pair, _ := domain.NewPair(domain.BTCEUR)
+
+	ltps, err := client.GetTickers(context.Background(), []domain.Pair{pair})
+
+	require.NoError(t, err)
+	require.Len(t, ltps, 1)
+	assert.Equal(t, 0.5, ltps[0].Amount)
+	assert.Equal(t, "0.5", ltps[0].RawAmount)
+	assert.True(t, gock.IsDone())
+}
+
+func TestBinanceClient_CheckHealth(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		expected domain.HealthStatus
+	}{
+		{"reachable", 200, domain.HealthOK},
+		{"http error", 503, domain.HealthDown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+
+			gock.New("https://api.binance.com").
+				Get("/api/v3/ping").
+				Reply(tt.status).
+				BodyString(`{}`)
+
+			client := NewBinanceClient("").(*BinanceClient)
+
+			status := client.CheckHealth(context.Background())
+
+			assert.Equal(t, tt.expected, status)
+			assert.Equal(t, "binance", client.Name())
+			assert.True(t, gock.IsDone())
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package binance
+
+import (
+	"context"
+	"net/http"
+
+	"go-exercise/internal/domain"
+	"go-exercise/internal/ports"
+)
+
+// Ensure BinanceClient reports its health
+var _ ports.HealthChecker = (*BinanceClient)(nil)
+
+// Name identifies Binance in readiness responses
+func (b *BinanceClient) Name() string {
+	return "binance"
+}
+
+// CheckHealth pings the Binance API
+func (b *BinanceClient) CheckHealth(ctx context.Context) domain.HealthStatus {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.baseURL+"/api/v3/ping", nil)
+	if err != nil {
+		return domain.HealthDown
+	}
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return domain.HealthDown
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return domain.HealthDown
+	}
+	return domain.HealthOK
+}
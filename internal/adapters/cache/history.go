@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"sort"
+	"time"
+
+	"go-exercise/internal/domain"
+)
+
+// ringBuffer is a fixed-size buffer that overwrites its oldest sample when full
+type ringBuffer struct {
+	samples []domain.CachedLTP
+	next    int
+	full    bool
+}
+
+func newRingBuffer(size int) *ringBuffer {
+	return &ringBuffer{samples: make([]domain.CachedLTP, size)}
+}
+
+// add appends a sample, evicting the oldest one when the buffer is full
+func (r *ringBuffer) add(sample domain.CachedLTP) {
+	r.samples[r.next] = sample
+	r.next = (r.next + 1) % len(r.samples)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// len returns the number of stored samples
+func (r *ringBuffer) len() int {
+	if r.full {
+		return len(r.samples)
+	}
+	return r.next
+}
+
+// at returns the i-th stored sample, counting from the oldest
+func (r *ringBuffer) at(i int) domain.CachedLTP {
+	start := 0
+	if r.full {
+		start = r.next
+	}
+	return r.samples[(start+i)%len(r.samples)]
+}
+
+// earliestSince returns the oldest sample stored at or after the given time
+func (r *ringBuffer) earliestSince(since time.Time) (domain.CachedLTP, bool) {
+	i := sort.Search(r.len(), func(i int) bool {
+		return !r.at(i).Timestamp.Before(since)
+	})
+	if i == r.len() {
+		return domain.CachedLTP{}, false
+	}
+	return r.at(i), true
+}
+
+// page returns a copy of up to limit of the newest samples stored strictly before the
+// given time, oldest first, and whether older samples remain. A zero before means no
+// upper bound and a limit <= 0 means no limit. Only the returned samples are copied.
+func (r *ringBuffer) page(before time.Time, limit int) domain.HistoryPage {
+	// Samples are stored in chronological order, so the bound is a binary search
+	end := r.len()
+	if !before.IsZero() {
+		end = sort.Search(end, func(i int) bool {
+			return !r.at(i).Timestamp.Before(before)
+		})
+	}
+	if limit <= 0 || limit > end {
+		limit = end
+	}
+
+	samples := make([]domain.CachedLTP, limit)
+	for i := range samples {
+		samples[i] = r.at(end - limit + i)
+	}
+	return domain.HistoryPage{Samples: samples, More: end > limit}
+}
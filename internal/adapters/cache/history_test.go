@@ -0,0 +1,108 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"go-exercise/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// filledRingBuffer returns a buffer of the given size holding samples with amounts
+// 1..count stored one second apart, starting at base
+func filledRingBuffer(size, count int, base time.Time) *ringBuffer {
+	buf := newRingBuffer(size)
+	for i := 1; i <= count; i++ {
+		buf.add(domain.CachedLTP{
+			LTP:       domain.LTP{Amount: float64(i)},
+			Timestamp: base.Add(time.Duration(i) * time.Second),
+		})
+	}
+	return buf
+}
+
+func pageAmounts(page domain.HistoryPage) []float64 {
+	result := make([]float64, len(page.Samples))
+	for i, sample := range page.Samples {
+		result[i] = sample.LTP.Amount
+	}
+	return result
+}
+
+func TestRingBuffer_Page(t *testing.T) {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	// Holds amounts 3..7 after wrapping around
+	buf := filledRingBuffer(5, 7, base)
+	at := func(amount int) time.Time { return base.Add(time.Duration(amount) * time.Second) }
+
+	tests := []struct {
+		name     string
+		before   time.Time
+		limit    int
+		expected []float64
+		more     bool
+	}{
+		{"first page", time.Time{}, 2, []float64{6, 7}, true},
+		{"middle page", at(6), 2, []float64{4, 5}, true},
+		{"end of data", at(4), 2, []float64{3}, false},
+		{"exact last page", at(5), 2, []float64{3, 4}, false},
+		{"before everything", at(3), 2, []float64{}, false},
+		{"no limit", time.Time{}, 0, []float64{3, 4, 5, 6, 7}, false},
+		{"bound between samples", at(5).Add(time.Millisecond), 10, []float64{3, 4, 5}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := buf.page(tt.before, tt.limit)
+
+			assert.Equal(t, tt.expected, pageAmounts(page))
+			assert.Equal(t, tt.more, page.More)
+		})
+	}
+}
+
+func TestRingBuffer_Page_WalksAllSamplesWithCursor(t *testing.T) {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	buf := filledRingBuffer(4, 6, base)
+
+	var pages [][]float64
+	var before time.Time
+	for {
+		page := buf.page(before, 3)
+		pages = append(pages, pageAmounts(page))
+		if !page.More {
+			break
+		}
+		before = page.Samples[0].Timestamp
+	}
+
+	assert.Equal(t, [][]float64{{4, 5, 6}, {3}}, pages)
+}
+
+func TestRingBuffer_EarliestSince(t *testing.T) {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	// Holds amounts 3..7 after wrapping around
+	buf := filledRingBuffer(5, 7, base)
+	at := func(amount int) time.Time { return base.Add(time.Duration(amount) * time.Second) }
+
+	tests := []struct {
+		name     string
+		since    time.Time
+		expected float64
+		found    bool
+	}{
+		{"before all samples", time.Time{}, 3, true},
+		{"exactly at a sample", at(5), 5, true},
+		{"between samples", at(5).Add(time.Millisecond), 6, true},
+		{"after all samples", at(8), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sample, found := buf.earliestSince(tt.since)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.expected, sample.LTP.Amount)
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package cache
 
 import (
+	"container/list"
 	"sync"
+	"time"
 
 	"go-exercise/internal/domain"
 	"go-exercise/internal/ports"
@@ -9,48 +11,324 @@ import (
 
 // InMemoryCache implements the Repository port using in-memory storage
 type InMemoryCache struct {
-	mu    sync.RWMutex
-	store map[string]*domain.CachedLTP
+	mu     sync.RWMutex
+	store  map[string]*domain.CachedLTP
+	policy domain.TTLPolicy
+	// historySize bounds the per-pair history ring buffers; zero disables history
+	historySize int
+	history     map[string]*ringBuffer
+	// maxEntries caps the number of cached pairs; zero means unbounded.
+	// recency orders pair keys from most to least recently used.
+	maxEntries int
+	recency    *list.List
+	elements   map[string]*list.Element
+	// clock timestamps stored entries and decides their expiry
+	clock ports.Clock
+	// fetchLocks holds a mutex per pair key, serializing GetOrFetch misses of the same
+	// pair. It grows with the number of pairs fetched and is never cleared, as a lock may
+	// be held while the cache is cleared.
+	fetchLocksMu sync.Mutex
+	fetchLocks   map[string]*sync.Mutex
+}
+
+// systemClock is the real wall clock
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// Option configures optional InMemoryCache behavior
+type Option func(*InMemoryCache)
+
+// WithTTL sets the global time-to-live of cached entries
+func WithTTL(ttl time.Duration) Option {
+	return func(c *InMemoryCache) {
+		c.policy.TTL = ttl
+	}
+}
+
+// WithMinTTL sets per-pair minimum residency floors, keyed by pair value, so hot pairs
+// are not refetched more often than the floor even with a shorter global TTL
+func WithMinTTL(minTTL map[string]time.Duration) Option {
+	return func(c *InMemoryCache) {
+		c.policy.MinTTL = minTTL
+	}
+}
+
+// WithHistory keeps the last size stored values of every pair in a ring buffer,
+// available through GetHistory. History is disabled unless size is positive.
+func WithHistory(size int) Option {
+	return func(c *InMemoryCache) {
+		c.historySize = size
+	}
+}
+
+// WithMaxEntries caps the number of cached pairs, evicting the least recently used
+// pair (and its history) when a new pair is stored beyond the cap
+func WithMaxEntries(maxEntries int) Option {
+	return func(c *InMemoryCache) {
+		c.maxEntries = maxEntries
+	}
+}
+
+// WithClock replaces the wall clock used to timestamp and expire entries
+func WithClock(clock ports.Clock) Option {
+	return func(c *InMemoryCache) {
+		c.clock = clock
+	}
 }
 
 // NewInMemoryCache creates a new in-memory cache
-func NewInMemoryCache() ports.Repository {
-	return &InMemoryCache{
-		store: make(map[string]*domain.CachedLTP),
+func NewInMemoryCache(opts ...Option) ports.Repository {
+	c := &InMemoryCache{
+		store:      make(map[string]*domain.CachedLTP),
+		history:    make(map[string]*ringBuffer),
+		recency:    list.New(),
+		elements:   make(map[string]*list.Element),
+		clock:      systemClock{},
+		fetchLocks: make(map[string]*sync.Mutex),
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// NewInMemoryCacheWithCapacity creates an in-memory cache holding at most capacity pairs,
+// evicting the least recently used one when full, with the given TTL. A zero capacity
+// leaves the cache unbounded and a zero TTL means domain.DefaultTTL.
+func NewInMemoryCacheWithCapacity(capacity int, ttl time.Duration) ports.Repository {
+	return NewInMemoryCache(WithMaxEntries(capacity), WithTTL(ttl))
 }
 
 // GetLTP retrieves a cached LTP for a given pair
 func (c *InMemoryCache) GetLTP(pair domain.Pair) (*domain.CachedLTP, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+	// Reads only update recency when the cache is capped, so an unbounded cache is read
+	// under the shared lock and concurrent reads don't serialize
+	if c.maxEntries > 0 {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	} else {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
 
 	cached, exists := c.store[pair.Value()]
 	if !exists {
 		return nil, false
 	}
+	c.touch(pair.Value())
 
 	// Check if expired
-	if cached.IsExpired() {
+	if cached.IsExpiredAt(c.policy, c.clock.Now()) {
 		return nil, false
 	}
 
 	return cached, true
 }
 
+// GetStaleLTP retrieves a cached LTP for a given pair even if it has expired
+func (c *InMemoryCache) GetStaleLTP(pair domain.Pair) (*domain.CachedLTP, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	cached, exists := c.store[pair.Value()]
+	return cached, exists
+}
+
 // SetLTP stores an LTP in the cache
 func (c *InMemoryCache) SetLTP(pair domain.Pair, ltp domain.LTP) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	c.store[pair.Value()] = domain.NewCachedLTP(ltp)
+	c.set(pair, ltp, c.clock.Now())
+}
+
+// SetLTPs stores several LTPs under a single write lock, so readers see either none or
+// all of them, with the same timestamp
+func (c *InMemoryCache) SetLTPs(ltps []domain.LTP) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	for _, ltp := range ltps {
+		c.set(ltp.Pair, ltp, now)
+	}
+}
+
+// GetOrFetch returns the cached LTP of a pair or, when it is missing or expired, fetches
+// and stores it. Concurrent misses of the same pair wait for a single fetch instead of
+// each calling the upstream; misses of different pairs do not block each other. A failed
+// fetch stores nothing and the next caller tries again.
+func (c *InMemoryCache) GetOrFetch(pair domain.Pair, fetch func() (domain.LTP, error)) (*domain.CachedLTP, error) {
+	if cached, ok := c.GetLTP(pair); ok {
+		return cached, nil
+	}
+
+	lock := c.fetchLock(pair.Value())
+	lock.Lock()
+	defer lock.Unlock()
+
+	// The entry may have been repopulated while waiting for the lock
+	if cached, ok := c.GetLTP(pair); ok {
+		return cached, nil
+	}
+	ltp, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.set(pair, ltp, c.clock.Now()), nil
+}
+
+// fetchLock returns the mutex serializing fetches of the pair key, creating it if needed
+func (c *InMemoryCache) fetchLock(key string) *sync.Mutex {
+	c.fetchLocksMu.Lock()
+	defer c.fetchLocksMu.Unlock()
+
+	lock, ok := c.fetchLocks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		c.fetchLocks[key] = lock
+	}
+	return lock
+}
+
+// set stores an LTP taken at the given time and returns the stored entry. Callers must
+// hold the write lock.
+func (c *InMemoryCache) set(pair domain.Pair, ltp domain.LTP, at time.Time) *domain.CachedLTP {
+	cached := domain.NewCachedLTPAt(ltp, at)
+	c.store[pair.Value()] = cached
+	c.touch(pair.Value())
+	c.evict()
+
+	if c.historySize > 0 {
+		buf, ok := c.history[pair.Value()]
+		if !ok {
+			buf = newRingBuffer(c.historySize)
+			c.history[pair.Value()] = buf
+		}
+		buf.add(*cached)
+	}
+	return cached
+}
+
+// SetTTL replaces the global time-to-live of cached entries. Entries already stored
+// expire according to the new TTL from their original timestamps.
+func (c *InMemoryCache) SetTTL(ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.policy.TTL = ttl
+}
+
+// TTLFor returns the TTL the cache currently applies to the pair, including any
+// per-pair floor and TTL reloaded with SetTTL
+func (c *InMemoryCache) TTLFor(pair domain.Pair) time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.policy.For(pair)
+}
+
+// Invalidate removes the cached LTP of a single pair, so the next read fetches it again.
+// Its history is kept, as the recorded prices remain valid samples.
+func (c *InMemoryCache) Invalidate(pair domain.Pair) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := pair.Value()
+	delete(c.store, key)
+	if elem, ok := c.elements[key]; ok {
+		c.recency.Remove(elem)
+		delete(c.elements, key)
+	}
 }
 
-// Clear removes all cached data
+// Clear removes all cached data. The maps are emptied in place under the write lock
+// rather than replaced, so frequent clears reuse their memory instead of allocating.
 func (c *InMemoryCache) Clear() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	c.store = make(map[string]*domain.CachedLTP)
+	clear(c.store)
+	clear(c.history)
+	c.recency.Init()
+	clear(c.elements)
+}
+
+// touch marks a pair as the most recently used. It does nothing unless the cache is capped,
+// in which case callers must hold the write lock.
+func (c *InMemoryCache) touch(key string) {
+	if c.maxEntries <= 0 {
+		return
+	}
+	if elem, ok := c.elements[key]; ok {
+		c.recency.MoveToFront(elem)
+		return
+	}
+	c.elements[key] = c.recency.PushFront(key)
+}
+
+// evict drops least recently used pairs until the cache fits its cap.
+// Callers must hold the write lock.
+func (c *InMemoryCache) evict() {
+	if c.maxEntries <= 0 {
+		return
+	}
+	for c.recency.Len() > c.maxEntries {
+		oldest := c.recency.Back()
+		key := c.recency.Remove(oldest).(string)
+		delete(c.elements, key)
+		delete(c.store, key)
+		delete(c.history, key)
+	}
+}
+
+// Stats reports entry counts and timestamp bounds of the cached data
+func (c *InMemoryCache) Stats() domain.CacheStats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	now := c.clock.Now()
+	stats := domain.CacheStats{Entries: len(c.store)}
+	for _, cached := range c.store {
+		if cached.IsExpiredAt(c.policy, now) {
+			stats.Expired++
+		}
+		if stats.Oldest.IsZero() || cached.Timestamp.Before(stats.Oldest) {
+			stats.Oldest = cached.Timestamp
+		}
+		if stats.Newest.IsZero() || cached.Timestamp.After(stats.Newest) {
+			stats.Newest = cached.Timestamp
+		}
+	}
+
+	return stats
+}
+
+// GetHistory returns up to limit of the newest samples of a pair stored before the given
+// time, oldest first. A zero before means no upper bound and a limit <= 0 means no limit.
+func (c *InMemoryCache) GetHistory(pair domain.Pair, before time.Time, limit int) domain.HistoryPage {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	buf, ok := c.history[pair.Value()]
+	if !ok {
+		return domain.HistoryPage{Samples: []domain.CachedLTP{}}
+	}
+	return buf.page(before, limit)
 }
 
+// GetEarliestSince returns the oldest stored sample of a pair taken at or after since
+func (c *InMemoryCache) GetEarliestSince(pair domain.Pair, since time.Time) (domain.CachedLTP, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	buf, ok := c.history[pair.Value()]
+	if !ok {
+		return domain.CachedLTP{}, false
+	}
+	return buf.earliestSince(since)
+}
@@ -0,0 +1,639 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"testing"
+	"time"
+
+	"go-exercise/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock is a manually advanced clock for deterministic expiry tests
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *fakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+func TestInMemoryCache_GetLTP_DefaultTTL(t *testing.T) {
+	cache := NewInMemoryCache()
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+
+	cache.SetLTP(btcUSD, domain.LTP{Pair: btcUSD, Amount: 52000.12})
+
+	cached, found := cache.GetLTP(btcUSD)
+	assert.True(t, found)
+	assert.Equal(t, 52000.12, cached.LTP.Amount)
+}
+
+func TestInMemoryCache_GetLTP_ExpiresAfterTTL(t *testing.T) {
+	clock := newFakeClock()
+	cache := NewInMemoryCache(WithTTL(10*time.Millisecond), WithClock(clock))
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+
+	cache.SetLTP(btcUSD, domain.LTP{Pair: btcUSD, Amount: 52000.12})
+	clock.Advance(20 * time.Millisecond)
+
+	cached, found := cache.GetLTP(btcUSD)
+	assert.False(t, found)
+	assert.Nil(t, cached)
+}
+
+func TestInMemoryCache_SetTTL(t *testing.T) {
+	// Arrange
+	clock := newFakeClock()
+	cache := NewInMemoryCache(WithTTL(time.Minute), WithClock(clock)).(*InMemoryCache)
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	cache.SetLTP(btcUSD, domain.LTP{Pair: btcUSD, Amount: 52000.12})
+	clock.Advance(20 * time.Second)
+
+	// Act
+	cache.SetTTL(10 * time.Second)
+
+	// Assert
+	_, found := cache.GetLTP(btcUSD)
+	assert.False(t, found, "stored entries expire according to the new TTL")
+	cache.SetLTP(btcUSD, domain.LTP{Pair: btcUSD, Amount: 52000.12})
+	clock.Advance(5 * time.Second)
+	_, found = cache.GetLTP(btcUSD)
+	assert.True(t, found)
+}
+
+func TestInMemoryCache_TTLFor(t *testing.T) {
+	// Arrange
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	cache := NewInMemoryCache(WithTTL(time.Minute), WithMinTTL(map[string]time.Duration{
+		domain.BTCUSD: 2 * time.Minute,
+	})).(*InMemoryCache)
+
+	// Act
+	cache.SetTTL(30 * time.Second)
+
+	// Assert
+	assert.Equal(t, 2*time.Minute, cache.TTLFor(btcUSD), "per-pair floors apply")
+	assert.Equal(t, 30*time.Second, cache.TTLFor(btcEUR), "the reloaded TTL applies")
+}
+
+func TestInMemoryCache_GetLTP_ExpiryBoundary(t *testing.T) {
+	// Arrange
+	clock := newFakeClock()
+	cache := NewInMemoryCache(WithTTL(time.Minute), WithClock(clock))
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	cache.SetLTP(btcUSD, domain.LTP{Pair: btcUSD, Amount: 52000.12})
+	storedAt := clock.Now()
+
+	// Act
+	clock.Advance(time.Minute)
+	cachedAtTTL, foundAtTTL := cache.GetLTP(btcUSD)
+	expiredAtTTL := cache.Stats().Expired
+
+	clock.Advance(time.Nanosecond)
+	cachedPastTTL, foundPastTTL := cache.GetLTP(btcUSD)
+	expiredPastTTL := cache.Stats().Expired
+
+	// Assert
+	assert.True(t, foundAtTTL, "an entry exactly TTL old is still fresh")
+	assert.Equal(t, storedAt, cachedAtTTL.Timestamp)
+	assert.Equal(t, 0, expiredAtTTL)
+
+	assert.False(t, foundPastTTL)
+	assert.Nil(t, cachedPastTTL)
+	assert.Equal(t, 1, expiredPastTTL)
+
+	stale, found := cache.GetStaleLTP(btcUSD)
+	assert.True(t, found)
+	assert.Equal(t, 52000.12, stale.LTP.Amount)
+}
+
+func TestInMemoryCache_GetLTP_MinTTLProtectsHotPair(t *testing.T) {
+	clock := newFakeClock()
+	cache := NewInMemoryCache(
+		WithTTL(10*time.Millisecond),
+		WithMinTTL(map[string]time.Duration{domain.BTCUSD: time.Hour}),
+		WithClock(clock),
+	)
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+
+	cache.SetLTP(btcUSD, domain.LTP{Pair: btcUSD, Amount: 52000.12})
+	cache.SetLTP(btcEUR, domain.LTP{Pair: btcEUR, Amount: 50000.12})
+	clock.Advance(20 * time.Millisecond)
+
+	// The hot pair respects its minimum residency despite the shorter global TTL
+	_, found := cache.GetLTP(btcUSD)
+	assert.True(t, found)
+
+	// Other pairs follow the global TTL
+	_, found = cache.GetLTP(btcEUR)
+	assert.False(t, found)
+}
+
+func TestInMemoryCache_GetLTP_MinTTLShorterThanGlobalIsIgnored(t *testing.T) {
+	clock := newFakeClock()
+	cache := NewInMemoryCache(
+		WithTTL(time.Hour),
+		WithMinTTL(map[string]time.Duration{domain.BTCUSD: time.Millisecond}),
+		WithClock(clock),
+	)
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+
+	cache.SetLTP(btcUSD, domain.LTP{Pair: btcUSD, Amount: 52000.12})
+	clock.Advance(10 * time.Millisecond)
+
+	_, found := cache.GetLTP(btcUSD)
+	assert.True(t, found)
+}
+
+func TestInMemoryCache_GetHistory_DisabledByDefault(t *testing.T) {
+	cache := NewInMemoryCache()
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+
+	cache.SetLTP(btcUSD, domain.LTP{Pair: btcUSD, Amount: 52000.12})
+
+	history := cache.GetHistory(btcUSD, time.Time{}, 10).Samples
+	assert.NotNil(t, history)
+	assert.Empty(t, history)
+}
+
+func TestInMemoryCache_GetHistory_KeepsNewestSamplesOldestFirst(t *testing.T) {
+	cache := NewInMemoryCache(WithHistory(3))
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+
+	for _, amount := range []float64{1, 2, 3, 4, 5} {
+		cache.SetLTP(btcUSD, domain.LTP{Pair: btcUSD, Amount: amount})
+	}
+	cache.SetLTP(btcEUR, domain.LTP{Pair: btcEUR, Amount: 50000.12})
+
+	amounts := func(history []domain.CachedLTP) []float64 {
+		result := make([]float64, len(history))
+		for i, sample := range history {
+			result[i] = sample.LTP.Amount
+		}
+		return result
+	}
+
+	// The buffer is bounded: the two oldest samples were evicted
+	assert.Equal(t, []float64{3, 4, 5}, amounts(cache.GetHistory(btcUSD, time.Time{}, 0).Samples))
+	assert.Equal(t, []float64{4, 5}, amounts(cache.GetHistory(btcUSD, time.Time{}, 2).Samples))
+	assert.Equal(t, []float64{3, 4, 5}, amounts(cache.GetHistory(btcUSD, time.Time{}, 50).Samples))
+	// Pairs have independent buffers
+	assert.Equal(t, []float64{50000.12}, amounts(cache.GetHistory(btcEUR, time.Time{}, 50).Samples))
+
+	history := cache.GetHistory(btcUSD, time.Time{}, 0).Samples
+	assert.False(t, history[0].Timestamp.After(history[2].Timestamp))
+}
+
+func TestInMemoryCache_GetEarliestSince(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	start := time.Now()
+
+	t.Run("returns the oldest sample in the window", func(t *testing.T) {
+		cache := NewInMemoryCache(WithHistory(3))
+		for _, amount := range []float64{1, 2, 3} {
+			cache.SetLTP(btcUSD, domain.LTP{Pair: btcUSD, Amount: amount})
+		}
+
+		sample, found := cache.GetEarliestSince(btcUSD, start)
+		assert.True(t, found)
+		assert.Equal(t, 1.0, sample.LTP.Amount)
+
+		_, found = cache.GetEarliestSince(btcUSD, time.Now().Add(time.Second))
+		assert.False(t, found)
+	})
+
+	t.Run("reports none without history", func(t *testing.T) {
+		cache := NewInMemoryCache()
+		cache.SetLTP(btcUSD, domain.LTP{Pair: btcUSD, Amount: 52000.12})
+
+		_, found := cache.GetEarliestSince(btcUSD, start)
+		assert.False(t, found)
+	})
+}
+
+func TestInMemoryCache_Clear_DropsHistory(t *testing.T) {
+	cache := NewInMemoryCache(WithHistory(3))
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+
+	cache.SetLTP(btcUSD, domain.LTP{Pair: btcUSD, Amount: 52000.12})
+	cache.Clear()
+
+	assert.Empty(t, cache.GetHistory(btcUSD, time.Time{}, 0).Samples)
+}
+
+func TestInMemoryCache_MaxEntries_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewInMemoryCache(WithMaxEntries(2), WithHistory(5))
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	btcCHF, _ := domain.NewPair(domain.BTCCHF)
+
+	cache.SetLTP(btcUSD, domain.LTP{Pair: btcUSD, Amount: 52000.12})
+	cache.SetLTP(btcEUR, domain.LTP{Pair: btcEUR, Amount: 50000.12})
+
+	// Reading BTC/USD makes BTC/EUR the least recently used entry
+	_, found := cache.GetLTP(btcUSD)
+	assert.True(t, found)
+
+	cache.SetLTP(btcCHF, domain.LTP{Pair: btcCHF, Amount: 49000.12})
+
+	_, found = cache.GetStaleLTP(btcEUR)
+	assert.False(t, found)
+	assert.Empty(t, cache.GetHistory(btcEUR, time.Time{}, 0).Samples)
+
+	_, found = cache.GetLTP(btcUSD)
+	assert.True(t, found)
+	_, found = cache.GetLTP(btcCHF)
+	assert.True(t, found)
+	assert.Equal(t, 2, cache.Stats().Entries)
+}
+
+func TestInMemoryCache_MaxEntries_UpdatingExistingPairDoesNotEvict(t *testing.T) {
+	cache := NewInMemoryCache(WithMaxEntries(2))
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+
+	cache.SetLTP(btcUSD, domain.LTP{Pair: btcUSD, Amount: 52000.12})
+	cache.SetLTP(btcEUR, domain.LTP{Pair: btcEUR, Amount: 50000.12})
+	cache.SetLTP(btcUSD, domain.LTP{Pair: btcUSD, Amount: 52100.00})
+
+	assert.Equal(t, 2, cache.Stats().Entries)
+	cached, found := cache.GetLTP(btcUSD)
+	assert.True(t, found)
+	assert.Equal(t, 52100.00, cached.LTP.Amount)
+	_, found = cache.GetLTP(btcEUR)
+	assert.True(t, found)
+}
+
+func TestNewInMemoryCacheWithCapacity(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	btcCHF, _ := domain.NewPair(domain.BTCCHF)
+
+	t.Run("evicts in least recently used order", func(t *testing.T) {
+		cache := NewInMemoryCacheWithCapacity(2, time.Minute)
+
+		cache.SetLTP(btcUSD, domain.LTP{Pair: btcUSD, Amount: 52000.12})
+		cache.SetLTP(btcEUR, domain.LTP{Pair: btcEUR, Amount: 50000.12})
+		cache.SetLTP(btcCHF, domain.LTP{Pair: btcCHF, Amount: 49000.12})
+
+		// BTC/USD was inserted first and never read
+		_, found := cache.GetStaleLTP(btcUSD)
+		assert.False(t, found)
+
+		// Reading BTC/EUR leaves BTC/CHF as the next victim
+		_, found = cache.GetLTP(btcEUR)
+		assert.True(t, found)
+		cache.SetLTP(btcUSD, domain.LTP{Pair: btcUSD, Amount: 52000.12})
+
+		_, found = cache.GetStaleLTP(btcCHF)
+		assert.False(t, found)
+		_, found = cache.GetLTP(btcEUR)
+		assert.True(t, found)
+		_, found = cache.GetLTP(btcUSD)
+		assert.True(t, found)
+		assert.Equal(t, 2, cache.Stats().Entries)
+	})
+
+	t.Run("zero capacity is unbounded", func(t *testing.T) {
+		cache := NewInMemoryCacheWithCapacity(0, time.Minute)
+
+		cache.SetLTP(btcUSD, domain.LTP{Pair: btcUSD, Amount: 52000.12})
+		cache.SetLTP(btcEUR, domain.LTP{Pair: btcEUR, Amount: 50000.12})
+		cache.SetLTP(btcCHF, domain.LTP{Pair: btcCHF, Amount: 49000.12})
+
+		assert.Equal(t, 3, cache.Stats().Entries)
+	})
+
+	t.Run("applies the TTL", func(t *testing.T) {
+		cache := NewInMemoryCacheWithCapacity(2, time.Millisecond)
+
+		cache.SetLTP(btcUSD, domain.LTP{Pair: btcUSD, Amount: 52000.12})
+		time.Sleep(5 * time.Millisecond)
+
+		_, found := cache.GetLTP(btcUSD)
+		assert.False(t, found)
+	})
+}
+
+func TestInMemoryCache_GetLTP_UnboundedTakesReadLock(t *testing.T) {
+	// Arrange
+	cache := NewInMemoryCache().(*InMemoryCache)
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	cache.SetLTP(btcUSD, domain.LTP{Pair: btcUSD, Amount: 52000.12})
+	cache.mu.RLock()
+	defer cache.mu.RUnlock()
+
+	// Act
+	found := make(chan bool)
+	go func() {
+		_, ok := cache.GetLTP(btcUSD)
+		found <- ok
+	}()
+
+	// Assert
+	select {
+	case ok := <-found:
+		assert.True(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("GetLTP waited for the write lock although the cache tracks no recency")
+	}
+}
+
+func TestInMemoryCache_MaxEntries_ConcurrentAccess(t *testing.T) {
+	cache := NewInMemoryCache(WithMaxEntries(2))
+	pairs := []string{domain.BTCUSD, domain.BTCEUR, domain.BTCCHF}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			pair, _ := domain.NewPair(pairs[i%len(pairs)])
+			cache.SetLTP(pair, domain.LTP{Pair: pair, Amount: float64(i)})
+			cache.GetLTP(pair)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, cache.Stats().Entries, 2)
+}
+
+func TestInMemoryCache_SetLTPs_StoresAllEntries(t *testing.T) {
+	clock := newFakeClock()
+	cache := NewInMemoryCache(WithClock(clock), WithHistory(2))
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+
+	cache.SetLTPs([]domain.LTP{
+		{Pair: btcUSD, Amount: 52000.12},
+		{Pair: btcEUR, Amount: 50000.12},
+	})
+
+	for pair, amount := range map[domain.Pair]float64{btcUSD: 52000.12, btcEUR: 50000.12} {
+		cached, found := cache.GetLTP(pair)
+		assert.True(t, found)
+		assert.Equal(t, amount, cached.LTP.Amount)
+		assert.Equal(t, clock.Now(), cached.Timestamp)
+		assert.Len(t, cache.GetHistory(pair, time.Time{}, 0).Samples, 1)
+	}
+	assert.Equal(t, 2, cache.Stats().Entries)
+}
+
+func TestInMemoryCache_SetLTPs_IsAtomic(t *testing.T) {
+	cache := NewInMemoryCache()
+	var ltps []domain.LTP
+	for _, value := range []string{domain.BTCUSD, domain.BTCEUR, domain.BTCCHF} {
+		pair, _ := domain.NewPair(value)
+		ltps = append(ltps, domain.LTP{Pair: pair, Amount: 1})
+	}
+
+	// Readers must never observe a partially applied batch
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				if entries := cache.Stats().Entries; entries != 0 && entries != len(ltps) {
+					t.Errorf("observed %d of %d entries", entries, len(ltps))
+					return
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 200; i++ {
+		cache.SetLTPs(ltps)
+		cache.Clear()
+	}
+	close(stop)
+	wg.Wait()
+}
+
+func TestInMemoryCache_Clear_ConcurrentWithReadsAndWrites(t *testing.T) {
+	cache := NewInMemoryCache(WithHistory(4), WithMaxEntries(2))
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	btcCHF, _ := domain.NewPair(domain.BTCCHF)
+	pairs := []domain.Pair{btcUSD, btcEUR, btcCHF}
+
+	var wg sync.WaitGroup
+	for i, pair := range pairs {
+		wg.Add(2)
+		go func(pair domain.Pair, amount float64) {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				cache.SetLTP(pair, domain.LTP{Pair: pair, Amount: amount})
+			}
+		}(pair, float64(50000+i))
+		go func(pair domain.Pair) {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				if cached, found := cache.GetLTP(pair); found {
+					assert.Equal(t, pair, cached.LTP.Pair)
+				}
+			}
+		}(pair)
+	}
+	for i := 0; i < 200; i++ {
+		cache.Clear()
+	}
+	wg.Wait()
+
+	// The cache stays usable and within its bounds after clears raced with writes
+	assert.LessOrEqual(t, cache.Stats().Entries, 2)
+	cache.Clear()
+	assert.Zero(t, cache.Stats().Entries)
+	cache.SetLTP(btcUSD, domain.LTP{Pair: btcUSD, Amount: 52000.12})
+	_, found := cache.GetLTP(btcUSD)
+	assert.True(t, found)
+}
+
+// clearByReplacing is the previous Clear, kept to benchmark against clearing in place
+func (c *InMemoryCache) clearByReplacing() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.store = make(map[string]*domain.CachedLTP)
+	c.history = make(map[string]*ringBuffer)
+	c.recency.Init()
+	c.elements = make(map[string]*list.Element)
+}
+
+func BenchmarkInMemoryCache_Clear(b *testing.B) {
+	var ltps []domain.LTP
+	for _, value := range domain.ValidPairs() {
+		pair, _ := domain.NewPair(value)
+		ltps = append(ltps, domain.LTP{Pair: pair, Amount: 52000.12})
+	}
+
+	benchmarks := []struct {
+		name  string
+		clear func(*InMemoryCache)
+	}{
+		{"in place", (*InMemoryCache).Clear},
+		{"replacing maps", (*InMemoryCache).clearByReplacing},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			cache := NewInMemoryCache(WithHistory(8), WithMaxEntries(len(ltps))).(*InMemoryCache)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				cache.SetLTPs(ltps)
+				bm.clear(cache)
+			}
+		})
+	}
+}
+
+func TestInMemoryCache_Invalidate(t *testing.T) {
+	cache := NewInMemoryCache(WithHistory(2), WithMaxEntries(2))
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	btcCHF, _ := domain.NewPair(domain.BTCCHF)
+	cache.SetLTP(btcUSD, domain.LTP{Pair: btcUSD, Amount: 52000.12})
+	cache.SetLTP(btcEUR, domain.LTP{Pair: btcEUR, Amount: 50000.12})
+
+	cache.Invalidate(btcUSD)
+
+	_, found := cache.GetLTP(btcUSD)
+	assert.False(t, found)
+	_, found = cache.GetStaleLTP(btcUSD)
+	assert.False(t, found)
+	_, found = cache.GetLTP(btcEUR)
+	assert.True(t, found)
+	assert.Len(t, cache.GetHistory(btcUSD, time.Time{}, 0).Samples, 1)
+
+	// The freed slot is reused without evicting the remaining entry
+	cache.SetLTP(btcCHF, domain.LTP{Pair: btcCHF, Amount: 49000.12})
+	assert.Equal(t, 2, cache.Stats().Entries)
+	_, found = cache.GetLTP(btcEUR)
+	assert.True(t, found)
+}
+
+func TestInMemoryCache_GetOrFetch(t *testing.T) {
+	pair, _ := domain.NewPair(domain.BTCUSD)
+
+	t.Run("fresh entry is served without fetching", func(t *testing.T) {
+		// Arrange
+		cache := NewInMemoryCache()
+		cache.SetLTP(pair, domain.LTP{Pair: pair, Amount: 50000})
+
+		// Act
+		cached, err := cache.GetOrFetch(pair, func() (domain.LTP, error) {
+			t.Fatal("fetch must not be called for a fresh entry")
+			return domain.LTP{}, nil
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 50000.0, cached.LTP.Amount)
+	})
+
+	t.Run("miss fetches and stores", func(t *testing.T) {
+		// Arrange
+		clock := newFakeClock()
+		cache := NewInMemoryCache(WithClock(clock))
+
+		// Act
+		cached, err := cache.GetOrFetch(pair, func() (domain.LTP, error) {
+			return domain.LTP{Pair: pair, Amount: 51000}, nil
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 51000.0, cached.LTP.Amount)
+		assert.Equal(t, clock.Now(), cached.Timestamp)
+		stored, ok := cache.GetLTP(pair)
+		require.True(t, ok)
+		assert.Equal(t, 51000.0, stored.LTP.Amount)
+	})
+
+	t.Run("failed fetch stores nothing", func(t *testing.T) {
+		// Arrange
+		cache := NewInMemoryCache()
+
+		// Act
+		cached, err := cache.GetOrFetch(pair, func() (domain.LTP, error) {
+			return domain.LTP{}, domain.ErrUpstreamUnavailable
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrUpstreamUnavailable)
+		assert.Nil(t, cached)
+		_, ok := cache.GetStaleLTP(pair)
+		assert.False(t, ok)
+	})
+}
+
+func TestInMemoryCache_GetOrFetch_FetchesOncePerExpiredKey(t *testing.T) {
+	// Arrange
+	clock := newFakeClock()
+	cache := NewInMemoryCache(WithTTL(time.Minute), WithClock(clock))
+	values := []string{domain.BTCUSD, domain.BTCEUR, domain.BTCCHF}
+	pairs := make([]domain.Pair, len(values))
+	for i, value := range values {
+		pairs[i], _ = domain.NewPair(value)
+		cache.SetLTP(pairs[i], domain.LTP{Pair: pairs[i], Amount: 1})
+	}
+	clock.Advance(2 * time.Minute)
+
+	var mu sync.Mutex
+	fetches := make(map[string]int)
+	fetcher := func(pair domain.Pair) func() (domain.LTP, error) {
+		return func() (domain.LTP, error) {
+			mu.Lock()
+			fetches[pair.Value()]++
+			mu.Unlock()
+			// Keep the fetch in flight long enough for the other callers to pile up
+			time.Sleep(20 * time.Millisecond)
+			return domain.LTP{Pair: pair, Amount: 2}, nil
+		}
+	}
+
+	// Act
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 60; i++ {
+		wg.Add(1)
+		go func(pair domain.Pair) {
+			defer wg.Done()
+			<-start
+			cached, err := cache.GetOrFetch(pair, fetcher(pair))
+			assert.NoError(t, err)
+			assert.Equal(t, 2.0, cached.LTP.Amount)
+		}(pairs[i%len(pairs)])
+	}
+	close(start)
+	wg.Wait()
+
+	// Assert
+	assert.Equal(t, map[string]int{domain.BTCUSD: 1, domain.BTCEUR: 1, domain.BTCCHF: 1}, fetches)
+}
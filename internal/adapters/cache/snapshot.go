@@ -0,0 +1,106 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go-exercise/internal/domain"
+)
+
+// snapshotEntry is a cached price as persisted in a snapshot file
+type snapshotEntry struct {
+	Pair      string    `json:"pair"`
+	Amount    float64   `json:"amount"`
+	RawAmount string    `json:"raw_amount,omitempty"`
+	Symbol    string    `json:"symbol,omitempty"`
+	Bid       float64   `json:"bid,omitempty"`
+	Ask       float64   `json:"ask,omitempty"`
+	At        time.Time `json:"at,omitzero"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Save writes the cached prices to a JSON file, so a restarted process can Load them
+// instead of fetching them again. History is not saved. The file is replaced atomically.
+func (c *InMemoryCache) Save(path string) error {
+	c.mu.RLock()
+	entries := make([]snapshotEntry, 0, len(c.store))
+	for key, cached := range c.store {
+		entries = append(entries, snapshotEntry{
+			Pair:      key,
+			Amount:    cached.LTP.Amount,
+			RawAmount: cached.LTP.RawAmount,
+			Symbol:    cached.LTP.Symbol,
+			Bid:       cached.LTP.Bid,
+			Ask:       cached.LTP.Ask,
+			At:        cached.LTP.At,
+			Timestamp: cached.Timestamp,
+		})
+	}
+	c.mu.RUnlock()
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache snapshot: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create cache snapshot: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write cache snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cache snapshot: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace cache snapshot: %w", err)
+	}
+	return nil
+}
+
+// Load stores the prices of a snapshot written by Save, keeping their original timestamps
+// so they expire as if the process had kept running. Expired prices and pairs that are no
+// longer valid are dropped. It returns how many prices were restored.
+func (c *InMemoryCache) Load(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read cache snapshot: %w", err)
+	}
+	var entries []snapshotEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal cache snapshot: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	loaded := 0
+	for _, entry := range entries {
+		pair, err := domain.NewPair(entry.Pair)
+		if err != nil {
+			continue
+		}
+		ltp := domain.LTP{
+			Pair:      pair,
+			Amount:    entry.Amount,
+			RawAmount: entry.RawAmount,
+			Symbol:    entry.Symbol,
+			Bid:       entry.Bid,
+			Ask:       entry.Ask,
+			At:        entry.At,
+		}
+		if domain.NewCachedLTPAt(ltp, entry.Timestamp).IsExpiredAt(c.policy, now) {
+			continue
+		}
+		c.set(pair, ltp, entry.Timestamp)
+		loaded++
+	}
+	return loaded, nil
+}
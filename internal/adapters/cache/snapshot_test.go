@@ -0,0 +1,117 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-exercise/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryCache_SaveLoad_RoundTrip(t *testing.T) {
+	// Arrange
+	clock := newFakeClock()
+	saved := NewInMemoryCache(WithTTL(time.Minute), WithClock(clock)).(*InMemoryCache)
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+
+	saved.SetLTP(btcEUR, domain.LTP{Pair: btcEUR, Amount: 50000.12})
+	clock.Advance(50 * time.Second)
+	sourceAt := time.Date(2024, 1, 1, 11, 59, 58, 0, time.UTC)
+	saved.SetLTP(btcUSD, domain.LTP{Pair: btcUSD, Amount: 52000.12, RawAmount: "52000.12000", Bid: 52000, Ask: 52001, At: sourceAt, Cached: true})
+	storedUSDAt := clock.Now()
+	path := filepath.Join(t.TempDir(), "cache.json")
+
+	// Act
+	require.NoError(t, saved.Save(path))
+	// By the time the snapshot is loaded, BTC/EUR has outlived its TTL
+	clock.Advance(20 * time.Second)
+	loaded := NewInMemoryCache(WithTTL(time.Minute), WithClock(clock)).(*InMemoryCache)
+	n, err := loaded.Load(path)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	cached, found := loaded.GetLTP(btcUSD)
+	require.True(t, found)
+	assert.Equal(t, domain.LTP{Pair: btcUSD, Amount: 52000.12, RawAmount: "52000.12000", Bid: 52000, Ask: 52001, At: sourceAt}, cached.LTP)
+	assert.True(t, cached.Timestamp.Equal(storedUSDAt), "the original timestamp is kept")
+
+	_, found = loaded.GetStaleLTP(btcEUR)
+	assert.False(t, found, "expired entries are dropped, not loaded as stale")
+
+	// The restored price still expires at its original time
+	clock.Advance(41 * time.Second)
+	_, found = loaded.GetLTP(btcUSD)
+	assert.False(t, found)
+}
+
+func TestInMemoryCache_Load_SkipsInvalidPairs(t *testing.T) {
+	// Arrange
+	clock := newFakeClock()
+	path := filepath.Join(t.TempDir(), "cache.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[
+		{"pair":"BTC/USD","amount":52000.12,"timestamp":"2024-01-01T12:00:00Z"},
+		{"pair":"DOGE/XYZ","amount":1,"timestamp":"2024-01-01T12:00:00Z"}
+	]`), 0o600))
+	cache := NewInMemoryCache(WithClock(clock)).(*InMemoryCache)
+
+	// Act
+	n, err := cache.Load(path)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, 1, cache.Stats().Entries)
+}
+
+func TestInMemoryCache_Load_Errors(t *testing.T) {
+	dir := t.TempDir()
+	malformed := filepath.Join(dir, "malformed.json")
+	require.NoError(t, os.WriteFile(malformed, []byte(`{`), 0o600))
+
+	tests := []struct {
+		name        string
+		path        string
+		expectedErr string
+	}{
+		{"missing file", filepath.Join(dir, "missing.json"), "failed to read cache snapshot"},
+		{"malformed file", malformed, "failed to unmarshal cache snapshot"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := NewInMemoryCache().(*InMemoryCache)
+
+			n, err := cache.Load(tt.path)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedErr)
+			assert.Zero(t, n)
+		})
+	}
+}
+
+func TestInMemoryCache_Save_ReplacesFile(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "cache.json")
+	require.NoError(t, os.WriteFile(path, []byte(`stale contents`), 0o600))
+	cache := NewInMemoryCache().(*InMemoryCache)
+
+	// Act
+	err := cache.Save(path)
+
+	// Assert
+	require.NoError(t, err)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[]`, string(data))
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no temporary file is left behind")
+}
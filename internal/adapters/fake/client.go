@@ -0,0 +1,128 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+
+	"go-exercise/internal/domain"
+	"go-exercise/internal/ports"
+)
+
+// DefaultPrice is the starting price of pairs without an entry in defaultPrices
+const DefaultPrice = 50000.0
+
+// defaultPrices holds plausible starting prices for the built-in pairs
+var defaultPrices = map[string]float64{
+	domain.BTCUSD: 52000,
+	domain.BTCCHF: 49000,
+	domain.BTCEUR: 50000,
+}
+
+// FakeExternal implements the External port without any network calls.
+// Prices are fixed unless a random walk is enabled with WithRandomWalk.
+type FakeExternal struct {
+	mu     sync.Mutex
+	prices map[string]float64
+	// step is the maximum relative price change per fetch; zero keeps prices fixed
+	step float64
+	rng  *rand.Rand
+}
+
+// Option configures optional FakeExternal behavior
+type Option func(*FakeExternal)
+
+// WithPrice sets the starting price of a pair
+func WithPrice(pair string, amount float64) Option {
+	return func(f *FakeExternal) {
+		f.prices[pair] = amount
+	}
+}
+
+// WithRandomWalk moves each price by up to step (e.g. 0.01 for ±1%) on every fetch.
+// The seed makes the walk reproducible.
+func WithRandomWalk(step float64, seed int64) Option {
+	return func(f *FakeExternal) {
+		f.step = step
+		f.rng = rand.New(rand.NewSource(seed))
+	}
+}
+
+// NewFakeExternal creates a fake price provider for local development and tests
+func NewFakeExternal(opts ...Option) ports.External {
+	f := &FakeExternal{
+		prices: make(map[string]float64, len(defaultPrices)),
+	}
+	for pair, amount := range defaultPrices {
+		f.prices[pair] = amount
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// SupportedPairs returns every configured valid pair
+func (f *FakeExternal) SupportedPairs() []domain.Pair {
+	values := domain.ValidPairs()
+	pairs := make([]domain.Pair, 0, len(values))
+	for _, value := range values {
+		if pair, err := domain.NewPair(value); err == nil {
+			pairs = append(pairs, pair)
+		}
+	}
+	return pairs
+}
+
+// Name identifies the fake provider in readiness responses
+func (f *FakeExternal) Name() string {
+	return "fake"
+}
+
+// CheckHealth always reports the fake provider as healthy
+func (f *FakeExternal) CheckHealth(ctx context.Context) domain.HealthStatus {
+	return domain.HealthOK
+}
+
+// GetTicker returns the current fake price of a single pair
+func (f *FakeExternal) GetTicker(ctx context.Context, pair domain.Pair) (domain.LTP, error) {
+	ltps, err := f.GetTickers(ctx, []domain.Pair{pair})
+	if err != nil {
+		return domain.LTP{}, err
+	}
+	return ltps[0], nil
+}
+
+// GetTickers returns the current fake prices of the given pairs
+func (f *FakeExternal) GetTickers(ctx context.Context, pairs []domain.Pair) ([]domain.LTP, error) {
+	if len(pairs) == 0 {
+		return nil, fmt.Errorf("no pairs provided")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	result := make([]domain.LTP, 0, len(pairs))
+	for _, pair := range pairs {
+		amount, ok := f.prices[pair.Value()]
+		if !ok {
+			amount = DefaultPrice
+		}
+		if f.step > 0 {
+			// Round to cents like a real quote
+			amount = math.Round(amount*(1+f.step*(2*f.rng.Float64()-1))*100) / 100
+		}
+		f.prices[pair.Value()] = amount
+
+		result = append(result, domain.LTP{
+			Pair:   pair,
+			Amount: amount,
+		})
+	}
+	return result, nil
+}
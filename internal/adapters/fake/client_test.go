@@ -0,0 +1,110 @@
+package fake
+
+import (
+	"context"
+	"testing"
+
+	"go-exercise/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func allPairs(t *testing.T) []domain.Pair {
+	t.Helper()
+	var pairs []domain.Pair
+	for _, value := range domain.ValidPairs() {
+		pair, err := domain.NewPair(value)
+		require.NoError(t, err)
+		pairs = append(pairs, pair)
+	}
+	return pairs
+}
+
+func TestFakeExternal_GetTickers_ReturnsAllRequestedPairs(t *testing.T) {
+	// Arrange
+	client := NewFakeExternal()
+	pairs := allPairs(t)
+
+	// Act
+	ltps, err := client.GetTickers(context.Background(), pairs)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, ltps, len(pairs))
+	for i, ltp := range ltps {
+		assert.Equal(t, pairs[i], ltp.Pair)
+		assert.Equal(t, defaultPrices[pairs[i].Value()], ltp.Amount)
+	}
+}
+
+func TestFakeExternal_GetTicker_Deterministic(t *testing.T) {
+	// Arrange
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	client := NewFakeExternal(WithPrice(domain.BTCEUR, 12345.67))
+
+	// Act
+	first, err1 := client.GetTicker(context.Background(), btcEUR)
+	second, err2 := client.GetTicker(context.Background(), btcEUR)
+
+	// Assert
+	require.NoError(t, err1)
+	require.NoError(t, err2)
+	assert.Equal(t, 12345.67, first.Amount)
+	assert.Equal(t, first, second)
+}
+
+func TestFakeExternal_RandomWalk(t *testing.T) {
+	// Arrange
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	walk := func() []float64 {
+		client := NewFakeExternal(WithPrice(domain.BTCUSD, 1000), WithRandomWalk(0.01, 42))
+		var amounts []float64
+		for i := 0; i < 5; i++ {
+			ltp, err := client.GetTicker(context.Background(), btcUSD)
+			require.NoError(t, err)
+			amounts = append(amounts, ltp.Amount)
+		}
+		return amounts
+	}
+
+	// Act
+	first := walk()
+	second := walk()
+
+	// Assert
+	assert.Equal(t, first, second, "same seed should produce the same walk")
+	previous := 1000.0
+	for _, amount := range first {
+		assert.InDelta(t, previous, amount, previous*0.01+0.01)
+		previous = amount
+	}
+	assert.NotEqual(t, []float64{1000, 1000, 1000, 1000, 1000}, first)
+}
+
+func TestFakeExternal_Errors(t *testing.T) {
+	client := NewFakeExternal()
+
+	t.Run("no pairs", func(t *testing.T) {
+		_, err := client.GetTickers(context.Background(), nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := client.GetTickers(ctx, allPairs(t))
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestFakeExternal_SupportedPairs(t *testing.T) {
+	// Arrange
+	client := NewFakeExternal()
+
+	// Act
+	pairs := client.(interface{ SupportedPairs() []domain.Pair }).SupportedPairs()
+
+	// Assert
+	assert.Equal(t, allPairs(t), pairs)
+}
@@ -0,0 +1,32 @@
+package http
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go-exercise/internal/adapters/http/dto"
+)
+
+// HeaderAPIKey carries the client's API key when authentication is enabled
+const HeaderAPIKey = "X-API-Key"
+
+// apiKeyMiddleware rejects requests that do not present one of the given keys
+func apiKeyMiddleware(keys []string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			presented := []byte(c.Request().Header.Get(HeaderAPIKey))
+			if len(presented) > 0 {
+				for _, key := range keys {
+					if subtle.ConstantTimeCompare(presented, []byte(key)) == 1 {
+						return next(c)
+					}
+				}
+			}
+			return c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+				Error: "missing or invalid API key",
+				Code:  dto.CodeUnauthorized,
+			})
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package http
+
+import (
+	"fmt"
+	"time"
+
+	"go-exercise/internal/domain"
+	"go-exercise/internal/ports"
+
+	"github.com/labstack/echo/v4"
+)
+
+// maxAge returns how long the prices remain valid: the smallest remaining TTL among them,
+// never negative. Prices with no known update time were just fetched and get their full TTL.
+func maxAge(ltps []domain.LTP, ttls ports.TTLSource, now time.Time) time.Duration {
+	var age time.Duration
+	for i, ltp := range ltps {
+		remaining := ttls.TTLFor(ltp.Pair)
+		if !ltp.UpdatedAt.IsZero() {
+			remaining -= now.Sub(ltp.UpdatedAt)
+		}
+		if i == 0 || remaining < age {
+			age = remaining
+		}
+	}
+	return max(age, 0)
+}
+
+// setCacheControl sets Cache-Control so clients and CDNs don't cache the prices longer
+// than the server does. It does nothing unless the handler knows the cache TTLs.
+func (h *Handler) setCacheControl(c echo.Context, ltps []domain.LTP) {
+	if h.cacheTTL == nil {
+		return
+	}
+	seconds := int64(maxAge(ltps, h.cacheTTL, h.now()) / time.Second)
+	c.Response().Header().Set(echo.HeaderCacheControl, fmt.Sprintf("max-age=%d", seconds))
+}
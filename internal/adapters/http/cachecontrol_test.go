@@ -0,0 +1,134 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-exercise/internal/domain"
+	"go-exercise/internal/ports"
+	"go-exercise/internal/ports/mocks"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_GetLTP_CacheControl(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	now := time.Date(2024, 1, 1, 12, 1, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		ttls     ports.TTLSource
+		ltps     []domain.LTP
+		expected string
+	}{
+		{
+			name: "smallest remaining TTL among cached prices",
+			ttls: ttlPolicyStub{TTL: time.Minute},
+			ltps: []domain.LTP{
+				{Pair: btcEUR, Amount: 50000.12, UpdatedAt: now.Add(-20 * time.Second)},
+				{Pair: btcUSD, Amount: 52000.12, UpdatedAt: now.Add(-45 * time.Second)},
+			},
+			expected: "max-age=15",
+		},
+		{
+			name: "partial seconds are rounded down",
+			ttls: ttlPolicyStub{TTL: time.Minute},
+			ltps: []domain.LTP{
+				{Pair: btcUSD, Amount: 52000.12, UpdatedAt: now.Add(-10*time.Second - 500*time.Millisecond)},
+			},
+			expected: "max-age=49",
+		},
+		{
+			name: "freshly fetched prices get the full TTL",
+			ttls: ttlPolicyStub{TTL: time.Minute},
+			ltps: []domain.LTP{
+				{Pair: btcEUR, Amount: 50000.12, UpdatedAt: now},
+				{Pair: btcUSD, Amount: 52000.12},
+			},
+			expected: "max-age=60",
+		},
+		{
+			name: "stale prices are not cached",
+			ttls: ttlPolicyStub{TTL: time.Minute},
+			ltps: []domain.LTP{
+				{Pair: btcEUR, Amount: 50000.12, UpdatedAt: now.Add(-10 * time.Second)},
+				{Pair: btcUSD, Amount: 52000.12, UpdatedAt: now.Add(-5 * time.Minute)},
+			},
+			expected: "max-age=0",
+		},
+		{
+			name: "per-pair floors extend the TTL of their pairs",
+			ttls: ttlPolicyStub{TTL: time.Minute, MinTTL: map[string]time.Duration{domain.BTCUSD: 2 * time.Minute}},
+			ltps: []domain.LTP{
+				{Pair: btcEUR, Amount: 50000.12, UpdatedAt: now.Add(-20 * time.Second)},
+				{Pair: btcUSD, Amount: 52000.12, UpdatedAt: now.Add(-45 * time.Second)},
+			},
+			expected: "max-age=40",
+		},
+		{
+			name: "omitted without a cache TTL",
+			ltps: []domain.LTP{
+				{Pair: btcUSD, Amount: 52000.12, UpdatedAt: now},
+			},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ltpService := new(mocks.LTPService)
+			handler := NewHandler(ltpService, WithCacheTTL(tt.ttls))
+			handler.now = func() time.Time { return now }
+			ltpService.On("GetLTPs", mock.Anything, "BTC/USD,BTC/EUR", ports.LTPOptions{}).Return(tt.ltps, nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD,BTC/EUR", nil)
+			rec := httptest.NewRecorder()
+
+			// Act
+			err := handler.GetLTP(echo.New().NewContext(req, rec))
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tt.expected, rec.Header().Get(echo.HeaderCacheControl))
+		})
+	}
+}
+
+func TestHandler_GetLTP_CacheControlOnNotModified(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 1, 12, 1, 0, 0, time.UTC)
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService, WithCacheTTL(ttlPolicyStub{TTL: time.Minute}))
+	handler.now = func() time.Time { return now }
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	ltpService.On("GetLTPs", mock.Anything, "BTC/USD", ports.LTPOptions{}).Return([]domain.LTP{{
+		Pair: btcUSD, Amount: 52000.12, UpdatedAt: now.Add(-30 * time.Second),
+	}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD", nil)
+	req.Header.Set("If-Modified-Since", "Mon, 01 Jan 2024 12:00:30 GMT")
+	rec := httptest.NewRecorder()
+
+	// Act
+	err := handler.GetLTP(echo.New().NewContext(req, rec))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Equal(t, "max-age=30", rec.Header().Get(echo.HeaderCacheControl))
+}
+
+// ttlPolicyStub serves the TTLs of a fixed policy
+type ttlPolicyStub domain.TTLPolicy
+
+func (p ttlPolicyStub) TTLFor(pair domain.Pair) time.Duration {
+	return domain.TTLPolicy(p).For(pair)
+}
@@ -0,0 +1,199 @@
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/labstack/echo/v4"
+)
+
+// DefaultCompressionMinLength is the minimum response size in bytes before compression kicks in
+const DefaultCompressionMinLength = 1024
+
+// compressor is the common interface of the supported compression writers
+type compressor interface {
+	io.WriteCloser
+	Flush() error
+}
+
+// supportedEncodings lists the supported algorithms in server preference order
+var supportedEncodings = []struct {
+	name      string
+	newWriter func(io.Writer) compressor
+}{
+	{"br", func(w io.Writer) compressor { return brotli.NewWriter(w) }},
+	{"gzip", func(w io.Writer) compressor { return gzip.NewWriter(w) }},
+}
+
+// negotiateEncoding picks the best supported encoding from an Accept-Encoding header.
+// Higher q-values win; ties are broken by server preference (br before gzip).
+func negotiateEncoding(acceptEncoding string) (string, func(io.Writer) compressor) {
+	weights := make(map[string]float64)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		name := strings.ToLower(strings.TrimSpace(fields[0]))
+		if name == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		weights[name] = q
+	}
+
+	bestIdx, bestQ := -1, 0.0
+	for i, enc := range supportedEncodings {
+		q, ok := weights[enc.name]
+		if !ok {
+			q, ok = weights["*"]
+		}
+		if ok && q > bestQ {
+			bestIdx, bestQ = i, q
+		}
+	}
+	if bestIdx < 0 {
+		return "", nil
+	}
+	return supportedEncodings[bestIdx].name, supportedEncodings[bestIdx].newWriter
+}
+
+// compressMiddleware compresses responses of at least minLength bytes using the best
+// encoding the client accepts. Smaller responses are sent uncompressed.
+func compressMiddleware(minLength int) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			encoding, newWriter := negotiateEncoding(c.Request().Header.Get(echo.HeaderAcceptEncoding))
+			if encoding == "" {
+				return next(c)
+			}
+
+			res := c.Response()
+			res.Header().Add(echo.HeaderVary, echo.HeaderAcceptEncoding)
+
+			cw := &compressResponseWriter{
+				ResponseWriter: res.Writer,
+				encoding:       encoding,
+				newWriter:      newWriter,
+				minLength:      minLength,
+			}
+			original := res.Writer
+			res.Writer = cw
+			defer func() {
+				cw.close()
+				res.Writer = original
+			}()
+
+			return next(c)
+		}
+	}
+}
+
+// compressResponseWriter buffers the response until it is large enough to be worth
+// compressing, then either streams it through the compressor or writes it as is
+type compressResponseWriter struct {
+	http.ResponseWriter
+	encoding  string
+	newWriter func(io.Writer) compressor
+	minLength int
+
+	buf     bytes.Buffer
+	status  int
+	decided bool
+	writer  compressor
+}
+
+// WriteHeader defers the status until we know whether the body gets compressed
+func (w *compressResponseWriter) WriteHeader(code int) {
+	if w.decided {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.status = code
+}
+
+// Write buffers data until the minimum length is reached
+func (w *compressResponseWriter) Write(b []byte) (int, error) {
+	if w.decided {
+		if w.writer != nil {
+			return w.writer.Write(b)
+		}
+		return w.ResponseWriter.Write(b)
+	}
+
+	w.buf.Write(b)
+	if w.buf.Len() >= w.minLength {
+		if err := w.startCompression(); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+// Flush sends whatever has been buffered so streaming handlers are not held back
+func (w *compressResponseWriter) Flush() {
+	if !w.decided {
+		_ = w.passthrough()
+	}
+	if w.writer != nil {
+		_ = w.writer.Flush()
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *compressResponseWriter) startCompression() error {
+	// Already-encoded bodies and event streams are sent as is
+	if w.Header().Get(echo.HeaderContentEncoding) != "" || strings.HasPrefix(w.Header().Get(echo.HeaderContentType), "text/event-stream") {
+		return w.passthrough()
+	}
+
+	w.decided = true
+	w.Header().Set(echo.HeaderContentEncoding, w.encoding)
+	w.Header().Del(echo.HeaderContentLength)
+	w.writeStatus()
+
+	w.writer = w.newWriter(w.ResponseWriter)
+	_, err := w.writer.Write(w.buf.Bytes())
+	w.buf.Reset()
+	return err
+}
+
+func (w *compressResponseWriter) passthrough() error {
+	w.decided = true
+	w.writeStatus()
+	_, err := w.ResponseWriter.Write(w.buf.Bytes())
+	w.buf.Reset()
+	return err
+}
+
+func (w *compressResponseWriter) writeStatus() {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+}
+
+func (w *compressResponseWriter) close() {
+	if !w.decided {
+		// Nothing was written; leave the response untouched for the error handler
+		if w.status == 0 && w.buf.Len() == 0 {
+			return
+		}
+		_ = w.passthrough()
+	}
+	if w.writer != nil {
+		_ = w.writer.Close()
+	}
+}
@@ -0,0 +1,71 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go-exercise/internal/adapters/http/dto"
+	"go-exercise/internal/config"
+	"go-exercise/internal/domain"
+)
+
+// redacted replaces secret values in the configuration response
+const redacted = "REDACTED"
+
+// GetConfig handles GET /api/v1/config
+// @Summary Get the effective configuration
+// @Description Report the effective, non-secret configuration the server runs with. Secrets such as API keys are redacted. Only available when API keys are configured.
+// @Tags health
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.ConfigResponse "Successfully retrieved the configuration"
+// @Failure 401 {object} dto.ErrorResponse "Missing or invalid API key"
+// @Router /api/v1/config [get]
+func (h *Handler) GetConfig(c echo.Context) error {
+	return c.JSON(http.StatusOK, toConfigResponse(*h.config))
+}
+
+// toConfigResponse converts the configuration, redacting its secrets
+func toConfigResponse(cfg config.Config) dto.ConfigResponse {
+	defaultPairs := cfg.DefaultPairs
+	if defaultPairs == nil {
+		defaultPairs, _ = domain.ParsePairs("")
+	}
+	response := dto.ConfigResponse{
+		Provider:             cfg.PriceSource,
+		Pairs:                domain.ValidPairs(),
+		DefaultPairs:         make([]string, len(defaultPairs)),
+		MaxPairs:             cfg.MaxPairs,
+		AllowStale:           cfg.AllowStale,
+		CacheTTL:             formatDuration(cfg.CacheTTL),
+		CacheMaxEntries:      cfg.CacheMaxEntries,
+		HistorySize:          cfg.HistorySize,
+		RefreshInterval:      formatDuration(cfg.RefreshInterval),
+		RefreshFailures:      cfg.RefreshFailureThreshold,
+		KrakenTimeout:        formatDuration(cfg.KrakenTimeout),
+		UpstreamTimeout:      formatDuration(cfg.UpstreamTimeout),
+		KrakenBatchSize:      cfg.KrakenBatchSize,
+		SlowUpstream:         formatDuration(cfg.SlowUpstreamThreshold),
+		EventsInterval:       formatDuration(cfg.EventsInterval),
+		StartupSelfTest:      cfg.StartupSelfTest,
+		CORSAllowedOrigins:   cfg.CORSAllowedOrigins,
+		CompressionMinLength: cfg.CompressionMinLength,
+		DebugHTTP:            cfg.DebugHTTP,
+	}
+	for i, pair := range defaultPairs {
+		response.DefaultPairs[i] = pair.Value()
+	}
+	for range cfg.APIKeys {
+		response.APIKeys = append(response.APIKeys, redacted)
+	}
+	return response
+}
+
+// formatDuration formats a configured duration; empty when unset
+func formatDuration(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return d.String()
+}
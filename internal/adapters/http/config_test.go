@@ -0,0 +1,125 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-exercise/internal/adapters/http/dto"
+	"go-exercise/internal/config"
+	"go-exercise/internal/domain"
+	"go-exercise/internal/ports/mocks"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_GetConfig_RedactsSecrets(t *testing.T) {
+	// Arrange
+	minLength := 512
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	cfg := config.Config{
+		PriceSource:          "kraken",
+		StartupSelfTest:      "off",
+		DefaultPairs:         []domain.Pair{btcEUR},
+		CacheTTL:             time.Minute,
+		RefreshInterval:      30 * time.Second,
+		UpstreamTimeout:      3 * time.Second,
+		APIKeys:              []string{"super-secret", "other-secret"},
+		CORSAllowedOrigins:   []string{"https://example.com"},
+		CompressionMinLength: &minLength,
+	}
+	handler := NewHandler(new(mocks.LTPService), WithConfig(cfg))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/config", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	err := handler.GetConfig(echo.New().NewContext(req, rec))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "super-secret")
+	assert.NotContains(t, rec.Body.String(), "other-secret")
+
+	var response dto.ConfigResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, dto.ConfigResponse{
+		Provider:             "kraken",
+		Pairs:                domain.ValidPairs(),
+		DefaultPairs:         []string{domain.BTCEUR},
+		CacheTTL:             "1m0s",
+		RefreshInterval:      "30s",
+		UpstreamTimeout:      "3s",
+		StartupSelfTest:      "off",
+		APIKeys:              []string{"REDACTED", "REDACTED"},
+		CORSAllowedOrigins:   []string{"https://example.com"},
+		CompressionMinLength: &minLength,
+	}, response)
+}
+
+func TestRouter_Config(t *testing.T) {
+	tests := []struct {
+		name           string
+		withConfig     bool
+		opts           []RouterOption
+		apiKey         string
+		expectedStatus int
+	}{
+		{
+			name:           "not exposed without API keys",
+			withConfig:     true,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "not exposed without a configuration",
+			opts:           []RouterOption{WithAPIKeys([]string{"admin"})},
+			apiKey:         "admin",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "requires a valid key",
+			withConfig:     true,
+			opts:           []RouterOption{WithAPIKeys([]string{"admin"})},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "served with a valid key",
+			withConfig:     true,
+			opts:           []RouterOption{WithAPIKeys([]string{"admin"})},
+			apiKey:         "admin",
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var handlerOpts []HandlerOption
+			if tt.withConfig {
+				handlerOpts = append(handlerOpts, WithConfig(config.Config{PriceSource: "kraken", APIKeys: []string{"admin"}}))
+			}
+			router := SetupRouter(NewHandler(new(mocks.LTPService), handlerOpts...), tt.opts...)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/config", nil)
+			if tt.apiKey != "" {
+				req.Header.Set(HeaderAPIKey, tt.apiKey)
+			}
+			rec := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(rec, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Contains(t, rec.Body.String(), `"api_keys":["REDACTED"]`)
+				assert.NotContains(t, rec.Body.String(), `"admin"`)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go-exercise/internal/adapters/http/dto"
+	"go-exercise/internal/domain"
+	"go-exercise/internal/ports"
+)
+
+// Convert handles GET /api/v1/convert
+// @Summary Convert between quote currencies
+// @Description Convert an amount between two supported quote currencies (USD, CHF, EUR) using the rate implied by their BTC prices
+// @Tags convert
+// @Produce json
+// @Security ApiKeyAuth
+// @Param from query string true "Currency to convert from" Enums(USD, CHF, EUR)
+// @Param to query string true "Currency to convert to" Enums(USD, CHF, EUR)
+// @Param amount query number true "Amount to convert"
+// @Success 200 {object} dto.ConversionResponse "Successfully converted amount"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request parameters"
+// @Failure 401 {object} dto.ErrorResponse "Missing or invalid API key, when API keys are configured"
+// @Failure 422 {object} dto.ErrorResponse "Pair not supported by any provider"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Failure 502 {object} dto.ErrorResponse "Price for either leg unavailable"
+// @Failure 503 {object} dto.ErrorResponse "Upstream price provider rate limited; see the Retry-After header"
+// @Router /api/v1/convert [get]
+func (h *Handler) Convert(c echo.Context) error {
+	fromPair, err := domain.PairForQuote(c.QueryParam("from"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse(err))
+	}
+	toPair, err := domain.PairForQuote(c.QueryParam("to"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse(err))
+	}
+
+	rawAmount := c.QueryParam("amount")
+	amount, err := strconv.ParseFloat(rawAmount, 64)
+	if err != nil || amount < 0 {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: fmt.Sprintf("invalid amount value: %s", rawAmount),
+			Code:  dto.CodeInvalidParameter,
+		})
+	}
+
+	ltps, err := h.ltpService.GetLTPs(c.Request().Context(), fromPair.Value()+","+toPair.Value(), ports.LTPOptions{})
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	byPair := make(map[string]domain.LTP, len(ltps))
+	for _, ltp := range ltps {
+		byPair[ltp.Pair.Value()] = ltp
+	}
+	for _, pair := range []domain.Pair{fromPair, toPair} {
+		if _, ok := byPair[pair.Value()]; !ok {
+			return c.JSON(http.StatusBadGateway, dto.ErrorResponse{
+				Error: fmt.Sprintf("%s: no price for %s", domain.ErrRateUnavailable, pair),
+				Code:  dto.CodeRateUnavailable,
+			})
+		}
+	}
+
+	rate, err := domain.CrossRate(byPair[fromPair.Value()], byPair[toPair.Value()])
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.ConversionResponse{
+		From:      fromPair.Quote(),
+		To:        toPair.Quote(),
+		Amount:    amount,
+		Converted: amount * rate,
+		Rate:      rate,
+	})
+}
@@ -0,0 +1,172 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-exercise/internal/adapters/http/dto"
+	"go-exercise/internal/domain"
+	"go-exercise/internal/ports"
+	"go-exercise/internal/ports/mocks"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_Convert_Success(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+
+	ltpService.On("GetLTPs", mock.Anything, "BTC/EUR,BTC/USD", ports.LTPOptions{}).Return([]domain.LTP{
+		{Pair: btcEUR, Amount: 50000},
+		{Pair: btcUSD, Amount: 52000},
+	}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/convert?from=eur&to=USD&amount=100", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.Convert(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.ConversionResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "EUR", response.From)
+	assert.Equal(t, "USD", response.To)
+	assert.Equal(t, 100.0, response.Amount)
+	assert.InDelta(t, 1.04, response.Rate, 1e-9)
+	assert.InDelta(t, 104.0, response.Converted, 1e-9)
+
+	ltpService.AssertExpectations(t)
+}
+
+func TestHandler_Convert_InvalidParams_ReturnsBadRequest(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"unknown from currency", "from=GBP&to=USD&amount=1"},
+		{"missing to currency", "from=EUR&amount=1"},
+		{"base currency is not a quote", "from=BTC&to=USD&amount=1"},
+		{"missing amount", "from=EUR&to=USD"},
+		{"non-numeric amount", "from=EUR&to=USD&amount=lots"},
+		{"negative amount", "from=EUR&to=USD&amount=-5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ltpService := new(mocks.LTPService)
+			handler := NewHandler(ltpService)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/convert?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// Act
+			err := handler.Convert(c)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			ltpService.AssertNotCalled(t, "GetLTPs", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestHandler_Convert_LegUnavailable_ReturnsBadGateway(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+
+	tests := []struct {
+		name          string
+		ltps          []domain.LTP
+		serviceErr    error
+		expectedError string
+	}{
+		{
+			name:          "upstream down",
+			serviceErr:    fmt.Errorf("%w: failed to fetch from external service: timeout", domain.ErrUpstreamUnavailable),
+			expectedError: "failed to fetch from external service",
+		},
+		{
+			name:          "leg missing from result",
+			ltps:          []domain.LTP{{Pair: btcUSD, Amount: 52000}},
+			expectedError: "no price for BTC/EUR",
+		},
+		{
+			name:          "leg without a usable price",
+			ltps:          []domain.LTP{{Pair: btcEUR, Amount: 0}, {Pair: btcUSD, Amount: 52000}},
+			expectedError: "no usable price for BTC/EUR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ltpService := new(mocks.LTPService)
+			handler := NewHandler(ltpService)
+
+			ltpService.On("GetLTPs", mock.Anything, "BTC/EUR,BTC/USD", ports.LTPOptions{}).Return(tt.ltps, tt.serviceErr)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/convert?from=EUR&to=USD&amount=100", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// Act
+			err := handler.Convert(c)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusBadGateway, rec.Code)
+
+			var response dto.ErrorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Contains(t, response.Error, tt.expectedError)
+		})
+	}
+}
+
+func TestHandler_Convert_SameCurrency(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	btcCHF, _ := domain.NewPair(domain.BTCCHF)
+	ltpService.On("GetLTPs", mock.Anything, "BTC/CHF,BTC/CHF", ports.LTPOptions{}).Return([]domain.LTP{
+		{Pair: btcCHF, Amount: 49000},
+	}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/convert?from=CHF&to=CHF&amount=42", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.Convert(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.ConversionResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, 1.0, response.Rate)
+	assert.Equal(t, 42.0, response.Converted)
+}
@@ -0,0 +1,77 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"go-exercise/internal/adapters/http/dto"
+	"go-exercise/internal/domain"
+	"go-exercise/internal/ports"
+)
+
+// GetCrossRates handles GET /api/v1/ltp/cross
+// @Summary Price a currency in several quotes
+// @Description Price the base currency in each requested quote currency, from a direct pair when one exists and otherwise derived through an intermediate currency
+// @Tags ltp
+// @Produce json
+// @Security ApiKeyAuth
+// @Param base query string false "Currency to price" default(BTC)
+// @Param quotes query string true "Comma-separated quote currencies" example(USD,EUR,CHF)
+// @Success 200 {object} dto.CrossRatesResponse "Base currency priced in each quote"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request parameters"
+// @Failure 401 {object} dto.ErrorResponse "Missing or invalid API key, when API keys are configured"
+// @Failure 422 {object} dto.ErrorResponse "Pair not supported by any provider"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Failure 502 {object} dto.ErrorResponse "No direct or derived price for a quote"
+// @Failure 503 {object} dto.ErrorResponse "Upstream price provider rate limited; see the Retry-After header"
+// @Router /api/v1/ltp/cross [get]
+func (h *Handler) GetCrossRates(c echo.Context) error {
+	base := strings.ToUpper(strings.TrimSpace(c.QueryParam("base")))
+	if base == "" {
+		base = domain.BaseCurrency
+	}
+
+	var quotes []string
+	for _, quote := range strings.Split(c.QueryParam("quotes"), ",") {
+		if quote = strings.ToUpper(strings.TrimSpace(quote)); quote != "" {
+			quotes = append(quotes, quote)
+		}
+	}
+	if len(quotes) == 0 {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "quotes parameter is required",
+			Code:  dto.CodeInvalidParameter,
+		})
+	}
+
+	pairs, err := domain.CrossRatePairs(base, quotes)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse(err))
+	}
+	values := make([]string, len(pairs))
+	for i, pair := range pairs {
+		values[i] = pair.Value()
+	}
+
+	ltps, err := h.ltpService.GetLTPs(c.Request().Context(), strings.Join(values, ","), ports.LTPOptions{})
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	crossQuotes, err := domain.CrossRates(base, quotes, ltps)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	response := dto.CrossRatesResponse{Base: base, Quotes: make([]dto.CrossQuoteItem, len(crossQuotes))}
+	for i, quote := range crossQuotes {
+		response.Quotes[i] = dto.CrossQuoteItem{
+			Quote:   quote.Quote,
+			Amount:  quote.Amount,
+			Derived: quote.Derived(),
+			Via:     quote.Via,
+		}
+	}
+	return c.JSON(http.StatusOK, response)
+}
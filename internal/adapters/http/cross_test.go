@@ -0,0 +1,179 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-exercise/internal/adapters/http/dto"
+	"go-exercise/internal/domain"
+	"go-exercise/internal/ports"
+	"go-exercise/internal/ports/mocks"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_GetCrossRates_Success(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcCHF, _ := domain.NewPair(domain.BTCCHF)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+
+	ltpService.On("GetLTPs", mock.Anything, "BTC/USD,BTC/CHF,BTC/EUR", ports.LTPOptions{}).Return([]domain.LTP{
+		{Pair: btcUSD, Amount: 52000},
+		{Pair: btcCHF, Amount: 49000},
+		{Pair: btcEUR, Amount: 50000},
+	}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp/cross?quotes=eur,%20USD", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.GetCrossRates(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.CrossRatesResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, dto.CrossRatesResponse{
+		Base: "BTC",
+		Quotes: []dto.CrossQuoteItem{
+			{Quote: "EUR", Amount: 50000},
+			{Quote: "USD", Amount: 52000},
+		},
+	}, response)
+
+	ltpService.AssertExpectations(t)
+}
+
+func TestHandler_GetCrossRates_Derived(t *testing.T) {
+	// Arrange
+	defaultPairs := domain.ValidPairs()
+	t.Cleanup(func() { require.NoError(t, domain.SetValidPairs(defaultPairs)) })
+	require.NoError(t, domain.SetValidPairs([]string{domain.BTCUSD, domain.BTCEUR, "ETH/BTC"}))
+
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	ethBTC, _ := domain.NewPair("ETH/BTC")
+	ltpService.On("GetLTPs", mock.Anything, "BTC/EUR,ETH/BTC", ports.LTPOptions{}).Return([]domain.LTP{
+		{Pair: btcEUR, Amount: 50000},
+		{Pair: ethBTC, Amount: 0.05},
+	}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp/cross?base=ETH&quotes=EUR", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.GetCrossRates(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.CrossRatesResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "ETH", response.Base)
+	require.Len(t, response.Quotes, 1)
+	assert.Equal(t, "EUR", response.Quotes[0].Quote)
+	assert.InDelta(t, 2500.0, response.Quotes[0].Amount, 1e-9)
+	assert.True(t, response.Quotes[0].Derived)
+	assert.Equal(t, "BTC", response.Quotes[0].Via)
+}
+
+func TestHandler_GetCrossRates_InvalidParams_ReturnsBadRequest(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"missing quotes", "base=BTC"},
+		{"only separators", "quotes=,%20,"},
+		{"unknown quote", "quotes=USD,JPY"},
+		{"unknown base", "base=DOGE&quotes=USD"},
+		{"quote equal to base", "quotes=BTC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ltpService := new(mocks.LTPService)
+			handler := NewHandler(ltpService)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp/cross?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// Act
+			err := handler.GetCrossRates(c)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			ltpService.AssertNotCalled(t, "GetLTPs", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestHandler_GetCrossRates_Unavailable_ReturnsBadGateway(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+
+	tests := []struct {
+		name          string
+		ltps          []domain.LTP
+		serviceErr    error
+		expectedError string
+	}{
+		{
+			name:          "upstream down",
+			serviceErr:    fmt.Errorf("%w: failed to fetch from external service: timeout", domain.ErrUpstreamUnavailable),
+			expectedError: "failed to fetch from external service",
+		},
+		{
+			name:          "quote missing from result",
+			ltps:          []domain.LTP{{Pair: btcUSD, Amount: 52000}},
+			expectedError: "no direct or derived price of BTC in EUR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ltpService := new(mocks.LTPService)
+			handler := NewHandler(ltpService)
+
+			ltpService.On("GetLTPs", mock.Anything, "BTC/USD,BTC/CHF,BTC/EUR", ports.LTPOptions{}).Return(tt.ltps, tt.serviceErr)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp/cross?quotes=USD,EUR", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// Act
+			err := handler.GetCrossRates(c)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusBadGateway, rec.Code)
+
+			var response dto.ErrorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Contains(t, response.Error, tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package http
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// maxDebugBodyLength caps how much of a response body is logged in debug mode
+const maxDebugBodyLength = 4096
+
+// redactedHeaders lists request headers whose values are never logged
+var redactedHeaders = []string{HeaderAPIKey, echo.HeaderAuthorization}
+
+// debugLogMiddleware logs each request's query parameters and headers, and the start of
+// its response body. The body is copied as it is written, so streamed responses still
+// reach the client as they are flushed.
+func debugLogMiddleware(logger *log.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			res := c.Response()
+			dw := &debugResponseWriter{ResponseWriter: res.Writer}
+			original := res.Writer
+			res.Writer = dw
+			defer func() {
+				res.Writer = original
+			}()
+
+			err := next(c)
+
+			req := c.Request()
+			logger.Printf("debug http: %s %s query=%v headers=%s status=%d body=%q",
+				req.Method, req.URL.Path, req.URL.Query(), redactHeaders(req.Header), res.Status, dw.body.String())
+			return err
+		}
+	}
+}
+
+// redactHeaders formats request headers with sensitive values replaced
+func redactHeaders(header http.Header) string {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		value := strings.Join(header[name], ",")
+		for _, redacted := range redactedHeaders {
+			if http.CanonicalHeaderKey(redacted) == name {
+				value = "[REDACTED]"
+			}
+		}
+		parts = append(parts, name+"="+value)
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
+
+// debugResponseWriter passes writes through while keeping a copy of the first bytes
+type debugResponseWriter struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+// Write copies up to maxDebugBodyLength bytes before passing the data on
+func (w *debugResponseWriter) Write(b []byte) (int, error) {
+	if remaining := maxDebugBodyLength - w.body.Len(); remaining > 0 {
+		w.body.Write(b[:min(len(b), remaining)])
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush forwards flushes so streaming handlers are not held back
+func (w *debugResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the wrapped writer to http.ResponseController
+func (w *debugResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
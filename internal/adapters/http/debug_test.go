@@ -0,0 +1,116 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-exercise/internal/domain"
+	"go-exercise/internal/ports"
+	"go-exercise/internal/ports/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouter_DebugLogging(t *testing.T) {
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD", nil)
+		req.Header.Set(HeaderAPIKey, "secret-key")
+		return req
+	}
+	newService := func() *mocks.LTPService {
+		ltpService := new(mocks.LTPService)
+		btcUSD, _ := domain.NewPair(domain.BTCUSD)
+		ltpService.On("GetLTPs", mock.Anything, "BTC/USD", ports.LTPOptions{}).Return([]domain.LTP{{Pair: btcUSD, Amount: 52000.12}}, nil)
+		return ltpService
+	}
+
+	t.Run("logs query, redacted headers and body when enabled", func(t *testing.T) {
+		// Arrange
+		var buf bytes.Buffer
+		router := SetupRouter(NewHandler(newService()), WithAPIKeys([]string{"secret-key"}), WithDebugLogging(log.New(&buf, "", 0)))
+		rec := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(rec, newRequest())
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		line := buf.String()
+		assert.Contains(t, line, "debug http: GET /api/v1/ltp")
+		assert.Contains(t, line, "query=map[pairs:[BTC/USD]]")
+		assert.Contains(t, line, "X-Api-Key=[REDACTED]")
+		assert.NotContains(t, line, "secret-key")
+		assert.Contains(t, line, "status=200")
+		assert.Contains(t, line, `\"pair\":\"BTC/USD\"`)
+	})
+
+	t.Run("logs nothing when disabled", func(t *testing.T) {
+		// Arrange
+		var buf bytes.Buffer
+		original := log.Writer()
+		log.SetOutput(&buf)
+		defer log.SetOutput(original)
+		router := SetupRouter(NewHandler(newService()), WithAPIKeys([]string{"secret-key"}))
+		rec := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(rec, newRequest())
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NotContains(t, buf.String(), "debug http")
+	})
+
+	t.Run("caps the logged body", func(t *testing.T) {
+		// Arrange
+		rec := httptest.NewRecorder()
+		w := &debugResponseWriter{ResponseWriter: rec}
+
+		// Act
+		n, err := w.Write(bytes.Repeat([]byte("a"), maxDebugBodyLength+10))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, maxDebugBodyLength+10, n)
+		assert.Equal(t, maxDebugBodyLength+10, rec.Body.Len())
+		assert.Equal(t, maxDebugBodyLength, w.body.Len())
+	})
+}
+
+func TestRouter_DebugLogging_KeepsStreaming(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService, WithEventsInterval(10*time.Millisecond))
+	var buf bytes.Buffer
+	server := httptest.NewServer(SetupRouter(handler, WithDebugLogging(log.New(&buf, "", 0))))
+	defer server.Close()
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	ltpService.On("GetLTPs", mock.Anything, "BTC/USD", ports.LTPOptions{}).Return([]domain.LTP{{Pair: btcUSD, Amount: 52000.12}}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/v1/ltp/events?pairs=BTC/USD", nil)
+	require.NoError(t, err)
+
+	// Act
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	// Assert
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	reader := bufio.NewReader(resp.Body)
+	for i := 0; i < 2; i++ {
+		event, _ := readEvent(t, reader)
+		assert.Equal(t, "ltp", event)
+	}
+}
@@ -0,0 +1,69 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go-exercise/internal/adapters/http/dto"
+	"go-exercise/internal/domain"
+	"go-exercise/internal/ports"
+)
+
+// DefaultDiagTimeout bounds the live upstream fetch of the diagnostics endpoint
+const DefaultDiagTimeout = 5 * time.Second
+
+// WithDiagnostics enables the diagnostics endpoint, which probes the given price provider
+// directly, bypassing the service and its cache
+func WithDiagnostics(external ports.External) HandlerOption {
+	return func(h *Handler) {
+		h.external = external
+	}
+}
+
+// Diagnose handles GET /api/v1/diag
+// @Summary Probe the price provider
+// @Description Fetch a single pair live from the configured price provider, bypassing the cache, and report the outcome and latency.
+// @Description Unlike /health this always calls the network. Only available when API keys are configured.
+// @Tags health
+// @Produce json
+// @Security ApiKeyAuth
+// @Param pair query string false "Currency pair to fetch (defaults to the first valid pair)"
+// @Success 200 {object} dto.DiagResponse "The provider answered"
+// @Failure 400 {object} dto.ErrorResponse "Invalid pair"
+// @Failure 401 {object} dto.ErrorResponse "Missing or invalid API key"
+// @Failure 502 {object} dto.DiagResponse "The provider failed or timed out"
+// @Router /api/v1/diag [get]
+func (h *Handler) Diagnose(c echo.Context) error {
+	value := c.QueryParam("pair")
+	if value == "" {
+		value = domain.ValidPairs()[0]
+	}
+	pair, err := domain.NewPair(value)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	response := dto.DiagResponse{Pair: pair.Value()}
+	if h.symbols != nil {
+		response.Symbol, _ = h.symbols.Symbol(pair)
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), DefaultDiagTimeout)
+	defer cancel()
+
+	start := time.Now()
+	ltp, err := h.external.GetTicker(ctx, pair)
+	response.LatencyMS = float64(time.Since(start).Microseconds()) / 1000
+	response.CheckedAt = start.UTC()
+	if err != nil {
+		response.Error = err.Error()
+		return c.JSON(http.StatusBadGateway, response)
+	}
+
+	response.OK = true
+	response.Amount = json.Number(ltp.PreciseAmount())
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,115 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-exercise/internal/adapters/http/dto"
+	"go-exercise/internal/domain"
+	"go-exercise/internal/ports/mocks"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_Diagnose_Success(t *testing.T) {
+	// Arrange
+	external := new(mocks.External)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	external.On("GetTicker", mock.Anything, btcEUR).Return(domain.LTP{Pair: btcEUR, Amount: 50000.12, RawAmount: "50000.12000"}, nil)
+	handler := NewHandler(new(mocks.LTPService), WithDiagnostics(external), WithSymbolResolver(stubSymbols{domain.BTCEUR: "XBTEUR"}))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/diag?pair=btc/eur", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	err := handler.Diagnose(e.NewContext(req, rec))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.DiagResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, domain.BTCEUR, response.Pair)
+	assert.Equal(t, "XBTEUR", response.Symbol)
+	assert.True(t, response.OK)
+	assert.Equal(t, json.Number("50000.12000"), response.Amount)
+	assert.Empty(t, response.Error)
+	assert.GreaterOrEqual(t, response.LatencyMS, 0.0)
+	assert.False(t, response.CheckedAt.IsZero())
+	external.AssertExpectations(t)
+}
+
+func TestHandler_Diagnose_DefaultsToFirstValidPair(t *testing.T) {
+	// Arrange
+	external := new(mocks.External)
+	first, _ := domain.NewPair(domain.ValidPairs()[0])
+	external.On("GetTicker", mock.Anything, first).Return(domain.LTP{Pair: first, Amount: 52000.12}, nil)
+	handler := NewHandler(new(mocks.LTPService), WithDiagnostics(external))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/diag", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	err := handler.Diagnose(e.NewContext(req, rec))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"pair":"`+first.Value()+`"`)
+	assert.NotContains(t, rec.Body.String(), `"symbol"`)
+	external.AssertExpectations(t)
+}
+
+func TestHandler_Diagnose_UpstreamFailure(t *testing.T) {
+	// Arrange
+	external := new(mocks.External)
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	external.On("GetTicker", mock.Anything, btcUSD).Return(domain.LTP{}, errors.New("kraken API returned status 500"))
+	handler := NewHandler(new(mocks.LTPService), WithDiagnostics(external))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/diag?pair=BTC/USD", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	err := handler.Diagnose(e.NewContext(req, rec))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadGateway, rec.Code)
+
+	var response dto.DiagResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.False(t, response.OK)
+	assert.Equal(t, "kraken API returned status 500", response.Error)
+	assert.Empty(t, response.Amount)
+	external.AssertExpectations(t)
+}
+
+func TestHandler_Diagnose_InvalidPair(t *testing.T) {
+	// Arrange
+	external := new(mocks.External)
+	handler := NewHandler(new(mocks.LTPService), WithDiagnostics(external))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/diag?pair=BTC/INVALID", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	err := handler.Diagnose(e.NewContext(req, rec))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), dto.CodeInvalidPair)
+	external.AssertNotCalled(t, "GetTicker", mock.Anything, mock.Anything)
+}
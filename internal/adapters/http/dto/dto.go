@@ -1,21 +1,281 @@
 package dto
 
+import (
+	"encoding/json"
+	"encoding/xml"
+	"time"
+)
+
 // LTPItem represents a single LTP item in the response
 // @Description Single Last Traded Price item
 type LTPItem struct {
-	Pair   string  `json:"pair" example:"BTC/USD"`   // Currency pair
-	Amount float64 `json:"amount" example:"52000.12"` // Last traded price amount
+	Pair         string      `json:"pair" xml:"pair" example:"BTC/USD"`                                                // Currency pair
+	Amount       json.Number `json:"amount" xml:"amount" swaggertype:"number" example:"52000.12"`                      // Last traded price amount, exactly as reported by the provider
+	Stale        bool        `json:"stale,omitempty" xml:"stale,omitempty"`                                            // Set when served from an expired cache entry because the upstream failed
+	Symbol       string      `json:"symbol,omitempty" xml:"symbol,omitempty" example:"XXBTZUSD"`                       // Upstream symbol the price was reported under, only with debug=true
+	Bid          *float64    `json:"bid,omitempty" xml:"bid,omitempty" example:"51999.5"`                              // Best bid price, only with fields=bid
+	Ask          *float64    `json:"ask,omitempty" xml:"ask,omitempty" example:"52000.5"`                              // Best ask price, only with fields=ask
+	SLAOK        *bool       `json:"sla_ok,omitempty" xml:"sla_ok,omitempty"`                                          // Whether the data age meets the pair's freshness SLA, only with sla=true
+	Change24h    *float64    `json:"change_24h,omitempty" xml:"change_24h,omitempty" example:"1250.5"`                 // Price change over the last 24 hours, only with include=change and enough history
+	ChangePct24h *float64    `json:"change_pct_24h,omitempty" xml:"change_pct_24h,omitempty" example:"2.45"`           // Percentage price change over the last 24 hours, only with include=change and enough history
+	SourceTime   *time.Time  `json:"source_time,omitempty" xml:"source_time,omitempty" example:"2024-01-01T12:00:00Z"` // Time the provider reported for the price, omitted when it reports none
 }
 
 // LTPResponse represents the API response structure
 // @Description Response containing list of Last Traded Prices
 type LTPResponse struct {
-	LTP []LTPItem `json:"ltp"` // List of LTP items
+	XMLName xml.Name  `json:"-" xml:"ltpResponse" swaggerignore:"true"`
+	LTP     []LTPItem `json:"ltp" xml:"ltp"`                                                                                                                                          // List of LTP items
+	Query   string    `json:"query,omitempty" xml:"query,omitempty" example:"pairs=BTC/EUR,BTC/USD&refresh=false&order=sorted&provider=kraken&fields=&precision=&sla=false&include="` // Normalized query, only with debug=true
+}
+
+// InvalidPairItem describes an entry of a pairs string that cannot be served
+// @Description Invalid entry of a pairs string and the reason
+type InvalidPairItem struct {
+	Pair  string `json:"pair" example:"BTC/INVALID"`                                        // Entry as given
+	Code  string `json:"code" example:"INVALID_PAIR" enums:"INVALID_PAIR,UNSUPPORTED_PAIR"` // Why the entry is invalid
+	Error string `json:"error" example:"invalid pair: BTC/INVALID"`                         // Error message, for humans
+}
+
+// ValidatePairsResponse represents the result of validating a pairs string
+// @Description Valid and invalid entries of a pairs string, each listed once
+type ValidatePairsResponse struct {
+	Valid   []string          `json:"valid" example:"BTC/USD,BTC/EUR"` // Normalized valid pairs, in the order given
+	Invalid []InvalidPairItem `json:"invalid"`                         // Entries that cannot be served
+}
+
+// EnvelopeResponse wraps a response body with metadata and errors, as served under /api/v2
+// @Description Response envelope: data on success, errors on failure, and metadata either way
+type EnvelopeResponse struct {
+	Data   interface{}     `json:"data"`   // Response payload; null on failure
+	Meta   EnvelopeMeta    `json:"meta"`   // Details about how the request was served
+	Errors []ErrorResponse `json:"errors"` // Errors that failed the request; empty on success
+}
+
+// EnvelopeMeta describes how a request was served
+// @Description Request metadata
+type EnvelopeMeta struct {
+	RequestedAt time.Time         `json:"requested_at" example:"2024-01-01T12:00:00Z"` // When the request was received
+	Provider    string            `json:"provider,omitempty" example:"kraken"`         // External price provider in use
+	Cache       *CacheSummary     `json:"cache,omitempty"`                             // How the returned prices were sourced, on success
+	Refresher   *RefresherSummary `json:"refresher,omitempty"`                         // State of the background refresher, when one runs
+}
+
+// RefresherSummary warns clients when the background refresher keeps failing, in which
+// case cached prices may be older than usual
+// @Description State of the background cache refresher
+type RefresherSummary struct {
+	LastSuccess         *time.Time `json:"last_success,omitempty" example:"2024-01-01T12:00:00Z"` // When a refresh last succeeded; omitted until the first success
+	ConsecutiveFailures int        `json:"consecutive_failures" example:"0"`                      // Refreshes that failed since the last success
+	Degraded            bool       `json:"degraded" example:"false"`                              // Whether the failures reached the configured threshold
+}
+
+// CacheSummary counts how the prices of a response were sourced
+// @Description Number of prices served from the cache, fetched, or served stale
+type CacheSummary struct {
+	Hits   int `json:"hits" example:"2"`   // Prices served from fresh cache entries
+	Misses int `json:"misses" example:"1"` // Prices fetched from the provider
+	Stale  int `json:"stale" example:"0"`  // Expired prices served because the provider failed
+}
+
+// HistorySample represents a single stored price
+// @Description Price stored at a point in time
+type HistorySample struct {
+	Amount    json.Number `json:"amount" swaggertype:"number" example:"52000.12"` // Last traded price amount, exactly as reported by the provider
+	Timestamp time.Time   `json:"timestamp" example:"2024-01-01T12:00:00Z"`       // When the price was stored
+}
+
+// HistoryResponse represents the price history of a pair
+// @Description Recent stored prices of a pair, oldest first
+type HistoryResponse struct {
+	Pair    string          `json:"pair" example:"BTC/USD"`                        // Currency pair
+	Samples []HistorySample `json:"samples"`                                       // Stored prices, oldest first
+	Next    string          `json:"next,omitempty" example:"2024-01-01T12:00:00Z"` // Cursor for the next, older page, passed as before; omitted on the last page
+}
+
+// TWAPResponse represents a time-weighted average price
+// @Description Time-weighted average price of a pair over a window ending now
+type TWAPResponse struct {
+	Pair   string  `json:"pair" example:"BTC/USD"`  // Currency pair
+	Window string  `json:"window" example:"5m0s"`   // Averaging window
+	TWAP   float64 `json:"twap" example:"52000.12"` // Time-weighted average price
+}
+
+// Candle represents an OHLC candle
+// @Description Open, high, low and close prices and traded volume of one interval
+type Candle struct {
+	Time   time.Time `json:"time" example:"2024-01-01T12:00:00Z"` // Start of the interval
+	Open   float64   `json:"open" example:"42000.1"`              // First traded price
+	High   float64   `json:"high" example:"42100"`                // Highest traded price
+	Low    float64   `json:"low" example:"41950.5"`               // Lowest traded price
+	Close  float64   `json:"close" example:"42050.2"`             // Last traded price
+	Volume float64   `json:"volume" example:"12.3456789"`         // Traded volume in the base currency
+}
+
+// OHLCResponse represents recent candles of a pair
+// @Description Recent OHLC candles of a pair, oldest first
+type OHLCResponse struct {
+	Pair     string   `json:"pair" example:"BTC/USD"` // Currency pair
+	Interval int      `json:"interval" example:"1"`   // Candle interval in minutes
+	Candles  []Candle `json:"candles"`                // Candles, oldest first
+}
+
+// ConversionResponse represents a currency conversion result
+// @Description Amount converted using the rate implied by BTC cross rates
+type ConversionResponse struct {
+	From      string  `json:"from" example:"EUR"`         // Currency converted from
+	To        string  `json:"to" example:"USD"`           // Currency converted to
+	Amount    float64 `json:"amount" example:"100"`       // Amount in the source currency
+	Converted float64 `json:"converted" example:"103.99"` // Amount in the target currency
+	Rate      float64 `json:"rate" example:"1.0399"`      // Units of the target currency per unit of the source currency
 }
 
+// CrossRatesResponse represents a base currency priced in several quote currencies
+// @Description Base currency priced in each requested quote, directly or through cross rates
+type CrossRatesResponse struct {
+	Base   string           `json:"base" example:"BTC"` // Currency being priced
+	Quotes []CrossQuoteItem `json:"quotes"`             // One entry per requested quote, in request order
+}
+
+// CrossQuoteItem represents the price of the base currency in one quote currency
+// @Description Price of the base currency in a quote currency
+type CrossQuoteItem struct {
+	Quote   string  `json:"quote" example:"EUR"`         // Quote currency
+	Amount  float64 `json:"amount" example:"50000"`      // Units of the quote currency per unit of the base currency
+	Derived bool    `json:"derived" example:"false"`     // Whether the price was derived rather than read from a direct pair
+	Via     string  `json:"via,omitempty" example:"BTC"` // Intermediate currency of a derived price
+}
+
+// PairItem represents a single supported pair
+// @Description Supported currency pair
+type PairItem struct {
+	Pair   string `json:"pair" example:"BTC/USD"`            // Currency pair
+	Symbol string `json:"symbol,omitempty" example:"XBTUSD"` // Upstream provider symbol, when known
+}
+
+// PairsResponse represents the list of supported pairs
+// @Description Supported currency pairs, sorted
+type PairsResponse struct {
+	Pairs []PairItem `json:"pairs"` // Supported pairs
+}
+
+// PreloadRequest maps pairs to the prices to store in the cache
+// @Description Prices to store in the cache, keyed by pair
+type PreloadRequest map[string]json.Number
+
+// PreloadResponse lists the pairs stored by a preload
+// @Description Pairs stored in the cache
+type PreloadResponse struct {
+	Loaded []string `json:"loaded" example:"BTC/EUR,BTC/USD"` // Stored pairs, sorted
+}
+
+// DiagResponse reports the outcome of a live price provider probe
+// @Description Outcome of fetching one pair live from the price provider
+type DiagResponse struct {
+	Pair      string      `json:"pair" example:"BTC/USD"`                                   // Probed pair
+	Symbol    string      `json:"symbol,omitempty" example:"XBTUSD"`                        // Upstream symbol of the pair, when the provider has one
+	OK        bool        `json:"ok"`                                                       // Whether the provider returned a price
+	LatencyMS float64     `json:"latency_ms" example:"142.5"`                               // Round trip time in milliseconds
+	Amount    json.Number `json:"amount,omitempty" swaggertype:"number" example:"52000.12"` // Fetched price, on success
+	Error     string      `json:"error,omitempty" example:"kraken API returned status 500"` // Why the probe failed, on failure
+	CheckedAt time.Time   `json:"checked_at" example:"2024-01-01T12:00:00Z"`                // When the probe started
+}
+
+// ReadinessResponse aggregates the health of the service's dependencies
+// @Description Overall readiness and the state of each checked component
+type ReadinessResponse struct {
+	Status     string            `json:"status" enums:"ok,degraded,down" example:"ok"` // Worst state among the components; down only when a required one is down
+	Components map[string]string `json:"components"`                                   // State of each component by name: ok, degraded or down
+}
+
+// Error codes identify the kind of error for clients, independently of the message.
+// Keep the enums of ErrorResponse.Code in sync when adding one.
+const (
+	CodeBadRequest          = "BAD_REQUEST"
+	CodeInvalidParameter    = "INVALID_PARAMETER"
+	CodeInvalidPair         = "INVALID_PAIR"
+	CodeTooManyPairs        = "TOO_MANY_PAIRS"
+	CodeInvalidCurrency     = "INVALID_CURRENCY"
+	CodeUnknownProvider     = "UNKNOWN_PROVIDER"
+	CodeUnsupportedPair     = "UNSUPPORTED_PAIR"
+	CodeInsufficientHistory = "INSUFFICIENT_HISTORY"
+	CodeUpstreamRateLimited = "UPSTREAM_RATE_LIMITED"
+	CodeUpstreamUnavailable = "UPSTREAM_UNAVAILABLE"
+	CodeRateUnavailable     = "RATE_UNAVAILABLE"
+	CodePriceNotFound       = "PRICE_NOT_FOUND"
+	CodeCandlesUnsupported  = "CANDLES_UNSUPPORTED"
+	CodeUnauthorized        = "UNAUTHORIZED"
+	CodeNotFound            = "NOT_FOUND"
+	CodeMethodNotAllowed    = "METHOD_NOT_ALLOWED"
+	CodeInternalError       = "INTERNAL_ERROR"
+)
+
 // ErrorResponse represents an error response
 // @Description Error response structure
 type ErrorResponse struct {
-	Error string `json:"error" example:"invalid pair: BTC/INVALID"` // Error message
+	// Error message, for humans
+	Error string `json:"error" example:"invalid pair: BTC/INVALID"`
+	// Stable machine-readable error code, one of the Code constants
+	Code string `json:"code" example:"INVALID_PAIR" enums:"BAD_REQUEST,INVALID_PARAMETER,INVALID_PAIR,TOO_MANY_PAIRS,INVALID_CURRENCY,UNKNOWN_PROVIDER,UNSUPPORTED_PAIR,INSUFFICIENT_HISTORY,UPSTREAM_RATE_LIMITED,UPSTREAM_UNAVAILABLE,RATE_UNAVAILABLE,PRICE_NOT_FOUND,CANDLES_UNSUPPORTED,UNAUTHORIZED,NOT_FOUND,METHOD_NOT_ALLOWED,INTERNAL_ERROR"`
+}
+
+// StatusResponse combines build information, uptime and cache state for dashboards
+// @Description Status snapshot of the service
+type StatusResponse struct {
+	Version       string    `json:"version" example:"v1.2.0"`                  // Module version, "(devel)" for local builds
+	Commit        string    `json:"commit,omitempty" example:"3f2a1c9"`        // VCS revision the binary was built from, when known
+	GoVersion     string    `json:"go_version" example:"go1.22.0"`             // Go toolchain the binary was built with
+	StartedAt     time.Time `json:"started_at" example:"2024-01-01T12:00:00Z"` // When the process started
+	UptimeSeconds int64     `json:"uptime_seconds" example:"3600"`             // Seconds since the process started
+	CacheEntries  int       `json:"cache_entries" example:"3"`                 // Number of cached entries, expired ones included
+	Provider      string    `json:"provider" example:"kraken"`                 // Primary price provider
+	SlowUpstream  int64     `json:"slow_upstream_total" example:"0"`           // Upstream calls slower than the configured threshold
+}
+
+// ConfigResponse is the effective, non-secret configuration of the server. Durations
+// are formatted like "1m0s"; settings left unset are omitted and use built-in defaults.
+// @Description Effective runtime configuration with secrets redacted
+type ConfigResponse struct {
+	Provider             string   `json:"provider" example:"kraken"`                                    // Primary price provider
+	Pairs                []string `json:"pairs" example:"BTC/USD,BTC/CHF,BTC/EUR"`                      // Valid pairs
+	DefaultPairs         []string `json:"default_pairs" example:"BTC/USD,BTC/CHF,BTC/EUR"`              // Pairs served when a request names none
+	MaxPairs             int      `json:"max_pairs,omitempty" example:"20"`                             // Most pairs a request may name
+	AllowStale           bool     `json:"allow_stale" example:"false"`                                  // Whether expired prices are served when the upstream fails
+	CacheTTL             string   `json:"cache_ttl" example:"1m0s"`                                     // Global time-to-live of cached prices
+	CacheMaxEntries      int      `json:"cache_max_entries,omitempty" example:"100"`                    // Most cached pairs
+	HistorySize          int      `json:"history_size,omitempty" example:"60"`                          // Stored prices per pair
+	RefreshInterval      string   `json:"refresh_interval,omitempty" example:"30s"`                     // How often the background refresher runs
+	RefreshFailures      int      `json:"refresh_failure_threshold,omitempty" example:"3"`              // Consecutive failures degrading the refresher
+	KrakenTimeout        string   `json:"kraken_timeout,omitempty" example:"10s"`                       // Kraken HTTP client timeout
+	UpstreamTimeout      string   `json:"upstream_timeout,omitempty" example:"3s"`                      // Per-call upstream deadline
+	KrakenBatchSize      int      `json:"kraken_batch_size,omitempty" example:"10"`                     // Pairs per Kraken ticker request
+	SlowUpstream         string   `json:"slow_upstream_threshold,omitempty" example:"2s"`               // Upstream calls slower than this are logged
+	EventsInterval       string   `json:"events_interval,omitempty" example:"5s"`                       // How often the events stream pushes prices
+	StartupSelfTest      string   `json:"startup_selftest" example:"off"`                               // Startup self-test mode: off, warn or fail
+	APIKeys              []string `json:"api_keys,omitempty" example:"REDACTED"`                        // Configured API keys, redacted
+	CORSAllowedOrigins   []string `json:"cors_allowed_origins,omitempty" example:"https://example.com"` // Origins allowed by CORS; all when omitted
+	CompressionMinLength *int     `json:"compression_min_length,omitempty" example:"1024"`              // Smallest compressed response body
+	DebugHTTP            bool     `json:"debug_http" example:"false"`                                   // Whether request and response bodies are logged
+}
+
+// CacheStatsResponse represents cache statistics
+// @Description Cache statistics for operators
+type CacheStatsResponse struct {
+	Entries int        `json:"entries" example:"3"`                             // Number of cached entries
+	Expired int        `json:"expired" example:"1"`                             // Number of expired entries still stored
+	Oldest  *time.Time `json:"oldest,omitempty" example:"2024-01-01T12:00:00Z"` // Timestamp of the oldest entry
+	Newest  *time.Time `json:"newest,omitempty" example:"2024-01-01T12:00:30Z"` // Timestamp of the newest entry
 }
 
+// UpstreamStatusResponse lists the pairs the price provider currently fails to serve
+// @Description Last upstream error of each failing pair
+type UpstreamStatusResponse struct {
+	Errors []PairErrorItem `json:"errors"` // Pairs whose latest fetch failed, sorted by pair; empty when all succeed
+}
+
+// PairErrorItem is the last upstream error of a pair
+type PairErrorItem struct {
+	Pair  string    `json:"pair" example:"BTC/USD"`                           // Currency pair
+	Error string    `json:"error" example:"no data found for symbol BTC/USD"` // Last error fetching the pair
+	At    time.Time `json:"at" example:"2024-01-01T12:00:00Z"`                // When the fetch failed
+}
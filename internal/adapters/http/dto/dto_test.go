@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorResponse_CodeEnumsMatchConstants(t *testing.T) {
+	// Arrange
+	file, err := parser.ParseFile(token.NewFileSet(), "dto.go", nil, 0)
+	require.NoError(t, err)
+	var codes []string
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			value := spec.(*ast.ValueSpec)
+			for i, name := range value.Names {
+				if !strings.HasPrefix(name.Name, "Code") {
+					continue
+				}
+				code, err := strconv.Unquote(value.Values[i].(*ast.BasicLit).Value)
+				require.NoError(t, err)
+				codes = append(codes, code)
+			}
+		}
+	}
+
+	// Act
+	field, _ := reflect.TypeOf(ErrorResponse{}).FieldByName("Code")
+	enums := strings.Split(field.Tag.Get("enums"), ",")
+
+	// Assert
+	require.NotEmpty(t, codes)
+	assert.Equal(t, codes, enums)
+}
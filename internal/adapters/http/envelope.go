@@ -0,0 +1,107 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go-exercise/internal/adapters/http/dto"
+	"go-exercise/internal/domain"
+)
+
+// GetLTPEnvelope handles GET /api/v2/ltp
+// @Summary Get Last Traded Price in a response envelope
+// @Description Same as GET /api/v1/ltp, with the same parameters, but wraps the items in an envelope holding data, meta (request time, provider, cache summary and, when a background refresher runs, its state) and errors. Errors are reported in the envelope too.
+// @Tags ltp
+// @Produce json
+// @Security ApiKeyAuth
+// @Param pairs query string false "Currency pairs (comma-separated, e.g., BTC/USD,BTC/EUR)"
+// @Param quote query string false "Quote currency shorthand for all pairs quoted in it (e.g., USD); cannot be combined with pairs"
+// @Param refresh query bool false "Bypass the cache and fetch fresh prices"
+// @Param order query string false "Result ordering: sorted (default) or requested" Enums(sorted, requested)
+// @Param fields query string false "Optional price fields to include (comma-separated): bid, ask"
+// @Param sla query bool false "Include whether each pair's data age meets its configured freshness SLA"
+// @Param include query string false "Optional computed fields to include (comma-separated): change"
+// @Param provider query string false "Price provider to fetch from, bypassing the cache unless it is the primary one (e.g., kraken, binance); the primary provider when omitted"
+// @Param precision query int false "Round amounts to this many decimals; amounts are exact as reported when omitted" minimum(0) maximum(8)
+// @Param X-Accept-Stale header bool false "Prefer stale cached prices over an error when the upstream is down"
+// @Success 200 {object} dto.EnvelopeResponse{data=[]dto.LTPItem} "Successfully retrieved LTP data"
+// @Failure 400 {object} dto.EnvelopeResponse "Invalid request parameters"
+// @Failure 401 {object} dto.ErrorResponse "Missing or invalid API key, when API keys are configured"
+// @Failure 422 {object} dto.EnvelopeResponse "Pair not supported by any provider"
+// @Failure 500 {object} dto.EnvelopeResponse "Internal server error"
+// @Failure 502 {object} dto.EnvelopeResponse "Upstream price provider unavailable"
+// @Failure 503 {object} dto.EnvelopeResponse "Upstream price provider rate limited; see the Retry-After header"
+// @Router /api/v2/ltp [get]
+func (h *Handler) GetLTPEnvelope(c echo.Context) error {
+	meta := dto.EnvelopeMeta{
+		RequestedAt: time.Now().UTC(),
+		Provider:    h.ltpService.ProviderName(),
+		Refresher:   h.refresherSummary(),
+	}
+
+	query, err := parseLTPQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, envelopeError(meta, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  dto.CodeInvalidParameter,
+		}))
+	}
+	if query.opts.Provider != "" {
+		meta.Provider = query.opts.Provider
+	}
+
+	ltps, err := h.ltpService.GetLTPs(c.Request().Context(), query.pairs, query.opts)
+	if err != nil {
+		setRetryAfter(c, err)
+		return c.JSON(errorStatus(err), envelopeError(meta, errorResponse(err)))
+	}
+
+	meta.Cache = summarizeCache(ltps)
+	return c.JSON(http.StatusOK, dto.EnvelopeResponse{
+		Data:   toLTPItems(ltps, query.fields, query.precision),
+		Meta:   meta,
+		Errors: []dto.ErrorResponse{},
+	})
+}
+
+// envelopeError wraps an error response in an envelope without data
+func envelopeError(meta dto.EnvelopeMeta, errResponse dto.ErrorResponse) dto.EnvelopeResponse {
+	return dto.EnvelopeResponse{
+		Meta:   meta,
+		Errors: []dto.ErrorResponse{errResponse},
+	}
+}
+
+// refresherSummary describes the background refresher's state, or nil when none runs
+func (h *Handler) refresherSummary() *dto.RefresherSummary {
+	if h.refresher == nil {
+		return nil
+	}
+	status := h.refresher.RefresherStatus()
+	summary := &dto.RefresherSummary{
+		ConsecutiveFailures: status.ConsecutiveFailures,
+		Degraded:            status.Degraded,
+	}
+	if !status.LastSuccess.IsZero() {
+		lastSuccess := status.LastSuccess.UTC()
+		summary.LastSuccess = &lastSuccess
+	}
+	return summary
+}
+
+// summarizeCache counts how the given prices were sourced
+func summarizeCache(ltps []domain.LTP) *dto.CacheSummary {
+	summary := &dto.CacheSummary{}
+	for _, ltp := range ltps {
+		switch {
+		case ltp.Stale:
+			summary.Stale++
+		case ltp.Cached:
+			summary.Hits++
+		default:
+			summary.Misses++
+		}
+	}
+	return summary
+}
@@ -0,0 +1,250 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-exercise/internal/adapters/http/dto"
+	"go-exercise/internal/domain"
+	"go-exercise/internal/ports"
+	"go-exercise/internal/ports/mocks"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// envelope mirrors dto.EnvelopeResponse with typed data, to decode responses in tests
+type envelope struct {
+	Data   []dto.LTPItem       `json:"data"`
+	Meta   dto.EnvelopeMeta    `json:"meta"`
+	Errors []dto.ErrorResponse `json:"errors"`
+}
+
+func TestHandler_GetLTPEnvelope_Success(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	btcCHF, _ := domain.NewPair(domain.BTCCHF)
+	ltpService.On("ProviderName").Return("kraken")
+	ltpService.On("GetLTPs", mock.Anything, "", ports.LTPOptions{}).Return([]domain.LTP{
+		{Pair: btcCHF, Amount: 49000.12, Stale: true},
+		{Pair: btcEUR, Amount: 50000.12, Cached: true},
+		{Pair: btcUSD, Amount: 52000.12},
+	}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/ltp", nil)
+	rec := httptest.NewRecorder()
+	before := time.Now().UTC()
+
+	// Act
+	err := handler.GetLTPEnvelope(e.NewContext(req, rec))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var raw map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &raw))
+	assert.ElementsMatch(t, []string{"data", "meta", "errors"}, keys(raw))
+	assert.JSONEq(t, `[]`, string(raw["errors"]))
+
+	var response envelope
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, []dto.LTPItem{
+		{Pair: "BTC/CHF", Amount: "49000.12", Stale: true},
+		{Pair: "BTC/EUR", Amount: "50000.12"},
+		{Pair: "BTC/USD", Amount: "52000.12"},
+	}, response.Data)
+	assert.Equal(t, "kraken", response.Meta.Provider)
+	assert.Equal(t, &dto.CacheSummary{Hits: 1, Misses: 1, Stale: 1}, response.Meta.Cache)
+	assert.Nil(t, response.Meta.Refresher, "no refresher is configured")
+	assert.False(t, response.Meta.RequestedAt.Before(before.Truncate(time.Second)))
+	ltpService.AssertExpectations(t)
+}
+
+func TestHandler_GetLTPEnvelope_SelectedProvider(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	ltpService.On("ProviderName").Return("kraken")
+	ltpService.On("GetLTPs", mock.Anything, "BTC/USD", ports.LTPOptions{Provider: "binance"}).Return([]domain.LTP{
+		{Pair: btcUSD, Amount: 52010},
+	}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/ltp?pairs=BTC/USD&provider=binance", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	err := handler.GetLTPEnvelope(e.NewContext(req, rec))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response envelope
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "binance", response.Meta.Provider)
+	ltpService.AssertExpectations(t)
+}
+
+// refresherStatus is a fixed refresher status for tests
+type refresherStatus domain.RefresherStatus
+
+func (s refresherStatus) RefresherStatus() domain.RefresherStatus {
+	return domain.RefresherStatus(s)
+}
+
+func TestHandler_GetLTPEnvelope_RefresherStatus(t *testing.T) {
+	lastSuccess := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		status   domain.RefresherStatus
+		expected *dto.RefresherSummary
+	}{
+		{
+			name:     "healthy",
+			status:   domain.RefresherStatus{LastSuccess: lastSuccess},
+			expected: &dto.RefresherSummary{LastSuccess: &lastSuccess},
+		},
+		{
+			name:     "degraded",
+			status:   domain.RefresherStatus{LastSuccess: lastSuccess, ConsecutiveFailures: 3, LastError: "upstream down", Degraded: true},
+			expected: &dto.RefresherSummary{LastSuccess: &lastSuccess, ConsecutiveFailures: 3, Degraded: true},
+		},
+		{
+			name:     "never succeeded",
+			status:   domain.RefresherStatus{ConsecutiveFailures: 1, LastError: "upstream down"},
+			expected: &dto.RefresherSummary{ConsecutiveFailures: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ltpService := new(mocks.LTPService)
+			handler := NewHandler(ltpService, WithRefresherStatus(refresherStatus(tt.status)))
+
+			btcUSD, _ := domain.NewPair(domain.BTCUSD)
+			ltpService.On("ProviderName").Return("kraken")
+			ltpService.On("GetLTPs", mock.Anything, "", ports.LTPOptions{}).Return([]domain.LTP{
+				{Pair: btcUSD, Amount: 52000.12, Cached: true},
+			}, nil)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v2/ltp", nil)
+			rec := httptest.NewRecorder()
+
+			// Act
+			err := handler.GetLTPEnvelope(e.NewContext(req, rec))
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			var response envelope
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, tt.expected, response.Meta.Refresher)
+		})
+	}
+}
+
+func TestHandler_GetLTPEnvelope_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		serviceErr     error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "invalid parameter",
+			query:          "?refresh=maybe",
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   dto.CodeInvalidParameter,
+		},
+		{
+			name:           "invalid pair",
+			query:          "?pairs=BTC/INVALID",
+			serviceErr:     fmt.Errorf("invalid pairs: %w: BTC/INVALID", domain.ErrInvalidPair),
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   dto.CodeInvalidPair,
+		},
+		{
+			name:           "upstream unavailable",
+			query:          "?pairs=BTC/USD",
+			serviceErr:     fmt.Errorf("%w: timeout", domain.ErrUpstreamUnavailable),
+			expectedStatus: http.StatusBadGateway,
+			expectedCode:   dto.CodeUpstreamUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ltpService := new(mocks.LTPService)
+			handler := NewHandler(ltpService)
+			ltpService.On("ProviderName").Return("kraken")
+			if tt.serviceErr != nil {
+				ltpService.On("GetLTPs", mock.Anything, mock.Anything, ports.LTPOptions{}).Return(nil, tt.serviceErr)
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v2/ltp"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			// Act
+			err := handler.GetLTPEnvelope(e.NewContext(req, rec))
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			var raw map[string]json.RawMessage
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &raw))
+			assert.JSONEq(t, `null`, string(raw["data"]))
+
+			var response envelope
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Len(t, response.Errors, 1)
+			assert.Equal(t, tt.expectedCode, response.Errors[0].Code)
+			assert.Equal(t, "kraken", response.Meta.Provider)
+			assert.Nil(t, response.Meta.Cache)
+			ltpService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSummarizeCache(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+
+	assert.Equal(t, &dto.CacheSummary{}, summarizeCache(nil))
+	assert.Equal(t, &dto.CacheSummary{Hits: 2, Misses: 1}, summarizeCache([]domain.LTP{
+		{Pair: btcUSD, Cached: true},
+		{Pair: btcUSD, Cached: true},
+		{Pair: btcUSD},
+	}))
+	// Stale values come from the cache too, but are only counted as stale
+	assert.Equal(t, &dto.CacheSummary{Stale: 1}, summarizeCache([]domain.LTP{{Pair: btcUSD, Cached: true, Stale: true}}))
+}
+
+func keys(m map[string]json.RawMessage) []string {
+	result := make([]string, 0, len(m))
+	for key := range m {
+		result = append(result, key)
+	}
+	return result
+}
@@ -0,0 +1,51 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go-exercise/internal/adapters/http/dto"
+)
+
+// httpErrorHandler renders errors that reach Echo, including its own 404 and 405 and
+// failed binds, as dto.ErrorResponse JSON instead of Echo's default body
+func httpErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	var writeErr error
+	var httpErr *echo.HTTPError
+	switch {
+	case !errors.As(err, &httpErr):
+		writeErr = respondError(c, err)
+	case c.Request().Method == http.MethodHead:
+		writeErr = c.NoContent(httpErr.Code)
+	default:
+		writeErr = c.JSON(httpErr.Code, dto.ErrorResponse{
+			Error: fmt.Sprint(httpErr.Message),
+			Code:  httpErrorCode(httpErr.Code),
+		})
+	}
+	if writeErr != nil {
+		c.Logger().Error(writeErr)
+	}
+}
+
+// httpErrorCode maps the status of an Echo HTTP error to an error code
+func httpErrorCode(status int) string {
+	switch {
+	case status == http.StatusUnauthorized:
+		return dto.CodeUnauthorized
+	case status == http.StatusNotFound:
+		return dto.CodeNotFound
+	case status == http.StatusMethodNotAllowed:
+		return dto.CodeMethodNotAllowed
+	case status >= http.StatusInternalServerError:
+		return dto.CodeInternalError
+	default:
+		return dto.CodeBadRequest
+	}
+}
@@ -0,0 +1,88 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-exercise/internal/adapters/http/dto"
+	"go-exercise/internal/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPErrorHandler_RendersJSON(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   string
+		expectedMsg    string
+	}{
+		{"not found", echo.ErrNotFound, http.StatusNotFound, dto.CodeNotFound, "Not Found"},
+		{"method not allowed", echo.ErrMethodNotAllowed, http.StatusMethodNotAllowed, dto.CodeMethodNotAllowed, "Method Not Allowed"},
+		{"unauthorized", echo.ErrUnauthorized, http.StatusUnauthorized, dto.CodeUnauthorized, "Unauthorized"},
+		{"failed bind", echo.NewHTTPError(http.StatusBadRequest, "Syntax error: offset=12").SetInternal(errors.New("unexpected EOF")), http.StatusBadRequest, dto.CodeBadRequest, "Syntax error"},
+		{"other client error", echo.ErrStatusRequestEntityTooLarge, http.StatusRequestEntityTooLarge, dto.CodeBadRequest, "Request Entity Too Large"},
+		{"server error", echo.ErrServiceUnavailable, http.StatusServiceUnavailable, dto.CodeInternalError, "Service Unavailable"},
+		{"domain error", fmt.Errorf("%w: BTC/XYZ", domain.ErrInvalidPair), http.StatusBadRequest, dto.CodeInvalidPair, "BTC/XYZ"},
+		{"plain error", errors.New("boom"), http.StatusInternalServerError, dto.CodeInternalError, "boom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// Act
+			httpErrorHandler(tt.err, c)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			var response dto.ErrorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedCode, response.Code)
+			assert.Contains(t, response.Error, tt.expectedMsg)
+		})
+	}
+}
+
+func TestHTTPErrorHandler_HeadHasNoBody(t *testing.T) {
+	// Arrange
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodHead, "/api/v1/nonexistent", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	httpErrorHandler(echo.ErrNotFound, c)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Empty(t, rec.Body.String())
+}
+
+func TestHTTPErrorHandler_CommittedResponseIsLeftAlone(t *testing.T) {
+	// Arrange
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	require.NoError(t, c.String(http.StatusOK, "partial"))
+
+	// Act
+	httpErrorHandler(echo.ErrNotFound, c)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "partial", rec.Body.String())
+}
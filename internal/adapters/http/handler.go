@@ -1,60 +1,660 @@
 package http
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"go-exercise/internal/adapters/http/dto"
+	"go-exercise/internal/config"
+	"go-exercise/internal/domain"
 	"go-exercise/internal/ports"
 )
 
+// HeaderAcceptStale lets clients choose whether they prefer stale data over an error when the upstream is down
+const HeaderAcceptStale = "X-Accept-Stale"
+
+// MaxPrecision is the most decimals the precision query parameter accepts
+const MaxPrecision = 8
+
+// keepPrecision leaves amounts exactly as reported by the provider
+const keepPrecision = -1
+
+// DefaultHistoryLimit is how many history samples are returned when no limit is given
+const DefaultHistoryLimit = 50
+
+// DefaultTWAPWindow is the TWAP window used when no window is given
+const DefaultTWAPWindow = 5 * time.Minute
+
+// DefaultEventsInterval is how often the events stream pushes prices unless configured otherwise
+const DefaultEventsInterval = 5 * time.Second
+
 // Handler handles HTTP requests
 type Handler struct {
-	ltpService ports.LTPService
+	ltpService     ports.LTPService
+	eventsInterval time.Duration
+	symbols        ports.SymbolResolver
+	// external is probed directly by the diagnostics endpoint; nil disables it
+	external ports.External
+	// refresher reports the background refresher's state in envelope metadata; nil omits it
+	refresher ports.RefresherStatusReporter
+	// config is served by the configuration endpoint; nil disables it
+	config *config.Config
+	// cacheTTL bounds the Cache-Control max-age of LTP responses; nil omits the header
+	cacheTTL ports.TTLSource
+	now      func() time.Time
+	// healthChecks are the components checked by the readiness endpoint
+	healthChecks []healthCheck
+	// closing is closed by Shutdown to end open event streams
+	closing   chan struct{}
+	closeOnce sync.Once
+}
+
+// HandlerOption configures optional Handler behavior
+type HandlerOption func(*Handler)
+
+// WithEventsInterval sets how often the events stream pushes prices
+func WithEventsInterval(interval time.Duration) HandlerOption {
+	return func(h *Handler) {
+		h.eventsInterval = interval
+	}
+}
+
+// WithSymbolResolver includes upstream symbols in the supported pairs listing
+func WithSymbolResolver(symbols ports.SymbolResolver) HandlerOption {
+	return func(h *Handler) {
+		h.symbols = symbols
+	}
+}
+
+// WithRefresherStatus reports the background refresher's state in the metadata of
+// enveloped responses
+func WithRefresherStatus(refresher ports.RefresherStatusReporter) HandlerOption {
+	return func(h *Handler) {
+		h.refresher = refresher
+	}
+}
+
+// WithConfig serves the effective configuration, with secrets redacted, at
+// GET /api/v1/config when API keys guard it
+func WithConfig(cfg config.Config) HandlerOption {
+	return func(h *Handler) {
+		h.config = &cfg
+	}
+}
+
+// WithCacheTTL sets Cache-Control: max-age on LTP responses to the smallest remaining
+// time-to-live among the returned prices. The TTLs are read from the cache on every
+// request, so reloaded TTLs and per-pair floors are honored.
+func WithCacheTTL(ttls ports.TTLSource) HandlerOption {
+	return func(h *Handler) {
+		h.cacheTTL = ttls
+	}
 }
 
 // NewHandler creates a new HTTP handler
-func NewHandler(ltpService ports.LTPService) *Handler {
-	return &Handler{
-		ltpService: ltpService,
+func NewHandler(ltpService ports.LTPService, opts ...HandlerOption) *Handler {
+	h := &Handler{
+		ltpService:     ltpService,
+		eventsInterval: DefaultEventsInterval,
+		now:            time.Now,
+		closing:        make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(h)
 	}
+	return h
 }
 
 // GetLTP handles GET /api/v1/ltp
 // @Summary Get Last Traded Price
-// @Description Get LTP for BTC currency pairs (BTC/USD, BTC/CHF, BTC/EUR). If no pairs are specified, returns all pairs.
+// @Description Get LTP for BTC currency pairs (BTC/USD, BTC/CHF, BTC/EUR). If no pairs are specified, returns the default pairs (all pairs unless the server configures DEFAULT_PAIRS). Responds with XML when the Accept header prefers application/xml or text/xml, JSON otherwise.
 // @Tags ltp
 // @Accept json
-// @Produce json
+// @Produce json,xml,application/x-ndjson
+// @Security ApiKeyAuth
 // @Param pairs query string false "Currency pairs (comma-separated, e.g., BTC/USD,BTC/EUR)"
-// @Success 200 {object} dto.LTPResponse "Successfully retrieved LTP data"
+// @Param quote query string false "Quote currency shorthand for all pairs quoted in it (e.g., USD); cannot be combined with pairs"
+// @Param refresh query bool false "Bypass the cache and fetch fresh prices"
+// @Param order query string false "Result ordering: sorted (default) or requested" Enums(sorted, requested)
+// @Param fields query string false "Optional price fields to include (comma-separated): bid, ask"
+// @Param sla query bool false "Include whether each pair's data age meets its configured freshness SLA"
+// @Param include query string false "Optional computed fields to include (comma-separated): change"
+// @Param precision query int false "Round amounts to this many decimals; amounts are exact as reported when omitted" minimum(0) maximum(8)
+// @Param debug query bool false "Echo back the normalized query the server interpreted and include the upstream symbol of each price"
+// @Param pretty query bool false "Indent the response for human reading; compact when omitted"
+// @Param format query string false "Stream each price as its own line of newline-delimited JSON instead of one response object; the debug query echo is omitted" Enums(ndjson)
+// @Param provider query string false "Price provider to fetch from, bypassing the cache unless it is the primary one (e.g., kraken, binance); the primary provider when omitted"
+// @Param X-Accept-Stale header bool false "Prefer stale cached prices over an error when the upstream is down"
+// @Param If-None-Match header string false "ETag of a previous response; answered with 304 if the data is unchanged"
+// @Param If-Modified-Since header string false "Last-Modified of a previous response; answered with 304 if no price was updated since. Ignored when If-None-Match is given"
+// @Success 200 {object} dto.LTPResponse "Successfully retrieved LTP data; Last-Modified is the newest update time among the prices, and Cache-Control max-age the shortest time any of them stays cached"
+// @Success 304 "Data unchanged since the given ETag or date"
 // @Failure 400 {object} dto.ErrorResponse "Invalid request parameters"
+// @Failure 401 {object} dto.ErrorResponse "Missing or invalid API key, when API keys are configured"
+// @Failure 422 {object} dto.ErrorResponse "Pair not supported by any provider"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Failure 502 {object} dto.ErrorResponse "Upstream price provider unavailable"
+// @Failure 503 {object} dto.ErrorResponse "Upstream price provider rate limited; see the Retry-After header"
 // @Router /api/v1/ltp [get]
 func (h *Handler) GetLTP(c echo.Context) error {
-	pairsStr := c.QueryParam("pairs")
-
-	ltps, err := h.ltpService.GetLTPs(pairsStr)
+	query, err := parseLTPQuery(c)
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
 			Error: err.Error(),
+			Code:  dto.CodeInvalidParameter,
 		})
 	}
 
-	// Convert domain LTPs to DTOs
+	ltps, err := h.ltpService.GetLTPs(c.Request().Context(), query.pairs, query.opts)
+	if err != nil {
+		return respondError(c, err)
+	}
+	h.setCacheControl(c, ltps)
+	if setLastModified(c, lastModified(ltps)) {
+		return c.NoContent(http.StatusNotModified)
+	}
+	if query.ndjson {
+		return streamNDJSON(c, ltps, query.fields, query.precision)
+	}
+
+	response := dto.LTPResponse{
+		LTP: toLTPItems(ltps, query.fields, query.precision),
+	}
+	if query.debug {
+		response.Query = canonicalQuery(h.requestedPairs(query), query, h.ltpService.ProviderName())
+	}
+
+	return respondNegotiated(c, response, query.pretty)
+}
+
+// ltpQuery holds the parsed parameters of an LTP request
+type ltpQuery struct {
+	pairs     string
+	opts      ports.LTPOptions
+	fields    ltpFields
+	precision int
+	debug     bool
+	pretty    bool
+	// ndjson streams one item per line instead of a single response object
+	ndjson bool
+}
+
+// parseLTPQuery parses and validates the query parameters and headers of an LTP request
+func parseLTPQuery(c echo.Context) (ltpQuery, error) {
+	query := ltpQuery{pairs: c.QueryParam("pairs")}
+
+	// A quote currency is shorthand for every pair quoted in it, which the service expands
+	if quote := c.QueryParam("quote"); quote != "" {
+		if query.pairs != "" {
+			return ltpQuery{}, fmt.Errorf("pairs and quote cannot be combined")
+		}
+		if _, err := domain.PairsByQuote(quote); err != nil {
+			return ltpQuery{}, err
+		}
+		query.opts.Quote = strings.ToUpper(strings.TrimSpace(quote))
+	}
+
+	forceRefresh, err := parseBoolParam(c, "refresh")
+	if err != nil {
+		return ltpQuery{}, err
+	}
+	query.opts.ForceRefresh = forceRefresh
+
+	switch order := ports.Order(c.QueryParam("order")); order {
+	case "", ports.OrderSorted, ports.OrderRequested:
+		query.opts.Order = order
+	default:
+		return ltpQuery{}, fmt.Errorf("invalid order value: %s. Valid values are: %s, %s", order, ports.OrderSorted, ports.OrderRequested)
+	}
+
+	if header := c.Request().Header.Get(HeaderAcceptStale); header != "" {
+		acceptStale, err := strconv.ParseBool(header)
+		if err != nil {
+			return ltpQuery{}, fmt.Errorf("invalid %s header value: %s", HeaderAcceptStale, header)
+		}
+		query.opts.AcceptStale = &acceptStale
+	}
+
+	if query.fields, err = parseFields(c.QueryParam("fields")); err != nil {
+		return ltpQuery{}, err
+	}
+	if query.opts.CheckSLA, err = parseBoolParam(c, "sla"); err != nil {
+		return ltpQuery{}, err
+	}
+	if query.opts.IncludeChange, err = parseInclude(c.QueryParam("include")); err != nil {
+		return ltpQuery{}, err
+	}
+	if query.precision, err = parsePrecision(c.QueryParam("precision")); err != nil {
+		return ltpQuery{}, err
+	}
+	if query.debug, err = parseBoolParam(c, "debug"); err != nil {
+		return ltpQuery{}, err
+	}
+	query.fields.symbol = query.debug
+	if query.pretty, err = parseBoolParam(c, "pretty"); err != nil {
+		return ltpQuery{}, err
+	}
+	query.opts.Provider = strings.ToLower(strings.TrimSpace(c.QueryParam("provider")))
+	switch format := c.QueryParam("format"); strings.ToLower(format) {
+	case "":
+	case "ndjson":
+		query.ndjson = true
+	default:
+		return ltpQuery{}, fmt.Errorf("invalid format value: %s. Valid values are: ndjson", format)
+	}
+	return query, nil
+}
+
+// ltpFields selects the optional price fields included in LTP responses
+type ltpFields struct {
+	bid bool
+	ask bool
+	// symbol includes the upstream symbol, for debugging symbol mappings
+	symbol bool
+}
+
+// parseFields parses the comma-separated fields query parameter
+func parseFields(raw string) (ltpFields, error) {
+	var fields ltpFields
+	if raw == "" {
+		return fields, nil
+	}
+	for _, field := range strings.Split(raw, ",") {
+		switch strings.ToLower(strings.TrimSpace(field)) {
+		case "bid":
+			fields.bid = true
+		case "ask":
+			fields.ask = true
+		default:
+			return ltpFields{}, fmt.Errorf("invalid fields value: %s. Valid fields are: bid, ask", field)
+		}
+	}
+	return fields, nil
+}
+
+// parsePrecision parses the optional precision query parameter, defaulting to keepPrecision
+func parsePrecision(raw string) (int, error) {
+	if raw == "" {
+		return keepPrecision, nil
+	}
+	precision, err := strconv.Atoi(raw)
+	if err != nil || precision < 0 || precision > MaxPrecision {
+		return 0, fmt.Errorf("invalid precision value: %s. Must be an integer between 0 and %d", raw, MaxPrecision)
+	}
+	return precision, nil
+}
+
+// roundAmount rounds a decimal amount to the given number of decimals, halves away from zero.
+// The decimal string is rounded exactly, so e.g. 1.005 becomes 1.01 despite float representation.
+func roundAmount(amount string, precision int) string {
+	if precision < 0 {
+		return amount
+	}
+	value, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return amount
+	}
+	return value.FloatString(precision)
+}
+
+// parseInclude parses the comma-separated include query parameter and reports whether
+// the 24-hour change was requested
+func parseInclude(raw string) (change bool, err error) {
+	if raw == "" {
+		return false, nil
+	}
+	for _, value := range strings.Split(raw, ",") {
+		switch strings.ToLower(strings.TrimSpace(value)) {
+		case "change":
+			change = true
+		default:
+			return false, fmt.Errorf("invalid include value: %s. Valid values are: change", value)
+		}
+	}
+	return change, nil
+}
+
+// toLTPItems converts domain LTPs to DTOs, rounding amounts to the given number of
+// decimals; keepPrecision leaves them exactly as reported
+func toLTPItems(ltps []domain.LTP, fields ltpFields, precision int) []dto.LTPItem {
 	ltpItems := make([]dto.LTPItem, len(ltps))
 	for i, ltp := range ltps {
-		ltpItems[i] = dto.LTPItem{
-			Pair:   ltp.Pair.Value(),
-			Amount: ltp.Amount,
+		ltpItems[i] = toLTPItem(ltp, fields, precision)
+	}
+	return ltpItems
+}
+
+// toLTPItem converts a single domain LTP, as toLTPItems does
+func toLTPItem(ltp domain.LTP, fields ltpFields, precision int) dto.LTPItem {
+	item := dto.LTPItem{
+		Pair:   ltp.Pair.Value(),
+		Amount: json.Number(roundAmount(ltp.PreciseAmount(), precision)),
+		Stale:  ltp.Stale,
+		SLAOK:  ltp.WithinSLA,
+	}
+	if fields.bid && ltp.Bid != 0 {
+		item.Bid = &ltp.Bid
+	}
+	if fields.ask && ltp.Ask != 0 {
+		item.Ask = &ltp.Ask
+	}
+	if fields.symbol {
+		item.Symbol = ltp.Symbol
+	}
+	if ltp.Change24h != nil {
+		item.Change24h = &ltp.Change24h.Amount
+		item.ChangePct24h = &ltp.Change24h.Percent
+	}
+	if !ltp.At.IsZero() {
+		item.SourceTime = &ltp.At
+	}
+	return item
+}
+
+// errorStatus maps typed domain errors to HTTP status codes
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidPair), errors.Is(err, domain.ErrTooManyPairs), errors.Is(err, domain.ErrInvalidCurrency),
+		errors.Is(err, domain.ErrUnknownProvider):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrUnsupportedPair), errors.Is(err, domain.ErrInsufficientHistory):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, domain.ErrRateLimited):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, domain.ErrUpstreamUnavailable), errors.Is(err, domain.ErrRateUnavailable),
+		errors.Is(err, domain.ErrPriceNotFound):
+		return http.StatusBadGateway
+	case errors.Is(err, domain.ErrCandlesUnsupported):
+		return http.StatusNotImplemented
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// errorCode maps typed domain errors to stable error codes
+func errorCode(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrInvalidPair):
+		return dto.CodeInvalidPair
+	case errors.Is(err, domain.ErrTooManyPairs):
+		return dto.CodeTooManyPairs
+	case errors.Is(err, domain.ErrInvalidCurrency):
+		return dto.CodeInvalidCurrency
+	case errors.Is(err, domain.ErrUnknownProvider):
+		return dto.CodeUnknownProvider
+	case errors.Is(err, domain.ErrUnsupportedPair):
+		return dto.CodeUnsupportedPair
+	case errors.Is(err, domain.ErrInsufficientHistory):
+		return dto.CodeInsufficientHistory
+	case errors.Is(err, domain.ErrRateLimited):
+		return dto.CodeUpstreamRateLimited
+	case errors.Is(err, domain.ErrUpstreamUnavailable):
+		return dto.CodeUpstreamUnavailable
+	case errors.Is(err, domain.ErrRateUnavailable):
+		return dto.CodeRateUnavailable
+	case errors.Is(err, domain.ErrPriceNotFound):
+		return dto.CodePriceNotFound
+	case errors.Is(err, domain.ErrCandlesUnsupported):
+		return dto.CodeCandlesUnsupported
+	default:
+		return dto.CodeInternalError
+	}
+}
+
+// errorResponse builds the response body for a typed domain error
+func errorResponse(err error) dto.ErrorResponse {
+	return dto.ErrorResponse{
+		Error: err.Error(),
+		Code:  errorCode(err),
+	}
+}
+
+// respondError writes the response for a typed domain error
+func respondError(c echo.Context, err error) error {
+	setRetryAfter(c, err)
+	return c.JSON(errorStatus(err), errorResponse(err))
+}
+
+// setRetryAfter passes the wait suggested by a rate limiting price provider on in a
+// Retry-After header
+func setRetryAfter(c echo.Context, err error) {
+	var rateLimitErr *domain.RateLimitError
+	if errors.As(err, &rateLimitErr) && rateLimitErr.RetryAfter > 0 {
+		seconds := int(math.Ceil(rateLimitErr.RetryAfter.Seconds()))
+		c.Response().Header().Set("Retry-After", strconv.Itoa(seconds))
+	}
+}
+
+// parseBoolParam parses an optional boolean query parameter, defaulting to false
+func parseBoolParam(c echo.Context, name string) (bool, error) {
+	raw := c.QueryParam(name)
+	if raw == "" {
+		return false, nil
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s value: %s", name, raw)
+	}
+	return value, nil
+}
+
+// requestedPairs returns the pairs an LTP request asked for as the service interprets
+// them, whether or not a price was found for each. The request is expected to have been
+// served already, so the pairs are known to parse.
+func (h *Handler) requestedPairs(query ltpQuery) []domain.Pair {
+	if query.opts.Quote != "" {
+		pairs, _ := domain.PairsByQuote(query.opts.Quote)
+		return pairs
+	}
+	validation, _ := h.ltpService.ValidatePairs(query.pairs)
+	return validation.Valid
+}
+
+// canonicalQuery returns the server-normalized form of an LTP request:
+// the sorted, deduplicated pairs followed by every resolved option, with an empty value
+// for an option left at its default. The provider falls back to the primary one.
+func canonicalQuery(pairs []domain.Pair, query ltpQuery, primary string) string {
+	opts := query.opts
+	order := opts.Order
+	if order == "" {
+		order = ports.OrderSorted
+	}
+	provider := opts.Provider
+	if provider == "" {
+		provider = primary
+	}
+	var fields []string
+	if query.fields.bid {
+		fields = append(fields, "bid")
+	}
+	if query.fields.ask {
+		fields = append(fields, "ask")
+	}
+	var precision string
+	if query.precision != keepPrecision {
+		precision = strconv.Itoa(query.precision)
+	}
+	var include string
+	if opts.IncludeChange {
+		include = "change"
+	}
+	return fmt.Sprintf("pairs=%s&refresh=%t&order=%s&provider=%s&fields=%s&precision=%s&sla=%t&include=%s",
+		domain.CanonicalPairs(pairs), opts.ForceRefresh, order, provider, strings.Join(fields, ","), precision, opts.CheckSLA, include)
+}
+
+// GetHistory handles GET /api/v1/ltp/history
+// @Summary Get price history
+// @Description Get the most recent stored prices for a pair, oldest first. Empty when history is disabled.
+// @Description Older pages are fetched by passing the returned next cursor as before.
+// @Tags ltp
+// @Produce json
+// @Security ApiKeyAuth
+// @Param pair query string true "Currency pair (e.g., BTC/USD)"
+// @Param limit query int false "Maximum number of samples to return" default(50)
+// @Param before query string false "Only return samples stored before this RFC 3339 timestamp, e.g. a previous next cursor"
+// @Success 200 {object} dto.HistoryResponse "Successfully retrieved price history"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request parameters"
+// @Failure 401 {object} dto.ErrorResponse "Missing or invalid API key, when API keys are configured"
+// @Failure 422 {object} dto.ErrorResponse "Pair not supported by any provider"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /api/v1/ltp/history [get]
+func (h *Handler) GetHistory(c echo.Context) error {
+	limit := DefaultHistoryLimit
+	if raw := c.QueryParam("limit"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value <= 0 {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: fmt.Sprintf("invalid limit value: %s", raw),
+				Code:  dto.CodeInvalidParameter,
+			})
 		}
+		limit = value
 	}
 
-	return c.JSON(http.StatusOK, dto.LTPResponse{
-		LTP: ltpItems,
+	var before time.Time
+	if raw := c.QueryParam("before"); raw != "" {
+		value, err := time.Parse(time.RFC3339Nano, raw)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: fmt.Sprintf("invalid before value: %s", raw),
+				Code:  dto.CodeInvalidParameter,
+			})
+		}
+		before = value
+	}
+
+	page, err := h.ltpService.GetHistory(c.QueryParam("pair"), before, limit)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	// The service already validated the pair
+	pair, _ := domain.NewPair(c.QueryParam("pair"))
+	response := dto.HistoryResponse{
+		Pair:    pair.Value(),
+		Samples: make([]dto.HistorySample, len(page.Samples)),
+	}
+	for i, sample := range page.Samples {
+		response.Samples[i] = dto.HistorySample{
+			Amount:    json.Number(sample.LTP.PreciseAmount()),
+			Timestamp: sample.Timestamp,
+		}
+	}
+	// The oldest returned sample bounds the next, older page
+	if page.More && len(page.Samples) > 0 {
+		response.Next = page.Samples[0].Timestamp.Format(time.RFC3339Nano)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetTWAP handles GET /api/v1/ltp/twap
+// @Summary Get time-weighted average price
+// @Description Get the time-weighted average price of a pair over a window ending now, computed from stored price history
+// @Tags ltp
+// @Produce json
+// @Security ApiKeyAuth
+// @Param pair query string true "Currency pair (e.g., BTC/USD)"
+// @Param window query string false "Averaging window as a duration (e.g., 5m)" default(5m)
+// @Success 200 {object} dto.TWAPResponse "Successfully computed TWAP"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request parameters"
+// @Failure 401 {object} dto.ErrorResponse "Missing or invalid API key, when API keys are configured"
+// @Failure 422 {object} dto.ErrorResponse "Pair not supported, or not enough history to cover the window"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /api/v1/ltp/twap [get]
+func (h *Handler) GetTWAP(c echo.Context) error {
+	window := DefaultTWAPWindow
+	if raw := c.QueryParam("window"); raw != "" {
+		value, err := time.ParseDuration(raw)
+		if err != nil || value <= 0 {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: fmt.Sprintf("invalid window value: %s", raw),
+				Code:  dto.CodeInvalidParameter,
+			})
+		}
+		window = value
+	}
+
+	twap, err := h.ltpService.GetTWAP(c.QueryParam("pair"), window)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	// The service already validated the pair
+	pair, _ := domain.NewPair(c.QueryParam("pair"))
+	return c.JSON(http.StatusOK, dto.TWAPResponse{
+		Pair:   pair.Value(),
+		Window: window.String(),
+		TWAP:   twap,
 	})
 }
 
+// GetPairs handles GET /api/v1/pairs
+// @Summary List supported pairs
+// @Description List the supported currency pairs in sorted order, with their upstream symbols when known
+// @Tags ltp
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.PairsResponse "Successfully retrieved supported pairs"
+// @Failure 401 {object} dto.ErrorResponse "Missing or invalid API key, when API keys are configured"
+// @Router /api/v1/pairs [get]
+func (h *Handler) GetPairs(c echo.Context) error {
+	values := domain.SupportedPairs()
+	response := dto.PairsResponse{
+		Pairs: make([]dto.PairItem, len(values)),
+	}
+	for i, value := range values {
+		response.Pairs[i] = dto.PairItem{Pair: value}
+		if h.symbols == nil {
+			continue
+		}
+		pair, err := domain.NewPair(value)
+		if err != nil {
+			continue
+		}
+		if symbol, ok := h.symbols.Symbol(pair); ok {
+			response.Pairs[i].Symbol = symbol
+		}
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetCacheStats handles GET /api/v1/cache/stats
+// @Summary Get cache statistics
+// @Description Get the number of cached entries, how many are expired, and the oldest/newest entry timestamps
+// @Tags cache
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.CacheStatsResponse "Successfully retrieved cache statistics"
+// @Failure 401 {object} dto.ErrorResponse "Missing or invalid API key, when API keys are configured"
+// @Router /api/v1/cache/stats [get]
+func (h *Handler) GetCacheStats(c echo.Context) error {
+	stats := h.ltpService.GetCacheStats()
+
+	response := dto.CacheStatsResponse{
+		Entries: stats.Entries,
+		Expired: stats.Expired,
+	}
+	if !stats.Oldest.IsZero() {
+		response.Oldest = &stats.Oldest
+	}
+	if !stats.Newest.IsZero() {
+		response.Newest = &stats.Newest
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
 // Health handles GET /health
 // @Summary Health check
 // @Description Health check endpoint
@@ -67,4 +667,3 @@ func (h *Handler) Health(c echo.Context) error {
 		"status": "ok",
 	})
 }
-
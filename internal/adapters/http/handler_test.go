@@ -2,17 +2,26 @@ package http
 
 import (
 	"encoding/json"
+	"encoding/xml"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"go-exercise/internal/adapters/http/dto"
+	"go-exercise/internal/application/service"
 	"go-exercise/internal/domain"
+	"go-exercise/internal/ports"
 	"go-exercise/internal/ports/mocks"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewHandler(t *testing.T) {
@@ -39,7 +48,7 @@ func TestHandler_GetLTP_Success_AllPairs(t *testing.T) {
 		{Pair: btcUSD, Amount: 52000.12},
 	}
 
-	ltpService.On("GetLTPs", "").Return(expectedLTPs, nil)
+	ltpService.On("GetLTPs", mock.Anything, "", ports.LTPOptions{}).Return(expectedLTPs, nil)
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp", nil)
@@ -58,11 +67,11 @@ func TestHandler_GetLTP_Success_AllPairs(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, response.LTP, 3)
 	assert.Equal(t, "BTC/CHF", response.LTP[0].Pair)
-	assert.Equal(t, 49000.12, response.LTP[0].Amount)
+	assert.Equal(t, json.Number("49000.12"), response.LTP[0].Amount)
 	assert.Equal(t, "BTC/EUR", response.LTP[1].Pair)
-	assert.Equal(t, 50000.12, response.LTP[1].Amount)
+	assert.Equal(t, json.Number("50000.12"), response.LTP[1].Amount)
 	assert.Equal(t, "BTC/USD", response.LTP[2].Pair)
-	assert.Equal(t, 52000.12, response.LTP[2].Amount)
+	assert.Equal(t, json.Number("52000.12"), response.LTP[2].Amount)
 
 	ltpService.AssertExpectations(t)
 }
@@ -77,7 +86,7 @@ func TestHandler_GetLTP_Success_SinglePair(t *testing.T) {
 		{Pair: btcUSD, Amount: 52000.12},
 	}
 
-	ltpService.On("GetLTPs", "BTC/USD").Return(expectedLTPs, nil)
+	ltpService.On("GetLTPs", mock.Anything, "BTC/USD", ports.LTPOptions{}).Return([]domain.LTP{expectedLTPs[0]}, nil)
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD", nil)
@@ -96,11 +105,351 @@ func TestHandler_GetLTP_Success_SinglePair(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, response.LTP, 1)
 	assert.Equal(t, "BTC/USD", response.LTP[0].Pair)
-	assert.Equal(t, 52000.12, response.LTP[0].Amount)
+	assert.Equal(t, json.Number("52000.12"), response.LTP[0].Amount)
 
 	ltpService.AssertExpectations(t)
 }
 
+func TestHandler_GetLTP_EmitsRawAmount(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	expectedLTPs := []domain.LTP{
+		{Pair: btcUSD, Amount: 52000.1, RawAmount: "52000.10000"},
+	}
+
+	ltpService.On("GetLTPs", mock.Anything, "BTC/USD", ports.LTPOptions{}).Return([]domain.LTP{expectedLTPs[0]}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.GetLTP(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"amount":52000.10000`)
+
+	ltpService.AssertExpectations(t)
+}
+
+func TestHandler_GetLTP_ETag(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	ltpService.On("GetLTPs", mock.Anything, "BTC/USD", ports.LTPOptions{}).Return([]domain.LTP{{Pair: btcUSD, Amount: 52000.12}}, nil).Twice()
+	ltpService.On("GetLTPs", mock.Anything, "BTC/USD", ports.LTPOptions{}).Return([]domain.LTP{{Pair: btcUSD, Amount: 52001.5}}, nil).Once()
+
+	e := echo.New()
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		rec := httptest.NewRecorder()
+		require.NoError(t, handler.GetLTP(e.NewContext(req, rec)))
+		return rec
+	}
+
+	// Act
+	first := get("")
+	etag := first.Header().Get("ETag")
+	unchanged := get(etag)
+	changed := get(etag)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.True(t, strings.HasPrefix(etag, `W/"`), "expected a weak ETag, got %q", etag)
+
+	assert.Equal(t, http.StatusNotModified, unchanged.Code)
+	assert.Empty(t, unchanged.Body.String())
+	assert.Equal(t, etag, unchanged.Header().Get("ETag"))
+
+	assert.Equal(t, http.StatusOK, changed.Code)
+	assert.NotEqual(t, etag, changed.Header().Get("ETag"))
+	assert.Contains(t, changed.Body.String(), "52001.5")
+
+	ltpService.AssertExpectations(t)
+}
+
+func TestHandler_GetLTP_IfModifiedSince(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	older := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	newest := time.Date(2024, 1, 1, 12, 0, 30, 500, time.UTC)
+	ltps := []domain.LTP{
+		{Pair: btcEUR, Amount: 50000.12, UpdatedAt: older},
+		{Pair: btcUSD, Amount: 52000.12, UpdatedAt: newest},
+	}
+
+	tests := []struct {
+		name            string
+		ifModifiedSince string
+		ifNoneMatch     string
+		expectedStatus  int
+	}{
+		{name: "no condition", expectedStatus: http.StatusOK},
+		{name: "client copy is current", ifModifiedSince: "Mon, 01 Jan 2024 12:00:30 GMT", expectedStatus: http.StatusNotModified},
+		{name: "client copy is newer", ifModifiedSince: "Mon, 01 Jan 2024 13:00:00 GMT", expectedStatus: http.StatusNotModified},
+		{name: "stale client", ifModifiedSince: "Mon, 01 Jan 2024 12:00:29 GMT", expectedStatus: http.StatusOK},
+		{name: "malformed date is ignored", ifModifiedSince: "yesterday", expectedStatus: http.StatusOK},
+		{name: "If-None-Match takes precedence", ifModifiedSince: "Mon, 01 Jan 2024 13:00:00 GMT", ifNoneMatch: `W/"other"`, expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ltpService := new(mocks.LTPService)
+			handler := NewHandler(ltpService)
+			ltpService.On("GetLTPs", mock.Anything, "BTC/USD,BTC/EUR", ports.LTPOptions{}).Return(ltps, nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD,BTC/EUR", nil)
+			if tt.ifModifiedSince != "" {
+				req.Header.Set("If-Modified-Since", tt.ifModifiedSince)
+			}
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+
+			// Act
+			err := handler.GetLTP(echo.New().NewContext(req, rec))
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, "Mon, 01 Jan 2024 12:00:30 GMT", rec.Header().Get("Last-Modified"))
+			if tt.expectedStatus == http.StatusNotModified {
+				assert.Empty(t, rec.Body.String())
+			} else {
+				assert.Contains(t, rec.Body.String(), "52000.12")
+			}
+		})
+	}
+}
+
+func TestHandler_GetLTP_NoLastModifiedWhenUnknown(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	ltpService.On("GetLTPs", mock.Anything, "BTC/USD", ports.LTPOptions{}).Return([]domain.LTP{{Pair: btcUSD, Amount: 52000.12}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD", nil)
+	req.Header.Set("If-Modified-Since", "Mon, 01 Jan 2024 12:00:00 GMT")
+	rec := httptest.NewRecorder()
+
+	// Act
+	err := handler.GetLTP(echo.New().NewContext(req, rec))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("Last-Modified"))
+}
+
+func TestHandler_GetLTP_SourceTime(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	sourceTime := time.Date(2024, 1, 1, 12, 0, 0, 123_000_000, time.UTC)
+	ltpService.On("GetLTPs", mock.Anything, "BTC/USD,BTC/EUR", ports.LTPOptions{}).Return([]domain.LTP{
+		{Pair: btcEUR, Amount: 50000.12, At: sourceTime},
+		{Pair: btcUSD, Amount: 52000.12},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD,BTC/EUR", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	err := handler.GetLTP(echo.New().NewContext(req, rec))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"ltp":[
+		{"pair":"BTC/EUR","amount":50000.12,"source_time":"2024-01-01T12:00:00.123Z"},
+		{"pair":"BTC/USD","amount":52000.12}
+	]}`, rec.Body.String())
+}
+
+func TestHandler_GetLTP_XML(t *testing.T) {
+	tests := []struct {
+		name                string
+		accept              string
+		expectedContentType string
+	}{
+		{"application xml", "application/xml", "application/xml; charset=UTF-8"},
+		{"text xml", "text/xml", "text/xml; charset=UTF-8"},
+		{"preferred over json", "application/json;q=0.5, application/xml", "application/xml; charset=UTF-8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ltpService := new(mocks.LTPService)
+			handler := NewHandler(ltpService)
+
+			btcUSD, _ := domain.NewPair(domain.BTCUSD)
+			btcEUR, _ := domain.NewPair(domain.BTCEUR)
+			ltpService.On("GetLTPs", mock.Anything, "BTC/USD,BTC/EUR", ports.LTPOptions{}).Return([]domain.LTP{
+				{Pair: btcEUR, Amount: 50000.12},
+				{Pair: btcUSD, Amount: 52000.12, RawAmount: "52000.12000"},
+			}, nil)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD,BTC/EUR", nil)
+			req.Header.Set(echo.HeaderAccept, tt.accept)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// Act
+			err := handler.GetLTP(c)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tt.expectedContentType, rec.Header().Get(echo.HeaderContentType))
+			assert.Contains(t, rec.Header().Values(echo.HeaderVary), echo.HeaderAccept)
+			assert.True(t, strings.HasPrefix(rec.Body.String(), xml.Header), "expected an XML declaration")
+
+			var response dto.LTPResponse
+			require.NoError(t, xml.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, "ltpResponse", response.XMLName.Local)
+			assert.Equal(t, []dto.LTPItem{
+				{Pair: "BTC/EUR", Amount: "50000.12"},
+				{Pair: "BTC/USD", Amount: "52000.12000"},
+			}, response.LTP)
+			assert.Contains(t, rec.Body.String(), "<ltp><pair>BTC/EUR</pair><amount>50000.12</amount></ltp>")
+
+			ltpService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_GetLTP_DefaultsToJSON(t *testing.T) {
+	for _, accept := range []string{"", "*/*", "text/html"} {
+		t.Run(accept, func(t *testing.T) {
+			// Arrange
+			ltpService := new(mocks.LTPService)
+			handler := NewHandler(ltpService)
+
+			btcUSD, _ := domain.NewPair(domain.BTCUSD)
+			ltpService.On("GetLTPs", mock.Anything, "BTC/USD", ports.LTPOptions{}).Return([]domain.LTP{{Pair: btcUSD, Amount: 52000.12}}, nil)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD", nil)
+			if accept != "" {
+				req.Header.Set(echo.HeaderAccept, accept)
+			}
+			rec := httptest.NewRecorder()
+
+			// Act
+			err := handler.GetLTP(e.NewContext(req, rec))
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, echo.MIMEApplicationJSON, rec.Header().Get(echo.HeaderContentType))
+			assert.JSONEq(t, `{"ltp":[{"pair":"BTC/USD","amount":52000.12}]}`, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_GetLTP_PrettyParam(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		accept   string
+		expected string
+	}{
+		{"compact by default", "", "", `{"ltp":[{"pair":"BTC/USD","amount":52000.12}]}`},
+		{"compact when pretty=false", "&pretty=false", "", `{"ltp":[{"pair":"BTC/USD","amount":52000.12}]}`},
+		{
+			"indented when pretty=true", "&pretty=true", "",
+			"{\n  \"ltp\": [\n    {\n      \"pair\": \"BTC/USD\",\n      \"amount\": 52000.12\n    }\n  ]\n}",
+		},
+		{
+			"indented XML", "&pretty=true", echo.MIMEApplicationXML,
+			xml.Header + "<ltpResponse>\n  <ltp>\n    <pair>BTC/USD</pair>\n    <amount>52000.12</amount>\n  </ltp>\n</ltpResponse>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ltpService := new(mocks.LTPService)
+			handler := NewHandler(ltpService)
+
+			btcUSD, _ := domain.NewPair(domain.BTCUSD)
+			ltpService.On("GetLTPs", mock.Anything, "BTC/USD", ports.LTPOptions{}).Return([]domain.LTP{{Pair: btcUSD, Amount: 52000.12}}, nil)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD"+tt.query, nil)
+			if tt.accept != "" {
+				req.Header.Set(echo.HeaderAccept, tt.accept)
+			}
+			rec := httptest.NewRecorder()
+
+			// Act
+			err := handler.GetLTP(e.NewContext(req, rec))
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tt.expected, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_GetLTP_InvalidPrettyParam_ReturnsBadRequest(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD&pretty=very", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	err := handler.GetLTP(e.NewContext(req, rec))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	ltpService.AssertNotCalled(t, "GetLTPs", mock.Anything, mock.Anything)
+}
+
+func TestETagMatches(t *testing.T) {
+	etag := `W/"abc"`
+	tests := []struct {
+		ifNoneMatch string
+		expected    bool
+	}{
+		{`W/"abc"`, true},
+		{`"abc"`, true},
+		{`"other", W/"abc"`, true},
+		{`*`, true},
+		{`W/"other"`, false},
+		{`abc`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ifNoneMatch, func(t *testing.T) {
+			assert.Equal(t, tt.expected, etagMatches(tt.ifNoneMatch, etag))
+		})
+	}
+}
+
 func TestHandler_GetLTP_Success_MultiplePairs(t *testing.T) {
 	// Arrange
 	ltpService := new(mocks.LTPService)
@@ -114,7 +463,7 @@ func TestHandler_GetLTP_Success_MultiplePairs(t *testing.T) {
 		{Pair: btcUSD, Amount: 52000.12},
 	}
 
-	ltpService.On("GetLTPs", "BTC/USD,BTC/EUR").Return(expectedLTPs, nil)
+	ltpService.On("GetLTPs", mock.Anything, "BTC/USD,BTC/EUR", ports.LTPOptions{}).Return(expectedLTPs, nil)
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD,BTC/EUR", nil)
@@ -136,13 +485,39 @@ func TestHandler_GetLTP_Success_MultiplePairs(t *testing.T) {
 	ltpService.AssertExpectations(t)
 }
 
-func TestHandler_GetLTP_ServiceError_ReturnsBadRequest(t *testing.T) {
+func TestHandler_GetLTP_EncodedPairList(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	ltps := []domain.LTP{{Pair: btcEUR, Amount: 50000.12}, {Pair: btcUSD, Amount: 52000.12}}
+	ltpService.On("GetLTPs", mock.Anything, "BTC%2FUSD%2CBTC%2FEUR", ports.LTPOptions{}).Return(ltps, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC%252FUSD%252CBTC%252FEUR", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	err := handler.GetLTP(e.NewContext(req, rec))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var response dto.LTPResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Len(t, response.LTP, 2)
+	ltpService.AssertExpectations(t)
+}
+
+func TestHandler_GetLTP_InvalidPair_ReturnsBadRequest(t *testing.T) {
 	// Arrange
 	ltpService := new(mocks.LTPService)
 	handler := NewHandler(ltpService)
 
-	expectedError := errors.New("invalid pair: BTC/INVALID")
-	ltpService.On("GetLTPs", "BTC/INVALID").Return(nil, expectedError)
+	expectedError := fmt.Errorf("invalid pairs: %w", fmt.Errorf("%w: BTC/INVALID", domain.ErrInvalidPair))
+	ltpService.On("GetLTPs", mock.Anything, "BTC/INVALID", ports.LTPOptions{}).Return(nil, expectedError)
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/INVALID", nil)
@@ -160,6 +535,132 @@ func TestHandler_GetLTP_ServiceError_ReturnsBadRequest(t *testing.T) {
 	err = json.Unmarshal(rec.Body.Bytes(), &response)
 	assert.NoError(t, err)
 	assert.Contains(t, response.Error, "invalid pair")
+	assert.Equal(t, dto.CodeInvalidPair, response.Code)
+
+	ltpService.AssertExpectations(t)
+}
+
+func TestHandler_GetLTP_UpstreamError_ReturnsBadGateway(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	expectedError := fmt.Errorf("%w: failed to fetch from external service: %w", domain.ErrUpstreamUnavailable, errors.New("kraken API returned status 500"))
+	ltpService.On("GetLTPs", mock.Anything, "BTC/USD", ports.LTPOptions{}).Return(nil, expectedError)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.GetLTP(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadGateway, rec.Code)
+
+	var response dto.ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Contains(t, response.Error, "failed to fetch from external service")
+	assert.Equal(t, dto.CodeUpstreamUnavailable, response.Code)
+
+	ltpService.AssertExpectations(t)
+}
+
+func TestHandler_GetLTP_PriceNotFound_ReturnsBadGateway(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	expectedError := fmt.Errorf("%w: %s", domain.ErrPriceNotFound, domain.BTCUSD)
+	ltpService.On("GetLTPs", mock.Anything, "BTC/USD", ports.LTPOptions{}).Return(nil, expectedError)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.GetLTP(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadGateway, rec.Code)
+
+	var response dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, dto.CodePriceNotFound, response.Code)
+
+	ltpService.AssertExpectations(t)
+}
+
+func TestHandler_GetLTP_RateLimited_ReturnsServiceUnavailable(t *testing.T) {
+	tests := []struct {
+		name               string
+		retryAfter         time.Duration
+		expectedRetryAfter string
+	}{
+		{"whole seconds", 30 * time.Second, "30"},
+		{"rounds up", 1500 * time.Millisecond, "2"},
+		{"no suggestion", 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ltpService := new(mocks.LTPService)
+			handler := NewHandler(ltpService)
+
+			expectedError := fmt.Errorf("%w: failed to fetch from external service: %w", domain.ErrUpstreamUnavailable, &domain.RateLimitError{RetryAfter: tt.retryAfter})
+			ltpService.On("GetLTPs", mock.Anything, "BTC/USD", ports.LTPOptions{}).Return(nil, expectedError)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// Act
+			err := handler.GetLTP(c)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+			assert.Equal(t, tt.expectedRetryAfter, rec.Header().Get("Retry-After"))
+
+			var response dto.ErrorResponse
+			err = json.Unmarshal(rec.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, dto.CodeUpstreamRateLimited, response.Code)
+
+			ltpService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_GetLTP_UnexpectedError_ReturnsInternalServerError(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	ltpService.On("GetLTPs", mock.Anything, "BTC/USD", ports.LTPOptions{}).Return(nil, errors.New("boom"))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.GetLTP(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	var response dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, dto.CodeInternalError, response.Code)
 
 	ltpService.AssertExpectations(t)
 }
@@ -179,7 +680,7 @@ func TestHandler_GetLTP_EmptyPairsQueryParam(t *testing.T) {
 		{Pair: btcUSD, Amount: 52000.12},
 	}
 
-	ltpService.On("GetLTPs", "").Return(expectedLTPs, nil)
+	ltpService.On("GetLTPs", mock.Anything, "", ports.LTPOptions{}).Return(expectedLTPs, nil)
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=", nil)
@@ -225,3 +726,1257 @@ func TestHandler_Health_Success(t *testing.T) {
 
 	ltpService.AssertNotCalled(t, "GetLTPs")
 }
+
+func TestHandler_GetCacheStats_Success(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	oldest := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	newest := oldest.Add(30 * time.Second)
+	ltpService.On("GetCacheStats").Return(domain.CacheStats{
+		Entries: 3,
+		Expired: 1,
+		Oldest:  oldest,
+		Newest:  newest,
+	})
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/cache/stats", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.GetCacheStats(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.CacheStatsResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, response.Entries)
+	assert.Equal(t, 1, response.Expired)
+	if assert.NotNil(t, response.Oldest) && assert.NotNil(t, response.Newest) {
+		assert.True(t, oldest.Equal(*response.Oldest))
+		assert.True(t, newest.Equal(*response.Newest))
+	}
+
+	ltpService.AssertExpectations(t)
+}
+
+func TestHandler_GetCacheStats_EmptyCache(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	ltpService.On("GetCacheStats").Return(domain.CacheStats{})
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/cache/stats", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.GetCacheStats(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "oldest")
+	assert.NotContains(t, rec.Body.String(), "newest")
+
+	ltpService.AssertExpectations(t)
+}
+
+func TestHandler_GetLTP_UnsupportedPair_ReturnsUnprocessableEntity(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	expectedError := fmt.Errorf("%w: %s", domain.ErrUnsupportedPair, domain.BTCEUR)
+	ltpService.On("GetLTPs", mock.Anything, "BTC/EUR", ports.LTPOptions{}).Return(nil, expectedError)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/EUR", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.GetLTP(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+	var response dto.ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Contains(t, response.Error, "not supported by any provider")
+
+	ltpService.AssertExpectations(t)
+}
+
+func TestHandler_GetLTP_RefreshParam(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	expectedLTPs := []domain.LTP{
+		{Pair: btcUSD, Amount: 52000.12},
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		expected ports.LTPOptions
+	}{
+		{"refresh=true forces refresh", "?pairs=BTC/USD&refresh=true", ports.LTPOptions{ForceRefresh: true}},
+		{"refresh=false uses cache", "?pairs=BTC/USD&refresh=false", ports.LTPOptions{}},
+		{"no refresh uses cache", "?pairs=BTC/USD", ports.LTPOptions{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ltpService := new(mocks.LTPService)
+			handler := NewHandler(ltpService)
+			if tt.expected == (ports.LTPOptions{}) {
+				// A single pair with the default options goes through the single-pair lookup
+				ltpService.On("GetLTPs", mock.Anything, "BTC/USD", ports.LTPOptions{}).Return([]domain.LTP{expectedLTPs[0]}, nil)
+			} else {
+				ltpService.On("GetLTPs", mock.Anything, "BTC/USD", tt.expected).Return(expectedLTPs, nil)
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// Act
+			err := handler.GetLTP(c)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			ltpService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_GetLTP_ProviderParam(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	expectedLTPs := []domain.LTP{
+		{Pair: btcUSD, Amount: 52000.12},
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		expected ports.LTPOptions
+	}{
+		{"provider selects a provider", "?pairs=BTC/USD&provider=binance", ports.LTPOptions{Provider: "binance"}},
+		{"provider is normalized", "?pairs=BTC/USD&provider=%20Kraken%20", ports.LTPOptions{Provider: "kraken"}},
+		{"no provider uses the primary one", "?pairs=BTC/USD", ports.LTPOptions{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ltpService := new(mocks.LTPService)
+			handler := NewHandler(ltpService)
+			if tt.expected == (ports.LTPOptions{}) {
+				// A single pair with the default options goes through the single-pair lookup
+				ltpService.On("GetLTPs", mock.Anything, "BTC/USD", ports.LTPOptions{}).Return([]domain.LTP{expectedLTPs[0]}, nil)
+			} else {
+				ltpService.On("GetLTPs", mock.Anything, "BTC/USD", tt.expected).Return(expectedLTPs, nil)
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// Act
+			err := handler.GetLTP(c)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			ltpService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_GetLTP_UnknownProvider_ReturnsBadRequest(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	expectedError := fmt.Errorf("%w: coinbase. Valid providers are: binance, kraken", domain.ErrUnknownProvider)
+	ltpService.On("GetLTPs", mock.Anything, "BTC/USD", ports.LTPOptions{Provider: "coinbase"}).Return(nil, expectedError)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD&provider=coinbase", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.GetLTP(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, dto.CodeUnknownProvider, response.Code)
+	assert.Contains(t, response.Error, "coinbase")
+
+	ltpService.AssertExpectations(t)
+}
+
+func TestHandler_GetLTP_InvalidRefreshParam_ReturnsBadRequest(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?refresh=maybe", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.GetLTP(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response dto.ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Contains(t, response.Error, "invalid refresh value")
+
+	ltpService.AssertNotCalled(t, "GetLTPs", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHandler_GetLTP_Debug_EchoesNormalizedQuery(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	expectedLTPs := []domain.LTP{
+		{Pair: btcEUR, Amount: 50000.12},
+		{Pair: btcUSD, Amount: 52000.12},
+	}
+
+	pairsStr := "btc/usd, BTC/EUR,BTC/USD"
+	ltpService.On("GetLTPs", mock.Anything, pairsStr, ports.LTPOptions{ForceRefresh: true}).Return(expectedLTPs, nil)
+	ltpService.On("ProviderName").Return("kraken")
+	ltpService.On("ValidatePairs", pairsStr).Return(domain.PairValidation{Valid: []domain.Pair{btcUSD, btcEUR}}, nil)
+
+	e := echo.New()
+	q := make(url.Values)
+	q.Set("pairs", pairsStr)
+	q.Set("refresh", "true")
+	q.Set("debug", "true")
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?"+q.Encode(), nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.GetLTP(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.LTPResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "pairs=BTC/EUR,BTC/USD&refresh=true&order=sorted&provider=kraken&fields=&precision=&sla=false&include=", response.Query)
+
+	// The normalized pairs match exactly what was returned
+	returned := make([]string, len(response.LTP))
+	for i, item := range response.LTP {
+		returned[i] = item.Pair
+	}
+	assert.True(t, strings.HasPrefix(response.Query, "pairs="+strings.Join(returned, ",")+"&"))
+
+	ltpService.AssertExpectations(t)
+}
+
+func TestHandler_GetLTP_Debug_EchoesResolvedOptions(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	ltps := []domain.LTP{{Pair: btcUSD, Amount: 52000.12}, {Pair: btcEUR, Amount: 50000.12}}
+
+	tests := []struct {
+		name     string
+		query    string
+		opts     ports.LTPOptions
+		expected string
+	}{
+		{
+			name:     "defaults",
+			query:    "pairs=BTC/USD,BTC/EUR&debug=true",
+			expected: "pairs=BTC/EUR,BTC/USD&refresh=false&order=sorted&provider=kraken&fields=&precision=&sla=false&include=",
+		},
+		{
+			name:     "every option",
+			query:    "pairs=BTC/USD,BTC/EUR&debug=true&order=requested&provider=Binance&fields=ask,bid&precision=2&sla=true&include=change",
+			opts:     ports.LTPOptions{Order: ports.OrderRequested, Provider: "binance", CheckSLA: true, IncludeChange: true},
+			expected: "pairs=BTC/EUR,BTC/USD&refresh=false&order=requested&provider=binance&fields=bid,ask&precision=2&sla=true&include=change",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ltpService := new(mocks.LTPService)
+			handler := NewHandler(ltpService)
+			ltpService.On("GetLTPs", mock.Anything, "BTC/USD,BTC/EUR", tt.opts).Return(ltps, nil)
+			ltpService.On("ProviderName").Return("kraken")
+			ltpService.On("ValidatePairs", "BTC/USD,BTC/EUR").Return(domain.PairValidation{Valid: []domain.Pair{btcUSD, btcEUR}}, nil)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			// Act
+			err := handler.GetLTP(e.NewContext(req, rec))
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			var response dto.LTPResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, tt.expected, response.Query)
+		})
+	}
+}
+
+func TestHandler_GetLTP_Debug_EchoesPairsWithoutPrice(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+
+	t.Run("listed pairs", func(t *testing.T) {
+		// Arrange
+		ltpService := new(mocks.LTPService)
+		handler := NewHandler(ltpService)
+		// The upstream has no price for BTC/EUR, which the response then omits
+		ltpService.On("GetLTPs", mock.Anything, "btc-eur,BTC/USD", ports.LTPOptions{}).Return([]domain.LTP{{Pair: btcUSD, Amount: 52000.12}}, nil)
+		ltpService.On("ValidatePairs", "btc-eur,BTC/USD").Return(domain.PairValidation{Valid: []domain.Pair{btcEUR, btcUSD}}, nil)
+		ltpService.On("ProviderName").Return("kraken")
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=btc-eur,BTC/USD&debug=true", nil)
+		rec := httptest.NewRecorder()
+
+		// Act
+		err := handler.GetLTP(e.NewContext(req, rec))
+
+		// Assert
+		require.NoError(t, err)
+		var response dto.LTPResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		require.Len(t, response.LTP, 1)
+		assert.Equal(t, "pairs=BTC/EUR,BTC/USD&refresh=false&order=sorted&provider=kraken&fields=&precision=&sla=false&include=", response.Query)
+	})
+
+	t.Run("quote", func(t *testing.T) {
+		// Arrange
+		ltpService := new(mocks.LTPService)
+		handler := NewHandler(ltpService)
+		ltpService.On("GetLTPs", mock.Anything, "", ports.LTPOptions{Quote: "EUR"}).Return([]domain.LTP{}, nil)
+		ltpService.On("ProviderName").Return("kraken")
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?quote=eur&debug=true", nil)
+		rec := httptest.NewRecorder()
+
+		// Act
+		err := handler.GetLTP(e.NewContext(req, rec))
+
+		// Assert
+		require.NoError(t, err)
+		var response dto.LTPResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.True(t, strings.HasPrefix(response.Query, "pairs=BTC/EUR&"), response.Query)
+		ltpService.AssertNotCalled(t, "ValidatePairs", mock.Anything)
+	})
+}
+
+func TestHandler_GetLTP_Debug_IncludesSymbols(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	ltps := []domain.LTP{
+		{Pair: btcEUR, Amount: 50000.12, Symbol: "XXBTZEUR"},
+		{Pair: btcUSD, Amount: 52000.12},
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		expected []string
+	}{
+		{"with debug", "pairs=BTC/EUR,BTC/USD&debug=true", []string{"XXBTZEUR", ""}},
+		{"without debug", "pairs=BTC/EUR,BTC/USD", []string{"", ""}},
+		{"debug disabled", "pairs=BTC/EUR,BTC/USD&debug=false", []string{"", ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ltpService := new(mocks.LTPService)
+			handler := NewHandler(ltpService)
+			ltpService.On("GetLTPs", mock.Anything, "BTC/EUR,BTC/USD", ports.LTPOptions{}).Return(ltps, nil)
+			ltpService.On("ProviderName").Return("kraken").Maybe()
+			ltpService.On("ValidatePairs", "BTC/EUR,BTC/USD").Return(domain.PairValidation{Valid: []domain.Pair{btcEUR, btcUSD}}, nil).Maybe()
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			// Act
+			err := handler.GetLTP(e.NewContext(req, rec))
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			var response dto.LTPResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			symbols := make([]string, len(response.LTP))
+			for i, item := range response.LTP {
+				symbols[i] = item.Symbol
+			}
+			assert.Equal(t, tt.expected, symbols)
+			if tt.expected[0] == "" {
+				assert.NotContains(t, rec.Body.String(), "symbol")
+			}
+		})
+	}
+}
+
+func TestHandler_GetLTP_NoDebug_OmitsQuery(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	ltpService.On("GetLTPs", mock.Anything, "BTC/USD", ports.LTPOptions{}).Return([]domain.LTP{{Pair: btcUSD, Amount: 52000.12}}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.GetLTP(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "query")
+
+	ltpService.AssertExpectations(t)
+}
+
+func TestHandler_GetLTP_OrderParam(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+
+	tests := []struct {
+		name     string
+		query    string
+		expected ports.LTPOptions
+		returned []domain.LTP
+		pairs    []string
+	}{
+		{
+			name:     "requested keeps caller order",
+			query:    "?pairs=BTC/USD,BTC/EUR&order=requested",
+			expected: ports.LTPOptions{Order: ports.OrderRequested},
+			returned: []domain.LTP{{Pair: btcUSD, Amount: 52000.12}, {Pair: btcEUR, Amount: 50000.12}},
+			pairs:    []string{"BTC/USD", "BTC/EUR"},
+		},
+		{
+			name:     "sorted explicitly",
+			query:    "?pairs=BTC/USD,BTC/EUR&order=sorted",
+			expected: ports.LTPOptions{Order: ports.OrderSorted},
+			returned: []domain.LTP{{Pair: btcEUR, Amount: 50000.12}, {Pair: btcUSD, Amount: 52000.12}},
+			pairs:    []string{"BTC/EUR", "BTC/USD"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ltpService := new(mocks.LTPService)
+			handler := NewHandler(ltpService)
+			ltpService.On("GetLTPs", mock.Anything, "BTC/USD,BTC/EUR", tt.expected).Return(tt.returned, nil)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// Act
+			err := handler.GetLTP(c)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			var response dto.LTPResponse
+			err = json.Unmarshal(rec.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			require.Len(t, response.LTP, len(tt.pairs))
+			for i, pair := range tt.pairs {
+				assert.Equal(t, pair, response.LTP[i].Pair)
+			}
+			ltpService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_GetLTP_InvalidOrderParam_ReturnsBadRequest(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?order=random", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.GetLTP(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "invalid order value")
+
+	ltpService.AssertNotCalled(t, "GetLTPs", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHandler_GetLTP_AcceptStaleHeader_UpstreamDown(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	expired := &domain.CachedLTP{
+		LTP:       domain.LTP{Pair: btcUSD, Amount: 51000.00},
+		Timestamp: time.Now().Add(-time.Hour),
+	}
+
+	tests := []struct {
+		name           string
+		header         string
+		expectedStatus int
+	}{
+		{"true serves stale data", "true", http.StatusOK},
+		{"false returns upstream error", "false", http.StatusBadGateway},
+		{"absent uses server default", "", http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			repo := new(mocks.Repository)
+			external := new(mocks.External)
+			handler := NewHandler(service.NewLTPService(repo, external))
+
+			repo.On("GetLTP", btcUSD).Return((*domain.CachedLTP)(nil), false)
+			repo.On("GetOrFetch", btcUSD, mock.Anything).Return(func(_ domain.Pair, fetch func() (domain.LTP, error)) (*domain.CachedLTP, error) {
+				_, err := fetch()
+				return nil, err
+			})
+			repo.On("GetStaleLTP", btcUSD).Return(expired, true)
+			external.On("GetTickers", mock.Anything, []domain.Pair{btcUSD}).Return(nil, errors.New("kraken API returned status 503"))
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD", nil)
+			if tt.header != "" {
+				req.Header.Set(HeaderAcceptStale, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// Act
+			err := handler.GetLTP(c)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var response dto.LTPResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				require.Len(t, response.LTP, 1)
+				assert.Equal(t, json.Number("51000"), response.LTP[0].Amount)
+				assert.True(t, response.LTP[0].Stale)
+			} else {
+				var response dto.ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				assert.Contains(t, response.Error, "failed to fetch from external service")
+			}
+		})
+	}
+}
+
+func TestHandler_GetLTP_MissingPrice_LeftOut(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+
+	tests := []struct {
+		name     string
+		pairs    string
+		expected []string
+	}{
+		{"one pair", "BTC/USD", []string{}},
+		{"several pairs", "BTC/USD,BTC/EUR", []string{domain.BTCEUR}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			repo := new(mocks.Repository)
+			external := new(mocks.External)
+			handler := NewHandler(service.NewLTPService(repo, external))
+
+			// The upstream has a price for BTC/EUR only
+			repo.On("GetLTP", mock.Anything).Return((*domain.CachedLTP)(nil), false)
+			repo.On("GetOrFetch", btcUSD, mock.Anything).Return(func(_ domain.Pair, fetch func() (domain.LTP, error)) (*domain.CachedLTP, error) {
+				ltp, err := fetch()
+				if err != nil {
+					return nil, err
+				}
+				return domain.NewCachedLTP(ltp), nil
+			})
+			repo.On("SetLTPs", mock.Anything).Return()
+			external.On("GetTickers", mock.Anything, mock.Anything).Return([]domain.LTP{{Pair: btcEUR, Amount: 50000.12}}, nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs="+tt.pairs, nil)
+			rec := httptest.NewRecorder()
+
+			// Act
+			err := handler.GetLTP(echo.New().NewContext(req, rec))
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			var response dto.LTPResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			pairs := []string{}
+			for _, item := range response.LTP {
+				pairs = append(pairs, item.Pair)
+			}
+			assert.Equal(t, tt.expected, pairs)
+		})
+	}
+}
+
+func TestHandler_GetLTP_InvalidAcceptStaleHeader_ReturnsBadRequest(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp", nil)
+	req.Header.Set(HeaderAcceptStale, "sometimes")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.GetLTP(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), HeaderAcceptStale)
+
+	ltpService.AssertNotCalled(t, "GetLTPs", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHandler_GetLTP_FieldsParam(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	ltps := []domain.LTP{{Pair: btcUSD, Amount: 52000.12, Bid: 51999.5, Ask: 52000.5}}
+
+	tests := []struct {
+		name        string
+		query       string
+		expectedBid *float64
+		expectedAsk *float64
+	}{
+		{"default is last trade only", "", nil, nil},
+		{"bid only", "&fields=bid", &ltps[0].Bid, nil},
+		{"bid and ask", "&fields=bid,ask", &ltps[0].Bid, &ltps[0].Ask},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ltpService := new(mocks.LTPService)
+			handler := NewHandler(ltpService)
+
+			ltpService.On("GetLTPs", mock.Anything, "BTC/USD", ports.LTPOptions{}).Return([]domain.LTP{ltps[0]}, nil)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// Act
+			err := handler.GetLTP(c)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			var response dto.LTPResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Len(t, response.LTP, 1)
+			assert.Equal(t, json.Number("52000.12"), response.LTP[0].Amount)
+			assert.Equal(t, tt.expectedBid, response.LTP[0].Bid)
+			assert.Equal(t, tt.expectedAsk, response.LTP[0].Ask)
+		})
+	}
+}
+
+func TestHandler_GetLTP_InvalidFieldsParam_ReturnsBadRequest(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?fields=bid,volume", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.GetLTP(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "invalid fields value: volume")
+
+	ltpService.AssertNotCalled(t, "GetLTPs", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHandler_GetHistory_Success(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	older := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Minute)
+	ltpService.On("GetHistory", "BTC/USD", time.Time{}, 2).Return(domain.HistoryPage{Samples: []domain.CachedLTP{
+		{LTP: domain.LTP{Pair: btcUSD, Amount: 51000}, Timestamp: older},
+		{LTP: domain.LTP{Pair: btcUSD, Amount: 52000}, Timestamp: newer},
+	}}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp/history?pair=BTC/USD&limit=2", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.GetHistory(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.HistoryResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "BTC/USD", response.Pair)
+	require.Len(t, response.Samples, 2)
+	assert.Equal(t, json.Number("51000"), response.Samples[0].Amount)
+	assert.True(t, older.Equal(response.Samples[0].Timestamp))
+	assert.Equal(t, json.Number("52000"), response.Samples[1].Amount)
+	assert.True(t, newer.Equal(response.Samples[1].Timestamp))
+	assert.Empty(t, response.Next)
+
+	ltpService.AssertExpectations(t)
+}
+
+func TestHandler_GetHistory_Pagination(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	sample := func(minutes int) domain.CachedLTP {
+		return domain.CachedLTP{LTP: domain.LTP{Pair: btcUSD, Amount: float64(minutes)}, Timestamp: base.Add(time.Duration(minutes) * time.Minute)}
+	}
+
+	tests := []struct {
+		name         string
+		query        string
+		before       time.Time
+		page         domain.HistoryPage
+		expectedNext string
+	}{
+		{
+			name:         "first page",
+			query:        "pair=BTC/USD&limit=2",
+			page:         domain.HistoryPage{Samples: []domain.CachedLTP{sample(4), sample(5)}, More: true},
+			expectedNext: "2024-01-01T12:04:00Z",
+		},
+		{
+			name:         "middle page",
+			query:        "pair=BTC/USD&limit=2&before=2024-01-01T12:04:00Z",
+			before:       base.Add(4 * time.Minute),
+			page:         domain.HistoryPage{Samples: []domain.CachedLTP{sample(2), sample(3)}, More: true},
+			expectedNext: "2024-01-01T12:02:00Z",
+		},
+		{
+			name:   "end of data",
+			query:  "pair=BTC/USD&limit=2&before=2024-01-01T12:02:00Z",
+			before: base.Add(2 * time.Minute),
+			page:   domain.HistoryPage{Samples: []domain.CachedLTP{sample(1)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ltpService := new(mocks.LTPService)
+			handler := NewHandler(ltpService)
+			ltpService.On("GetHistory", "BTC/USD", mock.MatchedBy(func(before time.Time) bool {
+				return before.Equal(tt.before)
+			}), 2).Return(tt.page, nil)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp/history?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// Act
+			err := handler.GetHistory(c)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			var response dto.HistoryResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Len(t, response.Samples, len(tt.page.Samples))
+			assert.Equal(t, tt.expectedNext, response.Next)
+			ltpService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_GetHistory_DefaultLimitAndEmptyHistory(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	ltpService.On("GetHistory", "BTC/EUR", time.Time{}, DefaultHistoryLimit).Return(domain.HistoryPage{Samples: []domain.CachedLTP{}}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp/history?pair=BTC/EUR", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.GetHistory(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"pair":"BTC/EUR","samples":[]}`, rec.Body.String())
+
+	ltpService.AssertExpectations(t)
+}
+
+func TestHandler_GetHistory_BadRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		serviceErr error
+	}{
+		{"invalid limit", "pair=BTC/USD&limit=many", nil},
+		{"non-positive limit", "pair=BTC/USD&limit=0", nil},
+		{"invalid before", "pair=BTC/USD&before=yesterday", nil},
+		{"invalid pair", "pair=BTC/INVALID", fmt.Errorf("%w: BTC/INVALID", domain.ErrInvalidPair)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ltpService := new(mocks.LTPService)
+			handler := NewHandler(ltpService)
+			if tt.serviceErr != nil {
+				ltpService.On("GetHistory", mock.Anything, mock.Anything, mock.Anything).Return(nil, tt.serviceErr)
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp/history?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// Act
+			err := handler.GetHistory(c)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+}
+
+func TestHandler_GetLTP_SLAParam(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	within, violated := true, false
+	ltpService.On("GetLTPs", mock.Anything, "BTC/USD,BTC/EUR", ports.LTPOptions{CheckSLA: true}).Return([]domain.LTP{
+		{Pair: btcEUR, Amount: 50000.12, WithinSLA: &violated},
+		{Pair: btcUSD, Amount: 52000.12, WithinSLA: &within},
+	}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD,BTC/EUR&sla=true", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.GetLTP(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"ltp":[
+		{"pair":"BTC/EUR","amount":50000.12,"sla_ok":false},
+		{"pair":"BTC/USD","amount":52000.12,"sla_ok":true}
+	]}`, rec.Body.String())
+
+	ltpService.AssertExpectations(t)
+}
+
+func TestHandler_GetLTP_IncludeChange(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	ltpService.On("GetLTPs", mock.Anything, "BTC/USD,BTC/EUR", ports.LTPOptions{IncludeChange: true}).Return([]domain.LTP{
+		{Pair: btcEUR, Amount: 50000.12},
+		{Pair: btcUSD, Amount: 52000, Change24h: &domain.PriceChange{Amount: 2000, Percent: 4}},
+	}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD,BTC/EUR&include=change", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.GetLTP(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	// BTC/EUR has no history to compare against, so its change is omitted
+	assert.JSONEq(t, `{"ltp":[
+		{"pair":"BTC/EUR","amount":50000.12},
+		{"pair":"BTC/USD","amount":52000,"change_24h":2000,"change_pct_24h":4}
+	]}`, rec.Body.String())
+
+	ltpService.AssertExpectations(t)
+}
+
+func TestHandler_GetLTP_InvalidIncludeParam_ReturnsBadRequest(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?include=volume", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.GetLTP(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), dto.CodeInvalidParameter)
+	ltpService.AssertNotCalled(t, "GetLTPs", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// stubSymbols resolves pairs from a fixed map
+type stubSymbols map[string]string
+
+func (s stubSymbols) Symbol(pair domain.Pair) (string, bool) {
+	symbol, ok := s[pair.Value()]
+	return symbol, ok
+}
+
+func TestHandler_GetLTP_PrecisionParam(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		ltp            domain.LTP
+		expectedAmount string
+	}{
+		{"omitted keeps the reported amount", "", domain.LTP{Amount: 52000.1234, RawAmount: "52000.12340"}, "52000.12340"},
+		{"rounds down", "&precision=2", domain.LTP{Amount: 52000.1234, RawAmount: "52000.12340"}, "52000.12"},
+		{"rounds half away from zero", "&precision=2", domain.LTP{Amount: 1.005, RawAmount: "1.005"}, "1.01"},
+		{"zero decimals", "&precision=0", domain.LTP{Amount: 52000.5}, "52001"},
+		{"pads to the precision", "&precision=4", domain.LTP{Amount: 52000.1}, "52000.1000"},
+		{"maximum", "&precision=8", domain.LTP{Amount: 0.123456789}, "0.12345679"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ltpService := new(mocks.LTPService)
+			handler := NewHandler(ltpService)
+
+			btcUSD, _ := domain.NewPair(domain.BTCUSD)
+			ltp := tt.ltp
+			ltp.Pair = btcUSD
+			ltpService.On("GetLTPs", mock.Anything, "BTC/USD", ports.LTPOptions{}).Return([]domain.LTP{ltp}, nil)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			// Act
+			err := handler.GetLTP(e.NewContext(req, rec))
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Contains(t, rec.Body.String(), `"amount":`+tt.expectedAmount+`}`)
+			ltpService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_GetLTP_InvalidPrecisionParam_ReturnsBadRequest(t *testing.T) {
+	for _, precision := range []string{"-1", "9", "two", "1.5"} {
+		t.Run(precision, func(t *testing.T) {
+			// Arrange
+			ltpService := new(mocks.LTPService)
+			handler := NewHandler(ltpService)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?precision="+precision, nil)
+			rec := httptest.NewRecorder()
+
+			// Act
+			err := handler.GetLTP(e.NewContext(req, rec))
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Contains(t, rec.Body.String(), dto.CodeInvalidParameter)
+			assert.Contains(t, rec.Body.String(), "between 0 and 8")
+			ltpService.AssertNotCalled(t, "GetLTPs", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestHandler_GetLTP_QuoteParam(t *testing.T) {
+	t.Run("requests the pairs quoted in the currency", func(t *testing.T) {
+		// Arrange
+		ltpService := new(mocks.LTPService)
+		handler := NewHandler(ltpService)
+
+		btcEUR, _ := domain.NewPair(domain.BTCEUR)
+		ltpService.On("GetLTPs", mock.Anything, "", ports.LTPOptions{Quote: "EUR"}).Return([]domain.LTP{{Pair: btcEUR, Amount: 50000.12}}, nil)
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?quote=eur", nil)
+		rec := httptest.NewRecorder()
+
+		// Act
+		err := handler.GetLTP(e.NewContext(req, rec))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"ltp":[{"pair":"BTC/EUR","amount":50000.12}]}`, rec.Body.String())
+		ltpService.AssertExpectations(t)
+	})
+
+	for name, query := range map[string]string{
+		"unsupported quote":   "quote=JPY",
+		"combined with pairs": "quote=USD&pairs=BTC/EUR",
+	} {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			ltpService := new(mocks.LTPService)
+			handler := NewHandler(ltpService)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?"+query, nil)
+			rec := httptest.NewRecorder()
+
+			// Act
+			err := handler.GetLTP(e.NewContext(req, rec))
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Contains(t, rec.Body.String(), dto.CodeInvalidParameter)
+			ltpService.AssertNotCalled(t, "GetLTPs", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestHandler_GetPairs(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []HandlerOption
+		expected []dto.PairItem
+	}{
+		{
+			name: "without symbol resolver lists sorted pairs",
+			expected: []dto.PairItem{
+				{Pair: domain.BTCCHF},
+				{Pair: domain.BTCEUR},
+				{Pair: domain.BTCUSD},
+			},
+		},
+		{
+			name: "with symbol resolver includes known symbols",
+			opts: []HandlerOption{WithSymbolResolver(stubSymbols{domain.BTCUSD: "XBTUSD", domain.BTCEUR: "XBTEUR"})},
+			expected: []dto.PairItem{
+				{Pair: domain.BTCCHF},
+				{Pair: domain.BTCEUR, Symbol: "XBTEUR"},
+				{Pair: domain.BTCUSD, Symbol: "XBTUSD"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ltpService := new(mocks.LTPService)
+			handler := NewHandler(ltpService, tt.opts...)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/pairs", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// Act
+			err := handler.GetPairs(c)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			var response dto.PairsResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, tt.expected, response.Pairs)
+		})
+	}
+}
+
+func TestHandler_GetTWAP(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		window         time.Duration
+		serviceResult  float64
+		serviceErr     error
+		expectedStatus int
+	}{
+		{
+			name:           "success with window",
+			query:          "pair=BTC/USD&window=10m",
+			window:         10 * time.Minute,
+			serviceResult:  52000.5,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "default window",
+			query:          "pair=BTC/USD",
+			window:         DefaultTWAPWindow,
+			serviceResult:  52000.5,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "insufficient history",
+			query:          "pair=BTC/USD&window=1h",
+			window:         time.Hour,
+			serviceErr:     fmt.Errorf("%w: samples do not cover the last 1h0m0s", domain.ErrInsufficientHistory),
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "invalid window",
+			query:          "pair=BTC/USD&window=soon",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "negative window",
+			query:          "pair=BTC/USD&window=-5m",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ltpService := new(mocks.LTPService)
+			handler := NewHandler(ltpService)
+			if tt.window > 0 {
+				ltpService.On("GetTWAP", "BTC/USD", tt.window).Return(tt.serviceResult, tt.serviceErr)
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp/twap?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// Act
+			err := handler.GetTWAP(c)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response dto.TWAPResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				assert.Equal(t, "BTC/USD", response.Pair)
+				assert.Equal(t, tt.window.String(), response.Window)
+				assert.Equal(t, tt.serviceResult, response.TWAP)
+			}
+			ltpService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected string
+	}{
+		{fmt.Errorf("invalid pairs: %w", domain.ErrInvalidPair), dto.CodeInvalidPair},
+		{fmt.Errorf("invalid pairs: %w", domain.ErrTooManyPairs), dto.CodeTooManyPairs},
+		{domain.ErrInvalidCurrency, dto.CodeInvalidCurrency},
+		{domain.ErrUnsupportedPair, dto.CodeUnsupportedPair},
+		{domain.ErrInsufficientHistory, dto.CodeInsufficientHistory},
+		{fmt.Errorf("%w: boom", domain.ErrUpstreamUnavailable), dto.CodeUpstreamUnavailable},
+		{fmt.Errorf("%w: %w", domain.ErrUpstreamUnavailable, &domain.RateLimitError{}), dto.CodeUpstreamRateLimited},
+		{domain.ErrRateUnavailable, dto.CodeRateUnavailable},
+		{errors.New("boom"), dto.CodeInternalError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			assert.Equal(t, tt.expected, errorCode(tt.err))
+		})
+	}
+}
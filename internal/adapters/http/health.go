@@ -0,0 +1,75 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go-exercise/internal/adapters/http/dto"
+	"go-exercise/internal/domain"
+	"go-exercise/internal/ports"
+)
+
+// DefaultHealthCheckTimeout bounds each component check of the readiness endpoint
+const DefaultHealthCheckTimeout = 2 * time.Second
+
+// healthCheck is a component checked by the readiness endpoint
+type healthCheck struct {
+	checker ports.HealthChecker
+	// required components being down make the service not ready
+	required bool
+}
+
+// WithHealthCheck adds a component to the readiness endpoint. A required component that
+// is down fails readiness; otherwise it only degrades the reported status.
+func WithHealthCheck(checker ports.HealthChecker, required bool) HandlerOption {
+	return func(h *Handler) {
+		h.healthChecks = append(h.healthChecks, healthCheck{checker: checker, required: required})
+	}
+}
+
+// Ready handles GET /health/ready
+// @Summary Readiness check
+// @Description Check every registered dependency concurrently and report the state of each. Responds 503 when a required dependency is down.
+// @Tags health
+// @Produce json
+// @Success 200 {object} dto.ReadinessResponse "Service is ready, possibly degraded"
+// @Failure 503 {object} dto.ReadinessResponse "A required dependency is down"
+// @Router /health/ready [get]
+func (h *Handler) Ready(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), DefaultHealthCheckTimeout)
+	defer cancel()
+
+	statuses := make([]domain.HealthStatus, len(h.healthChecks))
+	var wg sync.WaitGroup
+	for i, check := range h.healthChecks {
+		wg.Add(1)
+		go func(i int, checker ports.HealthChecker) {
+			defer wg.Done()
+			statuses[i] = checker.CheckHealth(ctx)
+		}(i, check.checker)
+	}
+	wg.Wait()
+
+	overall := domain.HealthOK
+	response := dto.ReadinessResponse{Components: make(map[string]string, len(h.healthChecks))}
+	for i, check := range h.healthChecks {
+		status := statuses[i]
+		response.Components[check.checker.Name()] = string(status)
+		switch {
+		case status == domain.HealthOK:
+		case status == domain.HealthDown && check.required:
+			overall = domain.HealthDown
+		case overall != domain.HealthDown:
+			overall = domain.HealthDegraded
+		}
+	}
+	response.Status = string(overall)
+
+	if overall == domain.HealthDown {
+		return c.JSON(http.StatusServiceUnavailable, response)
+	}
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-exercise/internal/domain"
+	"go-exercise/internal/ports/mocks"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type componentState struct {
+	name     string
+	status   domain.HealthStatus
+	required bool
+}
+
+func TestHandler_Ready(t *testing.T) {
+	tests := []struct {
+		name           string
+		components     []componentState
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "no components",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"status":"ok","components":{}}`,
+		},
+		{
+			name: "all ok",
+			components: []componentState{
+				{"kraken", domain.HealthOK, true},
+				{"redis", domain.HealthOK, false},
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"status":"ok","components":{"kraken":"ok","redis":"ok"}}`,
+		},
+		{
+			name: "degraded component",
+			components: []componentState{
+				{"kraken", domain.HealthOK, true},
+				{"redis", domain.HealthDegraded, true},
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"status":"degraded","components":{"kraken":"ok","redis":"degraded"}}`,
+		},
+		{
+			name: "optional component down",
+			components: []componentState{
+				{"kraken", domain.HealthOK, true},
+				{"redis", domain.HealthDown, false},
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"status":"degraded","components":{"kraken":"ok","redis":"down"}}`,
+		},
+		{
+			name: "required component down",
+			components: []componentState{
+				{"kraken", domain.HealthDown, true},
+				{"redis", domain.HealthDegraded, false},
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   `{"status":"down","components":{"kraken":"down","redis":"degraded"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var opts []HandlerOption
+			var checkers []*mocks.HealthChecker
+			for _, component := range tt.components {
+				checker := new(mocks.HealthChecker)
+				checker.On("Name").Return(component.name)
+				checker.On("CheckHealth", mock.Anything).Return(component.status).Once()
+				checkers = append(checkers, checker)
+				opts = append(opts, WithHealthCheck(checker, component.required))
+			}
+			handler := NewHandler(new(mocks.LTPService), opts...)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/health/ready", nil)
+			rec := httptest.NewRecorder()
+
+			// Act
+			err := handler.Ready(e.NewContext(req, rec))
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			for _, checker := range checkers {
+				checker.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestRouter_Ready_Endpoint(t *testing.T) {
+	// Arrange
+	checker := new(mocks.HealthChecker)
+	checker.On("Name").Return("kraken")
+	checker.On("CheckHealth", mock.Anything).Return(domain.HealthOK)
+	router := SetupRouter(NewHandler(new(mocks.LTPService), WithHealthCheck(checker, true)))
+
+	req := httptest.NewRequest(http.MethodGet, "/health/ready", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rec, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"ok","components":{"kraken":"ok"}}`, rec.Body.String())
+}
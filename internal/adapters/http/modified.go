@@ -0,0 +1,43 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"go-exercise/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// lastModified returns the newest update time among the prices; zero when none is known
+func lastModified(ltps []domain.LTP) time.Time {
+	var newest time.Time
+	for _, ltp := range ltps {
+		if ltp.UpdatedAt.After(newest) {
+			newest = ltp.UpdatedAt
+		}
+	}
+	return newest
+}
+
+// setLastModified sets the Last-Modified header and reports whether the request's
+// If-Modified-Since shows the client's copy is current. HTTP dates have second precision,
+// so the time is truncated before comparing. As If-None-Match takes precedence, the date
+// is ignored when the request carries one.
+func setLastModified(c echo.Context, modified time.Time) (notModified bool) {
+	if modified.IsZero() {
+		return false
+	}
+	modified = modified.UTC().Truncate(time.Second)
+	c.Response().Header().Set("Last-Modified", modified.Format(http.TimeFormat))
+
+	req := c.Request()
+	if req.Header.Get("If-None-Match") != "" {
+		return false
+	}
+	since, err := http.ParseTime(req.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	return !modified.After(since)
+}
@@ -0,0 +1,30 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go-exercise/internal/domain"
+)
+
+// MIMEApplicationNDJSON is the media type of newline-delimited JSON responses
+const MIMEApplicationNDJSON = "application/x-ndjson"
+
+// streamNDJSON writes each price as its own line of JSON, flushing after every line so
+// clients can start processing before the whole set has been written. Once the first
+// line is out the status can no longer change, so a client going away just ends the stream.
+func streamNDJSON(c echo.Context, ltps []domain.LTP, fields ltpFields, precision int) error {
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, MIMEApplicationNDJSON)
+	res.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(res)
+	for _, ltp := range ltps {
+		if err := encoder.Encode(toLTPItem(ltp, fields, precision)); err != nil {
+			return nil
+		}
+		res.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-exercise/internal/adapters/http/dto"
+	"go-exercise/internal/domain"
+	"go-exercise/internal/ports"
+	"go-exercise/internal/ports/mocks"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_GetLTP_NDJSON(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	btcCHF, _ := domain.NewPair(domain.BTCCHF)
+	ltpService.On("GetLTPs", mock.Anything, "BTC/USD,BTC/EUR,BTC/CHF", ports.LTPOptions{}).Return([]domain.LTP{
+		{Pair: btcCHF, Amount: 49000.12},
+		{Pair: btcEUR, Amount: 50000.12, Bid: 50000.1},
+		{Pair: btcUSD, Amount: 52000.12, RawAmount: "52000.12000"},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD,BTC/EUR,BTC/CHF&format=ndjson&fields=bid", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	err := handler.GetLTP(echo.New().NewContext(req, rec))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, MIMEApplicationNDJSON, rec.Header().Get(echo.HeaderContentType))
+	assert.True(t, rec.Flushed, "lines are flushed as they are written")
+
+	var items []dto.LTPItem
+	scanner := bufio.NewScanner(bytes.NewReader(rec.Body.Bytes()))
+	for scanner.Scan() {
+		decoder := json.NewDecoder(bytes.NewReader(scanner.Bytes()))
+		decoder.DisallowUnknownFields()
+		var item dto.LTPItem
+		require.NoError(t, decoder.Decode(&item), "every line is a valid LTPItem: %s", scanner.Text())
+		items = append(items, item)
+	}
+	require.NoError(t, scanner.Err())
+	require.Len(t, items, 3)
+	assert.Equal(t, "BTC/CHF", items[0].Pair)
+	assert.Equal(t, "BTC/EUR", items[1].Pair)
+	require.NotNil(t, items[1].Bid)
+	assert.Equal(t, 50000.1, *items[1].Bid)
+	assert.Equal(t, "BTC/USD", items[2].Pair)
+	assert.Equal(t, "52000.12000", items[2].Amount.String())
+}
+
+func TestHandler_GetLTP_InvalidFormat(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?format=csv", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	err := handler.GetLTP(echo.New().NewContext(req, rec))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "invalid format value: csv. Valid values are: ndjson")
+	ltpService.AssertNotCalled(t, "GetLTPs", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,103 @@
+package http
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// responseFormats are the media types responses can be serialized to, JSON first as the default
+var responseFormats = []string{echo.MIMEApplicationJSON, echo.MIMEApplicationXML, echo.MIMETextXML}
+
+// negotiateFormat picks the offered media type best matching an Accept header, honoring
+// q-values and preferring exact over wildcard matches. The first offer is the default,
+// used when the header is empty or accepts none of the offers.
+func negotiateFormat(accept string, offers []string) string {
+	best, bestQ, bestExact := offers[0], 0.0, false
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, q := parseAcceptPart(part)
+		if q <= 0 || q < bestQ {
+			continue
+		}
+		for _, offer := range offers {
+			if !mediaTypeMatches(mediaType, offer) {
+				continue
+			}
+			exact := mediaType == offer
+			if q > bestQ || (exact && !bestExact) {
+				best, bestQ, bestExact = offer, q, exact
+			}
+			break
+		}
+	}
+	return best
+}
+
+// parseAcceptPart splits one Accept header entry into its lowercased media type and q-value.
+// The q-value defaults to 1; a malformed one disqualifies the entry.
+func parseAcceptPart(part string) (string, float64) {
+	params := strings.Split(part, ";")
+	mediaType := strings.ToLower(strings.TrimSpace(params[0]))
+	q := 1.0
+	for _, param := range params[1:] {
+		name, value, found := strings.Cut(strings.TrimSpace(param), "=")
+		if !found || !strings.EqualFold(strings.TrimSpace(name), "q") {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return mediaType, 0
+		}
+		q = parsed
+	}
+	return mediaType, q
+}
+
+// mediaTypeMatches reports whether an accepted media type, possibly a wildcard, covers an offer
+func mediaTypeMatches(accepted, offer string) bool {
+	if accepted == "*/*" || accepted == offer {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(accepted, "/*"); ok {
+		return strings.HasPrefix(offer, prefix+"/")
+	}
+	return false
+}
+
+// prettyIndent indents pretty-printed responses
+const prettyIndent = "  "
+
+// respondNegotiated serializes the response in the format negotiated from the Accept header,
+// JSON unless XML is preferred, and writes it with a weak ETag. With pretty set the body is
+// indented for human reading; it is compact otherwise.
+func respondNegotiated(c echo.Context, response interface{}, pretty bool) error {
+	c.Response().Header().Add(echo.HeaderVary, echo.HeaderAccept)
+
+	var body []byte
+	var err error
+	switch format := negotiateFormat(c.Request().Header.Get(echo.HeaderAccept), responseFormats); format {
+	case echo.MIMEApplicationXML, echo.MIMETextXML:
+		if pretty {
+			body, err = xml.MarshalIndent(response, "", prettyIndent)
+		} else {
+			body, err = xml.Marshal(response)
+		}
+		if err != nil {
+			return err
+		}
+		return blobWithETag(c, format+"; charset=UTF-8", append([]byte(xml.Header), body...))
+	default:
+		if pretty {
+			body, err = json.MarshalIndent(response, "", prettyIndent)
+		} else {
+			body, err = json.Marshal(response)
+		}
+		if err != nil {
+			return err
+		}
+		return blobWithETag(c, echo.MIMEApplicationJSON, body)
+	}
+}
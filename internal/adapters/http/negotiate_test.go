@@ -0,0 +1,38 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiateFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		accept   string
+		expected string
+	}{
+		{"no header", "", echo.MIMEApplicationJSON},
+		{"json", "application/json", echo.MIMEApplicationJSON},
+		{"xml", "application/xml", echo.MIMEApplicationXML},
+		{"text xml", "text/xml", echo.MIMETextXML},
+		{"case insensitive", "Application/XML", echo.MIMEApplicationXML},
+		{"anything", "*/*", echo.MIMEApplicationJSON},
+		{"type wildcard", "text/*", echo.MIMETextXML},
+		{"unsupported only", "text/html", echo.MIMEApplicationJSON},
+		{"first supported", "text/html, application/xml", echo.MIMEApplicationXML},
+		{"higher q wins", "application/json;q=0.5, application/xml;q=0.9", echo.MIMEApplicationXML},
+		{"exact beats wildcard", "*/*, application/xml", echo.MIMEApplicationXML},
+		{"wildcard with lower q", "application/xml, */*;q=0.1", echo.MIMEApplicationXML},
+		{"q zero excludes", "application/xml;q=0", echo.MIMEApplicationJSON},
+		{"malformed q ignored", "application/xml;q=high", echo.MIMEApplicationJSON},
+		{"browser style", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", echo.MIMEApplicationXML},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, negotiateFormat(tt.accept, responseFormats))
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go-exercise/internal/adapters/http/dto"
+	"go-exercise/internal/domain"
+)
+
+// DefaultCandleInterval is the candle interval in minutes used when none is given
+const DefaultCandleInterval = 1
+
+// GetOHLC handles GET /api/v1/ohlc
+// @Summary Get OHLC candles
+// @Description Get the recent open/high/low/close candles of a pair from the price provider, oldest first
+// @Tags ltp
+// @Produce json
+// @Security ApiKeyAuth
+// @Param pair query string true "Currency pair (e.g., BTC/USD)"
+// @Param interval query int false "Candle interval in minutes" Enums(1, 5, 15, 30, 60, 240, 1440, 10080, 21600) default(1)
+// @Success 200 {object} dto.OHLCResponse "Successfully retrieved candles"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request parameters"
+// @Failure 401 {object} dto.ErrorResponse "Missing or invalid API key, when API keys are configured"
+// @Failure 422 {object} dto.ErrorResponse "Pair not supported by any provider"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Failure 501 {object} dto.ErrorResponse "The price provider does not serve candles"
+// @Failure 502 {object} dto.ErrorResponse "Upstream price provider unavailable"
+// @Failure 503 {object} dto.ErrorResponse "Upstream price provider rate limited; see the Retry-After header"
+// @Router /api/v1/ohlc [get]
+func (h *Handler) GetOHLC(c echo.Context) error {
+	interval := DefaultCandleInterval
+	if raw := c.QueryParam("interval"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || !domain.IsValidCandleInterval(value) {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: fmt.Sprintf("invalid interval value: %s. Valid intervals in minutes are: %v", raw, domain.CandleIntervals),
+				Code:  dto.CodeInvalidParameter,
+			})
+		}
+		interval = value
+	}
+
+	candles, err := h.ltpService.GetOHLC(c.Request().Context(), c.QueryParam("pair"), interval)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	// The service already validated the pair
+	pair, _ := domain.NewPair(c.QueryParam("pair"))
+	response := dto.OHLCResponse{
+		Pair:     pair.Value(),
+		Interval: interval,
+		Candles:  make([]dto.Candle, len(candles)),
+	}
+	for i, candle := range candles {
+		response.Candles[i] = dto.Candle{
+			Time:   candle.Time,
+			Open:   candle.Open,
+			High:   candle.High,
+			Low:    candle.Low,
+			Close:  candle.Close,
+			Volume: candle.Volume,
+		}
+	}
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,113 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-exercise/internal/adapters/http/dto"
+	"go-exercise/internal/domain"
+	"go-exercise/internal/ports/mocks"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_GetOHLC_Success(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	ltpService.On("GetOHLC", mock.Anything, "BTC/USD", 5).Return([]domain.Candle{
+		{Time: start, Open: 42000.1, High: 42100, Low: 41950.5, Close: 42050.2, Volume: 12.5},
+	}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ohlc?pair=BTC/USD&interval=5", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.GetOHLC(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"pair":"BTC/USD","interval":5,"candles":[
+		{"time":"2024-01-01T12:00:00Z","open":42000.1,"high":42100,"low":41950.5,"close":42050.2,"volume":12.5}
+	]}`, rec.Body.String())
+	ltpService.AssertExpectations(t)
+}
+
+func TestHandler_GetOHLC_DefaultInterval(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+	ltpService.On("GetOHLC", mock.Anything, "BTC/EUR", DefaultCandleInterval).Return([]domain.Candle{}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ohlc?pair=BTC/EUR", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.GetOHLC(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"pair":"BTC/EUR","interval":1,"candles":[]}`, rec.Body.String())
+	ltpService.AssertExpectations(t)
+}
+
+func TestHandler_GetOHLC_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		serviceErr     error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"non-numeric interval", "pair=BTC/USD&interval=hourly", nil, http.StatusBadRequest, dto.CodeInvalidParameter},
+		{"unsupported interval", "pair=BTC/USD&interval=7", nil, http.StatusBadRequest, dto.CodeInvalidParameter},
+		{"invalid pair", "pair=BTC/XYZ", fmt.Errorf("%w: BTC/XYZ", domain.ErrInvalidPair), http.StatusBadRequest, dto.CodeInvalidPair},
+		{"provider without candles", "pair=BTC/USD", domain.ErrCandlesUnsupported, http.StatusNotImplemented, dto.CodeCandlesUnsupported},
+		{"upstream failure", "pair=BTC/USD", fmt.Errorf("%w: boom", domain.ErrUpstreamUnavailable), http.StatusBadGateway, dto.CodeUpstreamUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ltpService := new(mocks.LTPService)
+			handler := NewHandler(ltpService)
+			if tt.serviceErr != nil {
+				ltpService.On("GetOHLC", mock.Anything, mock.Anything, mock.Anything).Return(nil, tt.serviceErr)
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/ohlc?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// Act
+			err := handler.GetOHLC(c)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			var response dto.ErrorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedCode, response.Code)
+			if tt.serviceErr == nil {
+				ltpService.AssertNotCalled(t, "GetOHLC", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
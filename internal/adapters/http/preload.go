@@ -0,0 +1,140 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"go-exercise/internal/adapters/http/dto"
+	"go-exercise/internal/domain"
+)
+
+// PreloadCache handles POST /api/v1/cache/preload
+// @Summary Preload cached prices
+// @Description Store the given prices in the cache without calling the price provider, e.g. to seed a fresh instance or pin prices in tests.
+// @Description The request is all-or-nothing: nothing is stored if any pair or amount is invalid, a pair is given more than once,
+// @Description or an amount is outside the configured price bounds. Only available when API keys are configured.
+// @Tags cache
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param body body map[string]number true "Prices keyed by pair (e.g., BTC/USD mapped to 52000.12)"
+// @Success 200 {object} dto.PreloadResponse "Prices stored"
+// @Failure 400 {object} dto.ErrorResponse "Malformed body, or invalid, duplicate or out-of-bounds entries"
+// @Failure 401 {object} dto.ErrorResponse "Missing or invalid API key"
+// @Router /api/v1/cache/preload [post]
+func (h *Handler) PreloadCache(c echo.Context) error {
+	var request dto.PreloadRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: fmt.Sprintf("invalid request body: %v", err),
+			Code:  dto.CodeBadRequest,
+		})
+	}
+	if len(request) == 0 {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "request body must map at least one pair to a price",
+			Code:  dto.CodeInvalidParameter,
+		})
+	}
+
+	// Validate everything before storing anything so a bad entry leaves the cache untouched
+	ltps := make([]domain.LTP, 0, len(request))
+	var invalidPairs, invalidAmounts []string
+	// Keys are normalized, so e.g. BTC/USD and btc-usd name the same pair
+	keys := make(map[domain.Pair][]string, len(request))
+	for value, rawAmount := range request {
+		pair, err := domain.NewPair(value)
+		if err != nil {
+			invalidPairs = append(invalidPairs, value)
+			continue
+		}
+		keys[pair] = append(keys[pair], value)
+		if len(keys[pair]) > 1 {
+			continue
+		}
+		amount, err := rawAmount.Float64()
+		if err != nil || amount <= 0 {
+			invalidAmounts = append(invalidAmounts, value)
+			continue
+		}
+		ltps = append(ltps, domain.LTP{Pair: pair, Amount: amount, RawAmount: rawAmount.String()})
+	}
+	if len(invalidPairs) > 0 {
+		sort.Strings(invalidPairs)
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: fmt.Sprintf("%s: %s", domain.ErrInvalidPair, strings.Join(invalidPairs, ", ")),
+			Code:  dto.CodeInvalidPair,
+		})
+	}
+	var duplicates []string
+	for pair, values := range keys {
+		if len(values) > 1 {
+			sort.Strings(values)
+			duplicates = append(duplicates, fmt.Sprintf("%s (%s)", pair.Value(), strings.Join(values, ", ")))
+		}
+	}
+	if len(duplicates) > 0 {
+		sort.Strings(duplicates)
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: fmt.Sprintf("%s: pairs given more than once: %s", domain.ErrInvalidPair, strings.Join(duplicates, "; ")),
+			Code:  dto.CodeInvalidPair,
+		})
+	}
+	if len(invalidAmounts) > 0 {
+		sort.Strings(invalidAmounts)
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: fmt.Sprintf("amounts must be positive numbers: %s", strings.Join(invalidAmounts, ", ")),
+			Code:  dto.CodeInvalidParameter,
+		})
+	}
+
+	sort.Slice(ltps, func(i, j int) bool {
+		return ltps[i].Pair.Value() < ltps[j].Pair.Value()
+	})
+	if err := h.ltpService.PreloadLTPs(ltps); err != nil {
+		if errors.Is(err, domain.ErrPriceOutOfBounds) {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: err.Error(),
+				Code:  dto.CodeInvalidParameter,
+			})
+		}
+		return respondError(c, err)
+	}
+
+	response := dto.PreloadResponse{Loaded: make([]string, len(ltps))}
+	for i, ltp := range ltps {
+		response.Loaded[i] = ltp.Pair.Value()
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// InvalidateCache handles DELETE /api/v1/cache
+// @Summary Invalidate cached prices
+// @Description Drop the cached price of one pair, so the next request fetches it, or of every pair when no pair is given.
+// @Description Stored history is kept for single pairs. Only available when API keys are configured.
+// @Tags cache
+// @Security ApiKeyAuth
+// @Param pair query string false "Currency pair to invalidate (e.g., BTC/USD); all pairs when omitted"
+// @Success 204 "Cache invalidated"
+// @Failure 400 {object} dto.ErrorResponse "Invalid pair"
+// @Failure 401 {object} dto.ErrorResponse "Missing or invalid API key"
+// @Router /api/v1/cache [delete]
+func (h *Handler) InvalidateCache(c echo.Context) error {
+	value := c.QueryParam("pair")
+	if value == "" {
+		h.ltpService.ClearCache()
+		return c.NoContent(http.StatusNoContent)
+	}
+
+	pair, err := domain.NewPair(value)
+	if err != nil {
+		return respondError(c, err)
+	}
+	h.ltpService.InvalidateLTP(pair)
+	return c.NoContent(http.StatusNoContent)
+}
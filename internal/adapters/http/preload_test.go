@@ -0,0 +1,185 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go-exercise/internal/adapters/http/dto"
+	"go-exercise/internal/domain"
+	"go-exercise/internal/ports/mocks"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_PreloadCache_Success(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	ltpService.On("PreloadLTPs", []domain.LTP{
+		{Pair: btcEUR, Amount: 50000.12, RawAmount: "50000.12"},
+		{Pair: btcUSD, Amount: 52000, RawAmount: "52000"},
+	}).Return(nil)
+
+	e := echo.New()
+	body := `{"BTC/USD": 52000, "BTC/EUR": 50000.12}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/cache/preload", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.PreloadCache(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.PreloadResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, []string{domain.BTCEUR, domain.BTCUSD}, response.Loaded)
+	ltpService.AssertExpectations(t)
+}
+
+func TestHandler_PreloadCache_BadRequest(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		expectedCode string
+		expectedMsg  string
+	}{
+		{"malformed body", `{"BTC/USD": `, dto.CodeBadRequest, "invalid request body"},
+		{"not an object", `[52000]`, dto.CodeBadRequest, "invalid request body"},
+		{"empty object", `{}`, dto.CodeInvalidParameter, "at least one pair"},
+		{"invalid pairs are reported", `{"BTC/USD": 52000, "ETH/USD": 3000, "BTC/XYZ": 1}`, dto.CodeInvalidPair, "BTC/XYZ, ETH/USD"},
+		{"non-positive amount", `{"BTC/USD": 0}`, dto.CodeInvalidParameter, "BTC/USD"},
+		{"non-numeric amount", `{"BTC/USD": "cheap"}`, dto.CodeBadRequest, "invalid request body"},
+		{"same pair in two spellings", `{"BTC/USD": 1, "btc-usd": 2, "BTC/EUR": 3}`, dto.CodeInvalidPair, "BTC/USD (BTC/USD, btc-usd)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ltpService := new(mocks.LTPService)
+			handler := NewHandler(ltpService)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/cache/preload", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// Act
+			err := handler.PreloadCache(c)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+			var response dto.ErrorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedCode, response.Code)
+			assert.Contains(t, response.Error, tt.expectedMsg)
+			// Nothing is stored when any entry is invalid
+			ltpService.AssertNotCalled(t, "PreloadLTPs", mock.Anything)
+		})
+	}
+}
+
+func TestHandler_PreloadCache_OutOfBounds(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	boundsErr := &domain.PriceBoundsError{Pair: btcUSD, Amount: 5, Bounds: domain.AmountBounds{Min: 1000}}
+	ltpService.On("PreloadLTPs", mock.Anything).Return(boundsErr)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/cache/preload", strings.NewReader(`{"BTC/USD": 5}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	// Act
+	err := handler.PreloadCache(e.NewContext(req, rec))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var response dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, dto.CodeInvalidParameter, response.Code)
+	assert.Equal(t, boundsErr.Error(), response.Error)
+}
+
+func TestHandler_InvalidateCache(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+
+	t.Run("single pair", func(t *testing.T) {
+		// Arrange
+		ltpService := new(mocks.LTPService)
+		ltpService.On("InvalidateLTP", btcUSD).Return().Once()
+		handler := NewHandler(ltpService)
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/cache?pair=btc-usd", nil)
+		rec := httptest.NewRecorder()
+
+		// Act
+		err := handler.InvalidateCache(e.NewContext(req, rec))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		ltpService.AssertExpectations(t)
+		ltpService.AssertNotCalled(t, "ClearCache")
+	})
+
+	t.Run("all pairs", func(t *testing.T) {
+		// Arrange
+		ltpService := new(mocks.LTPService)
+		ltpService.On("ClearCache").Return().Once()
+		handler := NewHandler(ltpService)
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/cache", nil)
+		rec := httptest.NewRecorder()
+
+		// Act
+		err := handler.InvalidateCache(e.NewContext(req, rec))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		ltpService.AssertExpectations(t)
+		ltpService.AssertNotCalled(t, "InvalidateLTP", mock.Anything)
+	})
+
+	t.Run("invalid pair", func(t *testing.T) {
+		// Arrange
+		ltpService := new(mocks.LTPService)
+		handler := NewHandler(ltpService)
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/cache?pair=BTC/INVALID", nil)
+		rec := httptest.NewRecorder()
+
+		// Act
+		err := handler.InvalidateCache(e.NewContext(req, rec))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), dto.CodeInvalidPair)
+		ltpService.AssertNotCalled(t, "InvalidateLTP", mock.Anything)
+		ltpService.AssertNotCalled(t, "ClearCache")
+	})
+}
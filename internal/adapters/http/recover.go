@@ -0,0 +1,43 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"go-exercise/internal/adapters/http/dto"
+)
+
+// recoverMiddleware turns panics into a 500 error response and logs them as structured
+// error events with the stack trace
+func recoverMiddleware(logger *slog.Logger) echo.MiddlewareFunc {
+	return middleware.RecoverWithConfig(middleware.RecoverConfig{
+		LogErrorFunc: func(c echo.Context, err error, stack []byte) error {
+			req := c.Request()
+			logger.Error("recovered from panic",
+				slog.String("error", err.Error()),
+				slog.String("method", req.Method),
+				slog.String("path", req.URL.Path),
+				slog.String("request_id", requestID(c)),
+				slog.String("stack", string(stack)),
+			)
+			// A handler that panicked mid-response cannot be answered anymore
+			if c.Response().Committed {
+				return nil
+			}
+			return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Error: "internal server error",
+				Code:  dto.CodeInternalError,
+			})
+		},
+	})
+}
+
+// requestID returns the request's ID, as sent by the client or set on the response
+func requestID(c echo.Context) string {
+	if id := c.Request().Header.Get(echo.HeaderXRequestID); id != "" {
+		return id
+	}
+	return c.Response().Header().Get(echo.HeaderXRequestID)
+}
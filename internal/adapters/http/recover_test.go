@@ -0,0 +1,49 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-exercise/internal/adapters/http/dto"
+	"go-exercise/internal/ports/mocks"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouter_RecoversFromPanics(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	router := SetupRouter(NewHandler(new(mocks.LTPService)), WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))))
+	router.GET("/panic", func(c echo.Context) error {
+		panic("boom")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/panic", nil)
+	req.Header.Set(echo.HeaderXRequestID, "req-123")
+	rec := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rec, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	var response dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, dto.CodeInternalError, response.Code)
+	assert.NotContains(t, rec.Body.String(), "boom")
+
+	var event map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &event))
+	assert.Equal(t, "ERROR", event["level"])
+	assert.Equal(t, "recovered from panic", event["msg"])
+	assert.Equal(t, "boom", event["error"])
+	assert.Equal(t, "/panic", event["path"])
+	assert.Equal(t, "req-123", event["request_id"])
+	assert.Contains(t, event["stack"], "recover_test.go")
+}
@@ -1,32 +1,154 @@
 package http
 
 import (
+	"log"
+	"log/slog"
+
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	echoSwagger "github.com/swaggo/echo-swagger"
-	
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+
 	_ "go-exercise/docs" // Swagger documentation
 )
 
+// ServiceName identifies this service in traces
+const ServiceName = "go-exercise"
+
+// routerConfig holds optional router settings
+type routerConfig struct {
+	compressionMinLength int
+	apiKeys              []string
+	cors                 middleware.CORSConfig
+	debugLogger          *log.Logger
+	logger               *slog.Logger
+}
+
+// RouterOption configures optional router behavior
+type RouterOption func(*routerConfig)
+
+// WithCompressionMinLength sets the minimum response size in bytes before responses are compressed
+func WithCompressionMinLength(minLength int) RouterOption {
+	return func(cfg *routerConfig) {
+		cfg.compressionMinLength = minLength
+	}
+}
+
+// WithAPIKeys requires requests to /api/v1 routes to present one of the given keys in the
+// X-API-Key header. Without keys the API stays open.
+func WithAPIKeys(keys []string) RouterOption {
+	return func(cfg *routerConfig) {
+		cfg.apiKeys = keys
+	}
+}
+
+// WithCORSAllowedOrigins restricts cross-origin requests to the given origins
+func WithCORSAllowedOrigins(origins []string) RouterOption {
+	return func(cfg *routerConfig) {
+		cfg.cors.AllowOrigins = origins
+	}
+}
+
+// WithCORSAllowedMethods restricts the methods allowed in cross-origin requests
+func WithCORSAllowedMethods(methods []string) RouterOption {
+	return func(cfg *routerConfig) {
+		cfg.cors.AllowMethods = methods
+	}
+}
+
+// WithCORSAllowedHeaders restricts the headers allowed in cross-origin requests.
+// Without it the headers requested in a preflight are allowed.
+func WithCORSAllowedHeaders(headers []string) RouterOption {
+	return func(cfg *routerConfig) {
+		cfg.cors.AllowHeaders = headers
+	}
+}
+
+// WithDebugLogging logs every request's query parameters and headers, API keys redacted,
+// along with its response body. It is meant for debugging only and is off by default.
+func WithDebugLogging(logger *log.Logger) RouterOption {
+	return func(cfg *routerConfig) {
+		cfg.debugLogger = logger
+	}
+}
+
+// WithLogger sets the structured logger used for recovered panics.
+// Without it slog.Default() is used.
+func WithLogger(logger *slog.Logger) RouterOption {
+	return func(cfg *routerConfig) {
+		cfg.logger = logger
+	}
+}
+
 // SetupRouter configures the Echo router with routes and middleware
-func SetupRouter(handler *Handler) *echo.Echo {
+func SetupRouter(handler *Handler, opts ...RouterOption) *echo.Echo {
+	cfg := routerConfig{
+		compressionMinLength: DefaultCompressionMinLength,
+		cors:                 middleware.DefaultCORSConfig,
+		logger:               slog.Default(),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
 	e := echo.New()
+	e.HTTPErrorHandler = httpErrorHandler
 
 	// Middleware
+	// Tracing comes first so the server span, joined to any incoming trace context, covers the whole request
+	e.Use(otelecho.Middleware(ServiceName))
 	e.Use(middleware.Logger())
-	e.Use(middleware.Recover())
-	e.Use(middleware.CORS())
+	e.Use(recoverMiddleware(cfg.logger))
+	e.Use(middleware.CORSWithConfig(cfg.cors))
+	e.Use(compressMiddleware(cfg.compressionMinLength))
+	// Registered after compression so the logged bodies are uncompressed
+	if cfg.debugLogger != nil {
+		e.Use(debugLogMiddleware(cfg.debugLogger))
+	}
 
 	// Routes
 	api := e.Group("/api/v1")
+	if len(cfg.apiKeys) > 0 {
+		api.Use(apiKeyMiddleware(cfg.apiKeys))
+	}
 	api.GET("/ltp", handler.GetLTP)
+	api.GET("/ltp/events", handler.StreamLTP)
+	api.GET("/ltp/history", handler.GetHistory)
+	api.GET("/ltp/twap", handler.GetTWAP)
+	api.GET("/ltp/validate", handler.ValidatePairs)
+	api.GET("/ltp/cross", handler.GetCrossRates)
+	api.GET("/ohlc", handler.GetOHLC)
+	api.GET("/pairs", handler.GetPairs)
+	api.GET("/convert", handler.Convert)
+	api.GET("/cache/stats", handler.GetCacheStats)
+	api.GET("/status", handler.GetStatus)
+	api.GET("/status/upstream", handler.GetUpstreamStatus)
+	// Writing to the cache, spending upstream requests and the configuration are only exposed
+	// when API keys guard them
+	if len(cfg.apiKeys) > 0 {
+		api.POST("/cache/preload", handler.PreloadCache)
+		api.DELETE("/cache", handler.InvalidateCache)
+		if handler.external != nil {
+			api.GET("/diag", handler.Diagnose)
+		}
+		if handler.config != nil {
+			api.GET("/config", handler.GetConfig)
+		}
+	}
+
+	// Version 2 wraps responses in an envelope with metadata; version 1 keeps its shape
+	v2 := e.Group("/api/v2")
+	if len(cfg.apiKeys) > 0 {
+		v2.Use(apiKeyMiddleware(cfg.apiKeys))
+	}
+	v2.GET("/ltp", handler.GetLTPEnvelope)
 
 	// Health check
 	e.GET("/health", handler.Health)
+	e.GET("/health/ready", handler.Ready)
 
 	// Swagger documentation
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 
 	return e
 }
-
@@ -1,17 +1,31 @@
 package http
 
 import (
+	"compress/gzip"
+	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"go-exercise/internal/adapters/http/dto"
 	"go-exercise/internal/domain"
+	"go-exercise/internal/ports"
 	"go-exercise/internal/ports/mocks"
 
+	"github.com/andybalholm/brotli"
+	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 func TestRouter_GetLTP_Endpoint(t *testing.T) {
@@ -31,7 +45,7 @@ func TestRouter_GetLTP_Endpoint(t *testing.T) {
 			{Pair: btcUSD, Amount: 52000.12},
 		}
 
-		ltpService.On("GetLTPs", "").Return(expectedLTPs, nil)
+		ltpService.On("GetLTPs", mock.Anything, "", ports.LTPOptions{}).Return(expectedLTPs, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp", nil)
 		rec := httptest.NewRecorder()
@@ -61,7 +75,7 @@ func TestRouter_GetLTP_Endpoint(t *testing.T) {
 			{Pair: btcUSD, Amount: 52000.12},
 		}
 
-		ltpService.On("GetLTPs", "BTC/USD").Return(expectedLTPs, nil)
+		ltpService.On("GetLTPs", mock.Anything, "BTC/USD", ports.LTPOptions{}).Return([]domain.LTP{expectedLTPs[0]}, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD", nil)
 		rec := httptest.NewRecorder()
@@ -77,7 +91,7 @@ func TestRouter_GetLTP_Endpoint(t *testing.T) {
 		require.NoError(t, err)
 		assert.Len(t, response.LTP, 1)
 		assert.Equal(t, "BTC/USD", response.LTP[0].Pair)
-		assert.Equal(t, 52000.12, response.LTP[0].Amount)
+		assert.Equal(t, json.Number("52000.12"), response.LTP[0].Amount)
 
 		ltpService.AssertExpectations(t)
 	})
@@ -96,7 +110,7 @@ func TestRouter_GetLTP_Endpoint(t *testing.T) {
 			{Pair: btcUSD, Amount: 52000.12},
 		}
 
-		ltpService.On("GetLTPs", "BTC/USD,BTC/EUR").Return(expectedLTPs, nil)
+		ltpService.On("GetLTPs", mock.Anything, "BTC/USD,BTC/EUR", ports.LTPOptions{}).Return(expectedLTPs, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD,BTC/EUR", nil)
 		rec := httptest.NewRecorder()
@@ -121,7 +135,7 @@ func TestRouter_GetLTP_Endpoint(t *testing.T) {
 		handler := NewHandler(ltpService)
 		router := SetupRouter(handler)
 
-		ltpService.On("GetLTPs", "BTC/INVALID").Return(nil, assert.AnError)
+		ltpService.On("GetLTPs", mock.Anything, "BTC/INVALID", ports.LTPOptions{}).Return(nil, domain.ErrInvalidPair)
 
 		req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/INVALID", nil)
 		rec := httptest.NewRecorder()
@@ -156,7 +170,7 @@ func TestRouter_GetLTP_Endpoint(t *testing.T) {
 			{Pair: btcUSD, Amount: 52000.12},
 		}
 
-		ltpService.On("GetLTPs", "").Return(expectedLTPs, nil)
+		ltpService.On("GetLTPs", mock.Anything, "", ports.LTPOptions{}).Return(expectedLTPs, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=", nil)
 		rec := httptest.NewRecorder()
@@ -213,6 +227,12 @@ func TestRouter_NotFound(t *testing.T) {
 
 	// Assert
 	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMEApplicationJSON)
+
+	var response dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, dto.CodeNotFound, response.Code)
+	assert.NotEmpty(t, response.Error)
 
 	ltpService.AssertNotCalled(t, "GetLTPs")
 }
@@ -231,10 +251,42 @@ func TestRouter_MethodNotAllowed(t *testing.T) {
 
 	// Assert
 	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMEApplicationJSON)
+
+	var response dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, dto.CodeMethodNotAllowed, response.Code)
+	assert.NotEmpty(t, response.Error)
 
 	ltpService.AssertNotCalled(t, "GetLTPs")
 }
 
+func TestRouter_MalformedJSON_ReturnsBadRequest(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+	router := SetupRouter(handler, WithAPIKeys([]string{"admin"}))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/cache/preload", strings.NewReader(`{"BTC/USD": `))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("X-API-Key", "admin")
+	rec := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rec, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMEApplicationJSON)
+
+	var response dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, dto.CodeBadRequest, response.Code)
+	assert.Contains(t, response.Error, "invalid request body")
+
+	ltpService.AssertNotCalled(t, "PreloadLTPs", mock.Anything)
+}
+
 func TestRouter_CORS_Headers(t *testing.T) {
 	// Arrange
 	ltpService := new(mocks.LTPService)
@@ -246,7 +298,7 @@ func TestRouter_CORS_Headers(t *testing.T) {
 		{Pair: btcUSD, Amount: 52000.12},
 	}
 
-	ltpService.On("GetLTPs", "BTC/USD").Return(expectedLTPs, nil)
+	ltpService.On("GetLTPs", mock.Anything, "BTC/USD", ports.LTPOptions{}).Return([]domain.LTP{expectedLTPs[0]}, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD", nil)
 	req.Header.Set("Origin", "http://localhost:3000")
@@ -262,3 +314,506 @@ func TestRouter_CORS_Headers(t *testing.T) {
 
 	ltpService.AssertExpectations(t)
 }
+
+func TestRouter_CORS_AllowedOrigins(t *testing.T) {
+	newRouter := func() *echo.Echo {
+		ltpService := new(mocks.LTPService)
+		ltpService.On("GetCacheStats").Return(domain.CacheStats{}).Maybe()
+		return SetupRouter(NewHandler(ltpService),
+			WithCORSAllowedOrigins([]string{"https://app.example.com"}),
+			WithCORSAllowedMethods([]string{http.MethodGet}),
+			WithCORSAllowedHeaders([]string{HeaderAPIKey}),
+		)
+	}
+
+	t.Run("allowed origin is echoed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/cache/stats", nil)
+		req.Header.Set("Origin", "https://app.example.com")
+		rec := httptest.NewRecorder()
+
+		newRouter().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "https://app.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("disallowed origin is not echoed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/cache/stats", nil)
+		req.Header.Set("Origin", "https://evil.example.com")
+		rec := httptest.NewRecorder()
+
+		newRouter().ServeHTTP(rec, req)
+
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("preflight returns the configured methods and headers", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodOptions, "/api/v1/cache/stats", nil)
+		req.Header.Set("Origin", "https://app.example.com")
+		req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+		rec := httptest.NewRecorder()
+
+		newRouter().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, "https://app.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, http.MethodGet, rec.Header().Get("Access-Control-Allow-Methods"))
+		assert.Equal(t, HeaderAPIKey, rec.Header().Get("Access-Control-Allow-Headers"))
+	})
+}
+
+func TestRouter_CacheStats_Endpoint(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+	router := SetupRouter(handler)
+
+	ltpService.On("GetCacheStats").Return(domain.CacheStats{Entries: 2})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/cache/stats", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rec, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.CacheStatsResponse
+	err := json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, 2, response.Entries)
+
+	ltpService.AssertExpectations(t)
+}
+
+func TestRouter_Pairs_Endpoint(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+	router := SetupRouter(handler)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pairs", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rec, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.PairsResponse
+	err := json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Len(t, response.Pairs, 3)
+}
+
+func TestRouter_APIKeyAuth(t *testing.T) {
+	tests := []struct {
+		name           string
+		opts           []RouterOption
+		path           string
+		apiKey         string
+		expectedStatus int
+	}{
+		{
+			name:           "disabled auth leaves the API open",
+			path:           "/api/v1/cache/stats",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "valid key is accepted",
+			opts:           []RouterOption{WithAPIKeys([]string{"first", "second"})},
+			path:           "/api/v1/cache/stats",
+			apiKey:         "second",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid key is rejected",
+			opts:           []RouterOption{WithAPIKeys([]string{"first", "second"})},
+			path:           "/api/v1/cache/stats",
+			apiKey:         "third",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "missing key is rejected",
+			opts:           []RouterOption{WithAPIKeys([]string{"first"})},
+			path:           "/api/v1/cache/stats",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "health stays unauthenticated",
+			opts:           []RouterOption{WithAPIKeys([]string{"first"})},
+			path:           "/health",
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ltpService := new(mocks.LTPService)
+			ltpService.On("GetCacheStats").Return(domain.CacheStats{}).Maybe()
+			handler := NewHandler(ltpService)
+			router := SetupRouter(handler, tt.opts...)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.apiKey != "" {
+				req.Header.Set(HeaderAPIKey, tt.apiKey)
+			}
+			rec := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(rec, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusUnauthorized {
+				ltpService.AssertNotCalled(t, "GetCacheStats")
+			}
+		})
+	}
+}
+
+func TestRouter_CachePreload(t *testing.T) {
+	tests := []struct {
+		name           string
+		opts           []RouterOption
+		apiKey         string
+		expectedStatus int
+	}{
+		{
+			name:           "not exposed without API keys",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "requires a valid key",
+			opts:           []RouterOption{WithAPIKeys([]string{"admin"})},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "stores prices with a valid key",
+			opts:           []RouterOption{WithAPIKeys([]string{"admin"})},
+			apiKey:         "admin",
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ltpService := new(mocks.LTPService)
+			ltpService.On("PreloadLTPs", mock.Anything).Return(nil).Maybe()
+			router := SetupRouter(NewHandler(ltpService), tt.opts...)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/cache/preload", strings.NewReader(`{"BTC/USD": 52000}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			if tt.apiKey != "" {
+				req.Header.Set(HeaderAPIKey, tt.apiKey)
+			}
+			rec := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(rec, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus != http.StatusOK {
+				ltpService.AssertNotCalled(t, "PreloadLTPs", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestRouter_CacheInvalidate(t *testing.T) {
+	tests := []struct {
+		name           string
+		opts           []RouterOption
+		apiKey         string
+		expectedStatus int
+	}{
+		{
+			name:           "not exposed without API keys",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "requires a valid key",
+			opts:           []RouterOption{WithAPIKeys([]string{"admin"})},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "clears the cache with a valid key",
+			opts:           []RouterOption{WithAPIKeys([]string{"admin"})},
+			apiKey:         "admin",
+			expectedStatus: http.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ltpService := new(mocks.LTPService)
+			ltpService.On("ClearCache").Return().Maybe()
+			router := SetupRouter(NewHandler(ltpService), tt.opts...)
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/cache", nil)
+			if tt.apiKey != "" {
+				req.Header.Set(HeaderAPIKey, tt.apiKey)
+			}
+			rec := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(rec, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus != http.StatusNoContent {
+				ltpService.AssertNotCalled(t, "ClearCache")
+			}
+		})
+	}
+}
+
+func TestRouter_Diag(t *testing.T) {
+	tests := []struct {
+		name           string
+		diagnostics    bool
+		opts           []RouterOption
+		apiKey         string
+		expectedStatus int
+	}{
+		{
+			name:           "not exposed without API keys",
+			diagnostics:    true,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "not exposed without a provider",
+			opts:           []RouterOption{WithAPIKeys([]string{"admin"})},
+			apiKey:         "admin",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "requires a valid key",
+			diagnostics:    true,
+			opts:           []RouterOption{WithAPIKeys([]string{"admin"})},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "probes the provider with a valid key",
+			diagnostics:    true,
+			opts:           []RouterOption{WithAPIKeys([]string{"admin"})},
+			apiKey:         "admin",
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			external := new(mocks.External)
+			btcUSD, _ := domain.NewPair(domain.BTCUSD)
+			external.On("GetTicker", mock.Anything, btcUSD).Return(domain.LTP{Pair: btcUSD, Amount: 52000.12}, nil).Maybe()
+			var handlerOpts []HandlerOption
+			if tt.diagnostics {
+				handlerOpts = append(handlerOpts, WithDiagnostics(external))
+			}
+			router := SetupRouter(NewHandler(new(mocks.LTPService), handlerOpts...), tt.opts...)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/diag?pair=BTC/USD", nil)
+			if tt.apiKey != "" {
+				req.Header.Set(HeaderAPIKey, tt.apiKey)
+			}
+			rec := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(rec, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus != http.StatusOK {
+				external.AssertNotCalled(t, "GetTicker", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestRouter_V2Envelope(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	router := SetupRouter(NewHandler(ltpService))
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	ltpService.On("ProviderName").Return("kraken")
+	ltpService.On("GetLTPs", mock.Anything, "BTC/USD", ports.LTPOptions{}).Return([]domain.LTP{{Pair: btcUSD, Amount: 52000.12}}, nil)
+	ltpService.On("GetLTPs", mock.Anything, "BTC/USD", ports.LTPOptions{}).Return([]domain.LTP{{Pair: btcUSD, Amount: 52000.12}}, nil)
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	// Act
+	v1 := get("/api/v1/ltp?pairs=BTC/USD")
+	v2 := get("/api/v2/ltp?pairs=BTC/USD")
+
+	// Assert
+	assert.Equal(t, http.StatusOK, v1.Code)
+	assert.JSONEq(t, `{"ltp":[{"pair":"BTC/USD","amount":52000.12}]}`, v1.Body.String())
+
+	assert.Equal(t, http.StatusOK, v2.Code)
+	var response map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(v2.Body.Bytes(), &response))
+	assert.JSONEq(t, `[{"pair":"BTC/USD","amount":52000.12}]`, string(response["data"]))
+	assert.JSONEq(t, `[]`, string(response["errors"]))
+	assert.Contains(t, string(response["meta"]), `"provider":"kraken"`)
+	ltpService.AssertExpectations(t)
+}
+
+func TestRouter_Compression(t *testing.T) {
+	newRouter := func(t *testing.T, opts ...RouterOption) (*echo.Echo, *mocks.LTPService) {
+		ltpService := new(mocks.LTPService)
+		btcUSD, _ := domain.NewPair(domain.BTCUSD)
+		ltpService.On("GetLTPs", mock.Anything, "BTC/USD", ports.LTPOptions{}).Return([]domain.LTP{{Pair: btcUSD, Amount: 52000.12}}, nil)
+		return SetupRouter(NewHandler(ltpService), opts...), ltpService
+	}
+
+	decodeLTP := func(t *testing.T, body io.Reader) dto.LTPResponse {
+		var response dto.LTPResponse
+		require.NoError(t, json.NewDecoder(body).Decode(&response))
+		return response
+	}
+
+	t.Run("brotli when accepted", func(t *testing.T) {
+		router, ltpService := newRouter(t, WithCompressionMinLength(1))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD", nil)
+		req.Header.Set("Accept-Encoding", "br")
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "br", rec.Header().Get("Content-Encoding"))
+		response := decodeLTP(t, brotli.NewReader(rec.Body))
+		assert.Len(t, response.LTP, 1)
+		ltpService.AssertExpectations(t)
+	})
+
+	t.Run("gzip when accepted", func(t *testing.T) {
+		router, ltpService := newRouter(t, WithCompressionMinLength(1))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+		reader, err := gzip.NewReader(rec.Body)
+		require.NoError(t, err)
+		response := decodeLTP(t, reader)
+		assert.Len(t, response.LTP, 1)
+		ltpService.AssertExpectations(t)
+	})
+
+	t.Run("prefers brotli over gzip", func(t *testing.T) {
+		router, _ := newRouter(t, WithCompressionMinLength(1))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD", nil)
+		req.Header.Set("Accept-Encoding", "gzip, deflate, br")
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, "br", rec.Header().Get("Content-Encoding"))
+	})
+
+	t.Run("honors q-values", func(t *testing.T) {
+		router, _ := newRouter(t, WithCompressionMinLength(1))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD", nil)
+		req.Header.Set("Accept-Encoding", "br;q=0.5, gzip;q=1.0")
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+	})
+
+	t.Run("below minimum length is not compressed", func(t *testing.T) {
+		router, _ := newRouter(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD", nil)
+		req.Header.Set("Accept-Encoding", "br, gzip")
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		response := decodeLTP(t, rec.Body)
+		assert.Len(t, response.LTP, 1)
+	})
+
+	t.Run("health is not compressed at the default threshold", func(t *testing.T) {
+		router, _ := newRouter(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		assert.JSONEq(t, `{"status":"ok"}`, rec.Body.String())
+	})
+
+	t.Run("not compressed without Accept-Encoding", func(t *testing.T) {
+		router, _ := newRouter(t, WithCompressionMinLength(1))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp?pairs=BTC/USD", nil)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		response := decodeLTP(t, rec.Body)
+		assert.Len(t, response.LTP, 1)
+	})
+}
+
+func TestRouter_JoinsIncomingTrace(t *testing.T) {
+	// Arrange
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+
+	ltpService := new(mocks.LTPService)
+	var serviceCtx context.Context
+	ltpService.On("GetLTPs", mock.Anything, "", ports.LTPOptions{}).
+		Run(func(args mock.Arguments) { serviceCtx = args.Get(0).(context.Context) }).
+		Return([]domain.LTP{}, nil)
+	router := SetupRouter(NewHandler(ltpService))
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	rec := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rec, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, traceID, spans[0].SpanContext().TraceID().String())
+	assert.Equal(t, spans[0].SpanContext().SpanID(), trace.SpanContextFromContext(serviceCtx).SpanID())
+}
@@ -0,0 +1,58 @@
+package http
+
+import (
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go-exercise/internal/adapters/http/dto"
+)
+
+// startTime approximates when the process started, to report its uptime
+var startTime = time.Now()
+
+// buildInfo holds the module version and VCS revision embedded by the Go toolchain
+var buildInfo = sync.OnceValues(func() (version, commit string) {
+	version = "(devel)"
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return version, ""
+	}
+	if info.Main.Version != "" {
+		version = info.Main.Version
+	}
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" {
+			commit = setting.Value
+		}
+	}
+	return version, commit
+})
+
+// GetStatus handles GET /api/v1/status
+// @Summary Get a status snapshot
+// @Description Report build information, process uptime, the number of cached entries, the price provider and how many upstream calls were slow in one call, for status dashboards
+// @Tags health
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.StatusResponse "Successfully retrieved the status"
+// @Failure 401 {object} dto.ErrorResponse "Missing or invalid API key, when API keys are configured"
+// @Router /api/v1/status [get]
+func (h *Handler) GetStatus(c echo.Context) error {
+	version, commit := buildInfo()
+	uptime := time.Since(startTime)
+
+	return c.JSON(http.StatusOK, dto.StatusResponse{
+		Version:       version,
+		Commit:        commit,
+		GoVersion:     runtime.Version(),
+		StartedAt:     startTime.UTC(),
+		UptimeSeconds: int64(uptime.Seconds()),
+		CacheEntries:  h.ltpService.GetCacheStats().Entries,
+		Provider:      h.ltpService.ProviderName(),
+		SlowUpstream:  h.ltpService.SlowUpstreamTotal(),
+	})
+}
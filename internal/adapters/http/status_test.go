@@ -0,0 +1,50 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+	"time"
+
+	"go-exercise/internal/adapters/http/dto"
+	"go-exercise/internal/domain"
+	"go-exercise/internal/ports/mocks"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_GetStatus(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	ltpService.On("GetCacheStats").Return(domain.CacheStats{Entries: 3, Expired: 1})
+	ltpService.On("ProviderName").Return("kraken")
+	ltpService.On("SlowUpstreamTotal").Return(int64(2))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/status", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	err := handler.GetStatus(e.NewContext(req, rec))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.StatusResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.NotEmpty(t, response.Version)
+	assert.Equal(t, runtime.Version(), response.GoVersion)
+	assert.True(t, response.StartedAt.Equal(startTime), "started at the package start time")
+	assert.InDelta(t, time.Since(startTime).Seconds(), float64(response.UptimeSeconds), 1)
+	assert.Equal(t, 3, response.CacheEntries)
+	assert.Equal(t, "kraken", response.Provider)
+	assert.Equal(t, int64(2), response.SlowUpstream)
+	ltpService.AssertExpectations(t)
+}
@@ -0,0 +1,94 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go-exercise/internal/adapters/http/dto"
+	"go-exercise/internal/ports"
+)
+
+// StreamLTP handles GET /api/v1/ltp/events
+// @Summary Stream Last Traded Prices
+// @Description Server-Sent Events stream pushing the latest LTPs for the requested pairs at a fixed interval. Each "ltp" event's data is an LTPResponse JSON document; an "end" event is sent when the server shuts down.
+// @Tags ltp
+// @Produce text/event-stream
+// @Security ApiKeyAuth
+// @Param pairs query string false "Currency pairs (comma-separated, e.g., BTC/USD,BTC/EUR)"
+// @Success 200 {object} dto.LTPResponse "Stream of LTP events"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request parameters"
+// @Failure 401 {object} dto.ErrorResponse "Missing or invalid API key, when API keys are configured"
+// @Failure 422 {object} dto.ErrorResponse "Pair not supported by any provider"
+// @Failure 502 {object} dto.ErrorResponse "Upstream price provider unavailable"
+// @Failure 503 {object} dto.ErrorResponse "Upstream price provider rate limited; see the Retry-After header"
+// @Router /api/v1/ltp/events [get]
+func (h *Handler) StreamLTP(c echo.Context) error {
+	pairsStr := c.QueryParam("pairs")
+
+	// Fetch once before opening the stream so bad requests get a regular error response
+	ltps, err := h.ltpService.GetLTPs(c.Request().Context(), pairsStr, ports.LTPOptions{})
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.WriteHeader(http.StatusOK)
+
+	ctx := c.Request().Context()
+	ticker := time.NewTicker(h.eventsInterval)
+	defer ticker.Stop()
+
+	for {
+		// Upstream hiccups are reported as error events without ending the stream
+		var writeErr error
+		if err != nil {
+			writeErr = writeEvent(res, "error", errorResponse(err))
+		} else {
+			writeErr = writeEvent(res, "ltp", dto.LTPResponse{LTP: toLTPItems(ltps, ltpFields{}, keepPrecision)})
+		}
+		if writeErr != nil {
+			return nil
+		}
+		res.Flush()
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-h.closing:
+			// Tell the client the stream ended on purpose rather than dropping the connection
+			if writeEvent(res, "end", struct{}{}) == nil {
+				res.Flush()
+			}
+			return nil
+		case <-ticker.C:
+		}
+
+		ltps, err = h.ltpService.GetLTPs(ctx, pairsStr, ports.LTPOptions{})
+	}
+}
+
+// Shutdown ends all open event streams with an "end" event. Streams opened afterwards end
+// right after their first event. Call it before shutting down the server so streaming
+// clients see a clean end of stream instead of a dropped connection.
+func (h *Handler) Shutdown() {
+	h.closeOnce.Do(func() {
+		close(h.closing)
+	})
+}
+
+// writeEvent writes a named Server-Sent Event with a JSON payload
+func writeEvent(w io.Writer, event string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+	return err
+}
@@ -0,0 +1,168 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go-exercise/internal/adapters/http/dto"
+	"go-exercise/internal/domain"
+	"go-exercise/internal/ports"
+	"go-exercise/internal/ports/mocks"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// readEvent reads a single Server-Sent Event and returns its name and data
+func readEvent(t *testing.T, reader *bufio.Reader) (string, string) {
+	t.Helper()
+
+	var event, data string
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimRight(line, "\n")
+		if line == "" {
+			return event, data
+		}
+		if v, ok := strings.CutPrefix(line, "event: "); ok {
+			event = v
+		}
+		if v, ok := strings.CutPrefix(line, "data: "); ok {
+			data = v
+		}
+	}
+}
+
+func TestHandler_StreamLTP_PushesEvents(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService, WithEventsInterval(10*time.Millisecond))
+	server := httptest.NewServer(SetupRouter(handler))
+	defer server.Close()
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	ltpService.On("GetLTPs", mock.Anything, "BTC/USD", ports.LTPOptions{}).Return([]domain.LTP{
+		{Pair: btcUSD, Amount: 52000.12},
+	}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/v1/ltp/events?pairs=BTC/USD", nil)
+	require.NoError(t, err)
+
+	// Act
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	// Assert
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	for i := 0; i < 2; i++ {
+		event, data := readEvent(t, reader)
+		assert.Equal(t, "ltp", event)
+
+		var response dto.LTPResponse
+		require.NoError(t, json.Unmarshal([]byte(data), &response))
+		require.Len(t, response.LTP, 1)
+		assert.Equal(t, "BTC/USD", response.LTP[0].Pair)
+		assert.Equal(t, json.Number("52000.12"), response.LTP[0].Amount)
+	}
+}
+
+func TestHandler_StreamLTP_StopsOnClientDisconnect(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService, WithEventsInterval(time.Hour))
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	ltpService.On("GetLTPs", mock.Anything, "", ports.LTPOptions{}).Return([]domain.LTP{
+		{Pair: btcUSD, Amount: 52000.12},
+	}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp/events", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	done := make(chan error, 1)
+	go func() {
+		done <- handler.StreamLTP(c)
+	}()
+	cancel()
+
+	// Assert
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("stream did not end after client disconnect")
+	}
+	assert.Contains(t, rec.Body.String(), "event: ltp")
+}
+
+func TestHandler_StreamLTP_EndsOnShutdown(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService, WithEventsInterval(time.Hour))
+	server := httptest.NewServer(SetupRouter(handler))
+	defer server.Close()
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	ltpService.On("GetLTPs", mock.Anything, "BTC/USD", ports.LTPOptions{}).Return([]domain.LTP{
+		{Pair: btcUSD, Amount: 52000.12},
+	}, nil)
+
+	resp, err := http.Get(server.URL + "/api/v1/ltp/events?pairs=BTC/USD")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	reader := bufio.NewReader(resp.Body)
+	event, _ := readEvent(t, reader)
+	require.Equal(t, "ltp", event)
+
+	// Act
+	handler.Shutdown()
+	handler.Shutdown() // idempotent
+
+	// Assert
+	event, _ = readEvent(t, reader)
+	assert.Equal(t, "end", event)
+	_, err = reader.ReadByte()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestHandler_StreamLTP_InvalidPair_ReturnsBadRequest(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	ltpService.On("GetLTPs", mock.Anything, "BTC/INVALID", ports.LTPOptions{}).Return(nil, domain.ErrInvalidPair)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp/events?pairs=BTC/INVALID", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.StreamLTP(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, echo.MIMEApplicationJSON, rec.Header().Get("Content-Type"))
+}
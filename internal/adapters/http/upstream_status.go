@@ -0,0 +1,28 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go-exercise/internal/adapters/http/dto"
+)
+
+// GetUpstreamStatus handles GET /api/v1/status/upstream
+// @Summary Get per-pair upstream errors
+// @Description Report the last error of each pair whose latest fetch from the price provider failed, to debug persistent failures of a specific pair. A pair is listed until a fetch of it succeeds.
+// @Tags health
+// @Produce json
+// @Success 200 {object} dto.UpstreamStatusResponse "Successfully retrieved the upstream errors"
+// @Router /api/v1/status/upstream [get]
+func (h *Handler) GetUpstreamStatus(c echo.Context) error {
+	pairErrs := h.ltpService.UpstreamErrors()
+	response := dto.UpstreamStatusResponse{Errors: make([]dto.PairErrorItem, len(pairErrs))}
+	for i, pairErr := range pairErrs {
+		response.Errors[i] = dto.PairErrorItem{
+			Pair:  pairErr.Pair,
+			Error: pairErr.Error,
+			At:    pairErr.At.UTC(),
+		}
+	}
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,61 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-exercise/internal/domain"
+	"go-exercise/internal/ports/mocks"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_GetUpstreamStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		errors   []domain.PairError
+		expected string
+	}{
+		{
+			name: "failing pairs",
+			errors: []domain.PairError{
+				{Pair: domain.BTCEUR, Error: "timeout", At: time.Date(2024, 1, 1, 13, 0, 0, 0, time.FixedZone("CET", 3600))},
+				{Pair: domain.BTCUSD, Error: "no data found for symbol BTC/USD", At: time.Date(2024, 1, 1, 12, 0, 30, 0, time.UTC)},
+			},
+			expected: `{"errors":[
+				{"pair":"BTC/EUR","error":"timeout","at":"2024-01-01T12:00:00Z"},
+				{"pair":"BTC/USD","error":"no data found for symbol BTC/USD","at":"2024-01-01T12:00:30Z"}
+			]}`,
+		},
+		{
+			name:     "no failing pairs",
+			errors:   []domain.PairError{},
+			expected: `{"errors":[]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ltpService := new(mocks.LTPService)
+			handler := NewHandler(ltpService)
+			ltpService.On("UpstreamErrors").Return(tt.errors)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/status/upstream", nil)
+			rec := httptest.NewRecorder()
+
+			// Act
+			err := handler.GetUpstreamStatus(echo.New().NewContext(req, rec))
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.JSONEq(t, tt.expected, rec.Body.String())
+			ltpService.AssertExpectations(t)
+		})
+	}
+}
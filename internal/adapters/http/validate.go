@@ -0,0 +1,43 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go-exercise/internal/adapters/http/dto"
+)
+
+// ValidatePairs handles GET /api/v1/ltp/validate
+// @Summary Validate a pairs query
+// @Description Report which entries of a pairs string are valid, without fetching any price. Invalid entries are listed in the response rather than failing the request. If no pairs are specified, the default pairs are validated.
+// @Tags ltp
+// @Produce json
+// @Security ApiKeyAuth
+// @Param pairs query string false "Currency pairs (comma-separated, e.g., BTC/USD,BTC/EUR)"
+// @Success 200 {object} dto.ValidatePairsResponse "Validation result"
+// @Failure 400 {object} dto.ErrorResponse "Too many pairs requested"
+// @Failure 401 {object} dto.ErrorResponse "Missing or invalid API key, when API keys are configured"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /api/v1/ltp/validate [get]
+func (h *Handler) ValidatePairs(c echo.Context) error {
+	validation, err := h.ltpService.ValidatePairs(c.QueryParam("pairs"))
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	response := dto.ValidatePairsResponse{
+		Valid:   make([]string, len(validation.Valid)),
+		Invalid: make([]dto.InvalidPairItem, len(validation.Invalid)),
+	}
+	for i, pair := range validation.Valid {
+		response.Valid[i] = pair.Value()
+	}
+	for i, invalid := range validation.Invalid {
+		response.Invalid[i] = dto.InvalidPairItem{
+			Pair:  invalid.Value,
+			Code:  errorCode(invalid.Err),
+			Error: invalid.Err.Error(),
+		}
+	}
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,93 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-exercise/internal/domain"
+	"go-exercise/internal/ports/mocks"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_ValidatePairs_ListsValidAndInvalid(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	ltpService.On("ValidatePairs", "btc/usd,BTC/INVALID,BTC/EUR").Return(domain.PairValidation{
+		Valid: []domain.Pair{btcUSD},
+		Invalid: []domain.InvalidPair{
+			{Value: "BTC/INVALID", Err: fmt.Errorf("%w: BTC/INVALID", domain.ErrInvalidPair)},
+			{Value: "BTC/EUR", Err: fmt.Errorf("%w: BTC/EUR", domain.ErrUnsupportedPair)},
+		},
+	}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp/validate?pairs=btc/usd,BTC/INVALID,BTC/EUR", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.ValidatePairs(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{
+		"valid": ["BTC/USD"],
+		"invalid": [
+			{"pair": "BTC/INVALID", "code": "INVALID_PAIR", "error": "invalid pair: BTC/INVALID"},
+			{"pair": "BTC/EUR", "code": "UNSUPPORTED_PAIR", "error": "pair not supported by any provider: BTC/EUR"}
+		]
+	}`, rec.Body.String())
+	ltpService.AssertExpectations(t)
+}
+
+func TestHandler_ValidatePairs_AllValid(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	ltpService.On("ValidatePairs", "").Return(domain.PairValidation{Valid: []domain.Pair{btcUSD, btcEUR}}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp/validate", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	err := handler.ValidatePairs(e.NewContext(req, rec))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"valid":["BTC/USD","BTC/EUR"],"invalid":[]}`, rec.Body.String())
+	ltpService.AssertExpectations(t)
+}
+
+func TestHandler_ValidatePairs_TooManyPairs(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	handler := NewHandler(ltpService)
+	ltpService.On("ValidatePairs", "BTC/USD,BTC/EUR").Return(domain.PairValidation{}, fmt.Errorf("invalid pairs: %w", domain.ErrTooManyPairs))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ltp/validate?pairs=BTC/USD,BTC/EUR", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	err := handler.ValidatePairs(e.NewContext(req, rec))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"code":"TOO_MANY_PAIRS"`)
+	ltpService.AssertExpectations(t)
+}
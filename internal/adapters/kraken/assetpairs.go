@@ -0,0 +1,147 @@
+package kraken
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go-exercise/internal/domain"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// KrakenAssetPairsResponse represents the response from Kraken AssetPairs API
+type KrakenAssetPairsResponse struct {
+	Error  []string                   `json:"error"`
+	Result map[string]KrakenAssetPair `json:"result"`
+}
+
+// KrakenAssetPair describes a tradable pair. Results are keyed by the pair's canonical
+// Kraken name (e.g. XXBTZUSD), which is also the key of Ticker and OHLC results.
+type KrakenAssetPair struct {
+	Altname string `json:"altname"` // Request symbol, e.g. XBTUSD
+	WSName  string `json:"wsname"`  // Slash-separated name, e.g. XBT/USD
+}
+
+// canonicalAssets maps Kraken asset codes to the codes used in domain pairs
+var canonicalAssets = map[string]string{
+	"XBT": "BTC",
+	"XDG": "DOGE",
+}
+
+// canonicalPairValue converts a Kraken slash-separated pair name to a domain pair value
+func canonicalPairValue(wsname string) (string, bool) {
+	base, quote, ok := strings.Cut(strings.ToUpper(wsname), "/")
+	if !ok || base == "" || quote == "" {
+		return "", false
+	}
+	if code, ok := canonicalAssets[base]; ok {
+		base = code
+	}
+	if code, ok := canonicalAssets[quote]; ok {
+		quote = code
+	}
+	return base + "/" + quote, true
+}
+
+// LoadAssetPairs fetches Kraken's tradable pairs and replaces the symbols of valid domain
+// pairs with the ones Kraken reports, so requests and response matching no longer rely on
+// guessing symbol variants. Pairs Kraken does not list keep their configured symbol and the
+// heuristics. It must be called at startup, before the client is used concurrently; on
+// error the client is left unchanged.
+func (k *KrakenClient) LoadAssetPairs(ctx context.Context) (err error) {
+	url := k.baseURL() + "/AssetPairs"
+
+	ctx, span := tracer.Start(ctx, "kraken.LoadAssetPairs", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("url.full", url),
+	))
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	req, err := k.newRequest(ctx, url)
+	if err != nil {
+		return fmt.Errorf("failed to build Kraken request: %w", err)
+	}
+
+	resp, err := k.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call Kraken API: %w", err)
+	}
+	defer resp.Body.Close()
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return &domain.RateLimitError{RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("kraken API returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var pairsResp KrakenAssetPairsResponse
+	if err := json.Unmarshal(body, &pairsResp); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if errs, _ := splitMessages(pairsResp.Error); len(errs) > 0 {
+		return fmt.Errorf("kraken API error: %v", errs)
+	}
+	if len(pairsResp.Result) == 0 {
+		return fmt.Errorf("%w for asset pairs", ErrUpstreamEmptyResult)
+	}
+
+	symbols := make(map[string]string, len(k.symbols))
+	for value, symbol := range k.symbols {
+		symbols[value] = symbol
+	}
+	resultPairs := make(map[string]string)
+	for key, assetPair := range pairsResp.Result {
+		// Dark pool variants (e.g. XXBTZUSD.d) share the wsname of the lit pair
+		if strings.HasSuffix(key, ".d") || assetPair.Altname == "" {
+			continue
+		}
+		value, ok := canonicalPairValue(assetPair.WSName)
+		if !ok || !domain.IsValidPair(value) {
+			continue
+		}
+		symbols[value] = assetPair.Altname
+		resultPairs[key] = value
+		resultPairs[assetPair.Altname] = value
+	}
+
+	k.symbols = symbols
+	k.resultPairs = resultPairs
+	span.SetAttributes(attribute.Int("kraken.asset_pairs", len(pairsResp.Result)))
+	return nil
+}
+
+// findPairInResult looks up the result entry of a pair, first under its overridden symbol,
+// then through the symbols learned from AssetPairs and then through the symbol variant
+// heuristics
+func findPairInResult[T any](k *KrakenClient, result map[string]T, pair domain.Pair) (T, string, bool) {
+	if symbol, ok := k.overrides[pair.Value()]; ok {
+		if data, ok := result[symbol]; ok {
+			return data, symbol, true
+		}
+	}
+	for symbol, data := range result {
+		if k.resultPairs[symbol] == pair.Value() {
+			return data, symbol, true
+		}
+	}
+	return findKrakenSymbolInResult(result, k.pairToKrakenSymbol(pair))
+}
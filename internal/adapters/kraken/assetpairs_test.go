@@ -0,0 +1,179 @@
+package kraken
+
+import (
+	"context"
+	"testing"
+
+	"go-exercise/internal/domain"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const assetPairsBody = `{"error":[],"result":{
+	"XXBTZUSD":{"altname":"XBTUSD","wsname":"XBT/USD","base":"XXBT","quote":"ZUSD"},
+	"XXBTZUSD.d":{"altname":"XBTUSD.d","base":"XXBT","quote":"ZUSD"},
+	"XBTCHF":{"altname":"XBTCHF","wsname":"XBT/CHF","base":"XXBT","quote":"CHF"},
+	"BTCEUR_SPOT":{"altname":"XBTEURS","wsname":"XBT/EUR","base":"XXBT","quote":"ZEUR"},
+	"XETHZUSD":{"altname":"ETHUSD","wsname":"ETH/USD","base":"XETH","quote":"ZUSD"}
+}}`
+
+func TestKrakenClient_LoadAssetPairs_BuildsSymbolMappings(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.kraken.com").
+		Get("/0/public/AssetPairs").
+		Reply(200).
+		BodyString(assetPairsBody)
+
+	client := NewKrakenClient("", WithSymbols(map[string]string{domain.BTCUSD: "BTCUSD"})).(*KrakenClient)
+
+	err := client.LoadAssetPairs(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		domain.BTCUSD: "XBTUSD",
+		domain.BTCCHF: "XBTCHF",
+		domain.BTCEUR: "XBTEURS",
+	}, client.symbols, "ETH/USD is not a valid pair and dark pool variants are skipped")
+	assert.Equal(t, map[string]string{
+		"XXBTZUSD":    domain.BTCUSD,
+		"XBTUSD":      domain.BTCUSD,
+		"XBTCHF":      domain.BTCCHF,
+		"BTCEUR_SPOT": domain.BTCEUR,
+		"XBTEURS":     domain.BTCEUR,
+	}, client.resultPairs)
+	assert.True(t, gock.IsDone())
+}
+
+func TestKrakenClient_LoadAssetPairs_MatchesResultsWithoutGuessing(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.kraken.com").
+		Get("/0/public/AssetPairs").
+		Reply(200).
+		BodyString(assetPairsBody)
+	// Neither result key is a variant the heuristics would try
+	gock.New("https://api.kraken.com").
+		Get("/0/public/Ticker").
+		MatchParam("pair", "XBTEURS,XBTUSD").
+		Reply(200).
+		BodyString(`{"error":[],"result":{
+			"BTCEUR_SPOT":{"c":["50000.10000","0.1"]},
+			"XXBTZUSD":{"c":["52000.10000","0.1"]}
+		}}`)
+
+	client := NewKrakenClient("").(*KrakenClient)
+	require.NoError(t, client.LoadAssetPairs(context.Background()))
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+
+	ltps, err := client.GetTickers(context.Background(), []domain.Pair{btcEUR, btcUSD})
+
+	require.NoError(t, err)
+	require.Len(t, ltps, 2)
+	assert.Equal(t, "50000.10000", ltps[0].RawAmount)
+	assert.Equal(t, "52000.10000", ltps[1].RawAmount)
+	assert.True(t, gock.IsDone())
+}
+
+func TestKrakenClient_LoadAssetPairs_KeepsSymbolOverrides(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.kraken.com").
+		Get("/0/public/AssetPairs").
+		Reply(200).
+		BodyString(assetPairsBody)
+	gock.New("https://api.kraken.com").
+		Get("/0/public/Ticker").
+		MatchParam("pair", "XXBTZEUR").
+		Reply(200).
+		BodyString(`{"error":[],"result":{
+			"BTCEUR_SPOT":{"c":["49000.10000","0.1"]},
+			"XXBTZEUR":{"c":["50000.10000","0.1"]}
+		}}`)
+
+	client := NewKrakenClient("", WithSymbolOverrides(map[string]string{
+		domain.BTCEUR: "XXBTZEUR",
+	})).(*KrakenClient)
+	require.NoError(t, client.LoadAssetPairs(context.Background()))
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+
+	ltps, err := client.GetTickers(context.Background(), []domain.Pair{btcEUR})
+
+	require.NoError(t, err)
+	require.Len(t, ltps, 1)
+	assert.Equal(t, "50000.10000", ltps[0].RawAmount, "the override wins over the learned result key")
+	assert.True(t, gock.IsDone())
+}
+
+func TestKrakenClient_LoadAssetPairs_FailureKeepsConfiguredSymbols(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		expectedErr string
+	}{
+		{"http error", 500, `{}`, "status 500"},
+		{"api error", 200, `{"error":["EGeneral:Internal error"]}`, "kraken API error"},
+		{"empty result", 200, `{"error":[],"result":{}}`, "null result"},
+		{"malformed body", 200, `not json`, "failed to unmarshal"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+
+			gock.New("https://api.kraken.com").
+				Get("/0/public/AssetPairs").
+				Reply(tt.status).
+				BodyString(tt.body)
+			// The heuristics still resolve the legacy result key
+			gock.New("https://api.kraken.com").
+				Get("/0/public/Ticker").
+				MatchParam("pair", "XBTUSD").
+				Reply(200).
+				BodyString(`{"error":[],"result":{"XXBTZUSD":{"c":["52000.10000","0.1"]}}}`)
+
+			client := NewKrakenClient("").(*KrakenClient)
+			btcUSD, _ := domain.NewPair(domain.BTCUSD)
+
+			err := client.LoadAssetPairs(context.Background())
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedErr)
+			assert.Equal(t, krakenSymbols, client.symbols)
+			assert.Nil(t, client.resultPairs)
+
+			ltp, err := client.GetTicker(context.Background(), btcUSD)
+			require.NoError(t, err)
+			assert.Equal(t, 52000.1, ltp.Amount)
+			assert.True(t, gock.IsDone())
+		})
+	}
+}
+
+func TestCanonicalPairValue(t *testing.T) {
+	tests := []struct {
+		wsname   string
+		expected string
+		ok       bool
+	}{
+		{"XBT/USD", domain.BTCUSD, true},
+		{"xbt/eur", domain.BTCEUR, true},
+		{"XDG/USD", "DOGE/USD", true},
+		{"ETH/XBT", "ETH/BTC", true},
+		{"XBTUSD", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.wsname, func(t *testing.T) {
+			value, ok := canonicalPairValue(tt.wsname)
+
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
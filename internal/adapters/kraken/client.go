@@ -1,9 +1,13 @@
 package kraken
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log"
+	"math"
 	"net/http"
 	"strconv"
 	"strings"
@@ -11,12 +15,71 @@ import (
 
 	"go-exercise/internal/domain"
 	"go-exercise/internal/ports"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// tracer creates the outbound call spans; it is a no-op unless a tracer provider is installed
+var tracer = otel.Tracer("go-exercise/internal/adapters/kraken")
+
+// ErrUpstreamEmptyResult is returned when Kraken responds without errors but with a null result
+var ErrUpstreamEmptyResult = errors.New("kraken API returned a null result")
+
+// ErrInvalidPrice is returned when Kraken reports a last trade price that is not a finite,
+// non-negative number, e.g. NaN, which could not even be serialized as JSON
+var ErrInvalidPrice = errors.New("invalid price")
+
+// ErrNoData is returned when a successful Kraken response holds no entry for a requested
+// pair. A null or empty result is an upstream data problem affecting every requested pair;
+// a result merely missing some pair reports it as a *NoDataError, so the two can be told
+// apart with errors.As.
+var ErrNoData = errors.New("no data")
+
+// NoDataError carries the pair, and the symbol it was requested as, along with ErrNoData
+type NoDataError struct {
+	Pair   domain.Pair
+	Symbol string
+}
+
+func (e *NoDataError) Error() string {
+	return fmt.Sprintf("%s for pair %s (tried %s and variants)", ErrNoData, e.Pair.Value(), e.Symbol)
+}
+
+// Is makes errors.Is(err, ErrNoData) match a NoDataError
+func (e *NoDataError) Is(target error) bool {
+	return target == ErrNoData
+}
+
 // KrakenClient implements the External port for Kraken API
 type KrakenClient struct {
-	baseURL    string
+	// host is the scheme and host, plus any proxy path prefix, of the API
+	host string
+	// apiPath is the version path appended to host, e.g. /0/public
+	apiPath    string
 	httpClient *http.Client
+	// symbols maps domain pair values to Kraken request symbols
+	symbols map[string]string
+	// overrides maps domain pair values to symbols set by operators. They take precedence
+	// over symbols, the ones learned by LoadAssetPairs and the result matching heuristics.
+	overrides map[string]string
+	// resultPairs maps the symbols Kraken returns in results to domain pair values, as
+	// learned by LoadAssetPairs; nil leaves response matching to the heuristics
+	resultPairs map[string]string
+	// batchSize is the most pairs requested in one ticker call; zero means no limit
+	batchSize int
+	// partialBatches returns the pairs of successful batches when others fail, instead
+	// of failing the whole call
+	partialBatches bool
+	// upstreamTimeout caps each ticker and OHLC call regardless of the caller's deadline;
+	// zero leaves only the caller's deadline and the HTTP client timeout
+	upstreamTimeout time.Duration
+	// userAgent identifies the client to Kraken and any proxies in between
+	userAgent string
+	// headers are added to every request, e.g. an API gateway token
+	headers http.Header
 }
 
 // KrakenTickerResponse represents the response from Kraken API
@@ -27,49 +90,246 @@ type KrakenTickerResponse struct {
 
 // KrakenTickerData represents ticker data for a pair
 type KrakenTickerData struct {
-	C []string `json:"c"` // c[0] = last trade closed price
+	A []KrakenPrice `json:"a"` // a[0] = best ask price
+	B []KrakenPrice `json:"b"` // b[0] = best bid price
+	C []KrakenPrice `json:"c"` // c[0] = last trade closed price
 }
 
-// NewKrakenClient creates a new Kraken client
-func NewKrakenClient(baseURL string) ports.External {
-	if baseURL == "" {
-		baseURL = "https://api.kraken.com/0/public"
+// KrakenPrice is a decimal value as Kraken reports it. Kraken sends decimal strings, but a
+// JSON number is accepted too, e.g. from a proxy re-encoding the response; either way the
+// value keeps its literal digits.
+type KrakenPrice string
+
+// UnmarshalJSON accepts both a JSON string and a JSON number. Strings are kept as is and
+// validated when parsed, like before.
+func (p *KrakenPrice) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err == nil {
+		*p = KrakenPrice(value)
+		return nil
 	}
-	return &KrakenClient{
-		baseURL: baseURL,
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return fmt.Errorf("price must be a decimal string or number, got %s", data)
+	}
+	*p = KrakenPrice(number)
+	return nil
+}
+
+// DefaultTimeout is the HTTP client timeout used unless configured otherwise
+const DefaultTimeout = 10 * time.Second
+
+const (
+	// DefaultHost is the Kraken API host used unless configured otherwise
+	DefaultHost = "https://api.kraken.com"
+	// DefaultAPIVersion is the public API version path used unless configured otherwise
+	DefaultAPIVersion = "/0/public"
+)
+
+// DefaultUserAgent is the User-Agent sent unless configured otherwise
+const DefaultUserAgent = "go-exercise/1.0"
+
+// Option configures optional KrakenClient behavior
+type Option func(*KrakenClient)
+
+// WithTimeout sets the timeout of the underlying HTTP client
+func WithTimeout(timeout time.Duration) Option {
+	return func(k *KrakenClient) {
+		k.httpClient.Timeout = timeout
+	}
+}
+
+// WithUpstreamTimeout caps each ticker and OHLC call at the given duration. The cap is
+// derived from the incoming context, so an earlier caller deadline still applies.
+func WithUpstreamTimeout(timeout time.Duration) Option {
+	return func(k *KrakenClient) {
+		k.upstreamTimeout = timeout
+	}
+}
+
+// WithHTTPClient replaces the underlying HTTP client entirely.
+// Options applied after it (e.g. WithTimeout) modify the given client.
+func WithHTTPClient(client *http.Client) Option {
+	return func(k *KrakenClient) {
+		k.httpClient = client
+	}
+}
+
+// WithTransport sets the transport of the underlying HTTP client, e.g. a tuned
+// *http.Transport with custom connection pooling
+func WithTransport(transport http.RoundTripper) Option {
+	return func(k *KrakenClient) {
+		k.httpClient.Transport = transport
+	}
+}
+
+// WithBaseURL sets the scheme and host of the API, e.g. a proxy such as
+// "http://proxy.internal/kraken". The API version path is appended to it.
+func WithBaseURL(host string) Option {
+	return func(k *KrakenClient) {
+		k.host = strings.TrimRight(host, "/")
+	}
+}
+
+// WithAPIVersion sets the version path appended to the host, e.g. "/0/public"
+func WithAPIVersion(path string) Option {
+	return func(k *KrakenClient) {
+		k.apiPath = normalizeAPIPath(path)
+	}
+}
+
+// normalizeAPIPath gives a non-empty path a single leading slash and no trailing one
+func normalizeAPIPath(path string) string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return ""
+	}
+	return "/" + path
+}
+
+// WithSymbols replaces the compiled-in pair-to-symbol mapping, keyed by pair value
+// (e.g. "BTC/USD": "XBTUSD"). Only mapped pairs are reported as supported.
+func WithSymbols(symbols map[string]string) Option {
+	return func(k *KrakenClient) {
+		k.symbols = symbols
+	}
+}
+
+// WithSymbolOverrides sets symbols, keyed by pair value (e.g. "BTC/USD": "XXBTZUSD"), that
+// are used to request a pair and are looked up first in responses, ahead of any other
+// mapping or heuristic. An overridden pair is supported even when no other mapping has it.
+func WithSymbolOverrides(overrides map[string]string) Option {
+	return func(k *KrakenClient) {
+		k.overrides = overrides
+	}
+}
+
+// WithBatchSize requests at most size pairs per ticker call, splitting larger requests
+// into several calls. A non-positive size requests every pair in a single call.
+func WithBatchSize(size int) Option {
+	return func(k *KrakenClient) {
+		k.batchSize = size
+	}
+}
+
+// WithPartialBatches makes a batched ticker call return the prices of the batches that
+// succeeded along with a domain.PartialFetchError naming the pairs of the failed ones.
+// It only fails outright when every batch fails.
+func WithPartialBatches() Option {
+	return func(k *KrakenClient) {
+		k.partialBatches = true
+	}
+}
+
+// WithUserAgent sets the User-Agent sent with every request; an empty one keeps
+// DefaultUserAgent
+func WithUserAgent(userAgent string) Option {
+	return func(k *KrakenClient) {
+		if userAgent != "" {
+			k.userAgent = userAgent
+		}
+	}
+}
+
+// WithHeader adds a header sent with every request, e.g. a token required by an API
+// gateway in front of Kraken. Repeating a name adds another value; a User-Agent given
+// this way replaces the one set by WithUserAgent.
+func WithHeader(name, value string) Option {
+	return func(k *KrakenClient) {
+		k.headers.Add(name, value)
+	}
+}
+
+// NewKrakenClient creates a new Kraken client. A non-empty baseURL is the full prefix of
+// the endpoints, version path included (e.g. "https://api.kraken.com/0/public"); an empty
+// one means DefaultHost and DefaultAPIVersion. WithBaseURL and WithAPIVersion override
+// the host and version separately.
+func NewKrakenClient(baseURL string, opts ...Option) ports.External {
+	host, apiPath := DefaultHost, DefaultAPIVersion
+	if baseURL != "" {
+		host, apiPath = strings.TrimRight(baseURL, "/"), ""
+	}
+	k := &KrakenClient{
+		host:    host,
+		apiPath: apiPath,
 		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
+			Timeout: DefaultTimeout,
 		},
+		symbols:   krakenSymbols,
+		userAgent: DefaultUserAgent,
+		headers:   make(http.Header),
 	}
+	for _, opt := range opts {
+		opt(k)
+	}
+	return k
 }
 
-// pairToKrakenSymbol converts domain pair to Kraken symbol for API request
-func pairToKrakenSymbol(pair domain.Pair) string {
-	mapping := map[string]string{
-		domain.BTCUSD: "XBTUSD",
-		domain.BTCCHF: "XBTCHF",
-		domain.BTCEUR: "XBTEUR",
+// newRequest builds a GET request to url carrying the User-Agent and default headers
+func (k *KrakenClient) newRequest(ctx context.Context, url string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", k.userAgent)
+	for name, values := range k.headers {
+		req.Header[name] = append([]string(nil), values...)
 	}
-	if symbol, ok := mapping[pair.Value()]; ok {
+	return req, nil
+}
+
+// withUpstreamTimeout derives the context of a single upstream call from the caller's
+func (k *KrakenClient) withUpstreamTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if k.upstreamTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, k.upstreamTimeout)
+}
+
+// baseURL returns the prefix of the API endpoints: the host followed by the version path
+func (k *KrakenClient) baseURL() string {
+	return k.host + k.apiPath
+}
+
+// krakenSymbols maps domain pairs to the Kraken symbols used in API requests
+var krakenSymbols = map[string]string{
+	domain.BTCUSD: "XBTUSD",
+	domain.BTCCHF: "XBTCHF",
+	domain.BTCEUR: "XBTEUR",
+}
+
+// pairToKrakenSymbol converts domain pair to Kraken symbol for API request
+func (k *KrakenClient) pairToKrakenSymbol(pair domain.Pair) string {
+	if symbol, ok := k.Symbol(pair); ok {
 		return symbol
 	}
 	return pair.Value()
 }
 
+// Symbol returns the Kraken symbol configured for the pair
+func (k *KrakenClient) Symbol(pair domain.Pair) (string, bool) {
+	if symbol, ok := k.overrides[pair.Value()]; ok {
+		return symbol, true
+	}
+	symbol, ok := k.symbols[pair.Value()]
+	return symbol, ok
+}
+
 // findKrakenSymbolInResult searches for a symbol in the result map
 // Kraken sometimes returns symbols with different formats (e.g., "XXBTZUSD" instead of "XBTUSD")
-func findKrakenSymbolInResult(result map[string]KrakenTickerData, requestedSymbol string) (KrakenTickerData, string, bool) {
+func findKrakenSymbolInResult[T any](result map[string]T, requestedSymbol string) (T, string, bool) {
 	// Try exact match first
 	if tickerData, ok := result[requestedSymbol]; ok {
 		return tickerData, requestedSymbol, true
 	}
 
-	// Common variants for XBT symbols
-	if len(requestedSymbol) >= 3 && requestedSymbol[:3] == "XBT" {
-		suffix := requestedSymbol[3:]
+	// Common variants using Kraken's legacy X (crypto) / Z (fiat) asset prefixes,
+	// so configured pairs such as ETHUSD resolve too
+	if len(requestedSymbol) == 6 {
+		base, suffix := requestedSymbol[:3], requestedSymbol[3:]
 		variants := []string{
-			"XXBTZ" + suffix, // Most common: XBTUSD -> XXBTZUSD
-			"XXBT" + suffix,
+			"X" + base + "Z" + suffix, // Most common: XBTUSD -> XXBTZUSD
+			"X" + base + suffix,
 		}
 		for _, variant := range variants {
 			if tickerData, ok := result[variant]; ok {
@@ -78,50 +338,160 @@ func findKrakenSymbolInResult(result map[string]KrakenTickerData, requestedSymbo
 		}
 	}
 
-	// If only one result exists, use it
+	// If only one result exists, use it, but only when it plausibly names the requested
+	// pair; otherwise we would attribute another pair's price to it
 	if len(result) == 1 {
 		for symbol, tickerData := range result {
-			return tickerData, symbol, true
+			if symbolMatches(symbol, requestedSymbol) {
+				return tickerData, symbol, true
+			}
+		}
+	}
+
+	var zero T
+	return zero, "", false
+}
+
+// krakenAssetAliases lists alternative codes Kraken uses for the same asset
+var krakenAssetAliases = map[string][]string{
+	"XBT": {"BTC"},
+	"BTC": {"XBT"},
+	"XDG": {"DOGE"},
+}
+
+// symbolMatches reports whether a returned symbol ends with the requested symbol's
+// quote currency and contains its base currency or a known alias of it
+func symbolMatches(symbol, requestedSymbol string) bool {
+	if len(requestedSymbol) != 6 {
+		return false
+	}
+	base, quote := requestedSymbol[:3], requestedSymbol[3:]
+	if !strings.HasSuffix(symbol, quote) {
+		return false
+	}
+	rest := strings.TrimSuffix(symbol, quote)
+	for _, candidate := range append([]string{base}, krakenAssetAliases[base]...) {
+		if strings.Contains(rest, candidate) {
+			return true
 		}
 	}
+	return false
+}
 
-	return KrakenTickerData{}, "", false
+// SupportedPairs returns the pairs that have a known Kraken symbol
+func (k *KrakenClient) SupportedPairs() []domain.Pair {
+	pairs := make([]domain.Pair, 0, len(k.symbols)+len(k.overrides))
+	for value := range k.symbols {
+		if pair, err := domain.NewPair(value); err == nil {
+			pairs = append(pairs, pair)
+		}
+	}
+	for value := range k.overrides {
+		if _, ok := k.symbols[value]; ok {
+			continue
+		}
+		if pair, err := domain.NewPair(value); err == nil {
+			pairs = append(pairs, pair)
+		}
+	}
+	return pairs
 }
 
 // GetTicker retrieves ticker information for a single pair
-func (k *KrakenClient) GetTicker(pair domain.Pair) (domain.LTP, error) {
-	ltps, err := k.GetTickers([]domain.Pair{pair})
+func (k *KrakenClient) GetTicker(ctx context.Context, pair domain.Pair) (domain.LTP, error) {
+	ltps, err := k.GetTickers(ctx, []domain.Pair{pair})
 	if err != nil {
 		return domain.LTP{}, err
 	}
 	if len(ltps) == 0 {
-		return domain.LTP{}, fmt.Errorf("no data returned for pair %s", pair.Value())
+		return domain.LTP{}, &NoDataError{Pair: pair, Symbol: k.pairToKrakenSymbol(pair)}
 	}
 	return ltps[0], nil
 }
 
-// GetTickers retrieves ticker information for multiple pairs
-func (k *KrakenClient) GetTickers(pairs []domain.Pair) ([]domain.LTP, error) {
+// GetTickers retrieves ticker information for multiple pairs. When a batch size is
+// configured, the pairs are requested in batches of at most that many, one after another,
+// and the results are merged in the requested order.
+func (k *KrakenClient) GetTickers(ctx context.Context, pairs []domain.Pair) ([]domain.LTP, error) {
 	if len(pairs) == 0 {
 		return nil, fmt.Errorf("no pairs provided")
 	}
+	if k.batchSize <= 0 || len(pairs) <= k.batchSize {
+		return k.getTickerBatch(ctx, pairs)
+	}
+
+	result := make([]domain.LTP, 0, len(pairs))
+	failed := make(map[domain.Pair]error)
+	var firstErr error
+	for start := 0; start < len(pairs); start += k.batchSize {
+		batch := pairs[start:min(start+k.batchSize, len(pairs))]
+		ltps, err := k.getTickerBatch(ctx, batch)
+		if err != nil {
+			if !k.partialBatches {
+				return nil, err
+			}
+			log.Printf("Skipping Kraken batch of pairs %s: %v", domain.CanonicalPairs(batch), err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			for _, pair := range batch {
+				failed[pair] = err
+			}
+			continue
+		}
+		result = append(result, ltps...)
+	}
+	if len(result) == 0 {
+		return nil, firstErr
+	}
+	if len(failed) > 0 {
+		return result, &domain.PartialFetchError{Failed: failed}
+	}
+	return result, nil
+}
 
+// getTickerBatch retrieves ticker information for pairs in a single request
+func (k *KrakenClient) getTickerBatch(ctx context.Context, pairs []domain.Pair) (_ []domain.LTP, err error) {
 	// Convert pairs to Kraken symbols
 	symbols := make([]string, len(pairs))
 	for i, pair := range pairs {
-		symbols[i] = pairToKrakenSymbol(pair)
+		symbols[i] = k.pairToKrakenSymbol(pair)
 	}
 
 	// Build URL with comma-separated symbols
 	pairParam := strings.Join(symbols, ",")
-	url := fmt.Sprintf("%s/Ticker?pair=%s", k.baseURL, pairParam)
+	url := fmt.Sprintf("%s/Ticker?pair=%s", k.baseURL(), pairParam)
 
-	resp, err := k.httpClient.Get(url)
+	ctx, span := tracer.Start(ctx, "kraken.GetTickers", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("url.full", url),
+		attribute.StringSlice("kraken.symbols", symbols),
+	))
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	ctx, cancel := k.withUpstreamTimeout(ctx)
+	defer cancel()
+
+	req, err := k.newRequest(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build Kraken request: %w", err)
+	}
+
+	resp, err := k.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to call Kraken API: %w", err)
 	}
 	defer resp.Body.Close()
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
 
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, &domain.RateLimitError{RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())}
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("kraken API returned status %d", resp.StatusCode)
 	}
@@ -136,33 +506,106 @@ func (k *KrakenClient) GetTickers(pairs []domain.Pair) ([]domain.LTP, error) {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
-	if len(tickerResp.Error) > 0 {
-		return nil, fmt.Errorf("kraken API error: %v", tickerResp.Error)
+	// Warnings accompany valid results, so only errors are fatal
+	errs, warnings := splitMessages(tickerResp.Error)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("kraken API error: %v", errs)
+	}
+	if len(warnings) > 0 {
+		log.Printf("Kraken API warnings for pairs %s: %v", pairParam, warnings)
+		span.SetAttributes(attribute.StringSlice("kraken.warnings", warnings))
+	}
+
+	// A null (or missing) or empty result is an upstream problem, not a missing symbol
+	if tickerResp.Result == nil {
+		return nil, fmt.Errorf("%w for pairs %s: %w", ErrUpstreamEmptyResult, pairParam, ErrNoData)
+	}
+	if len(tickerResp.Result) == 0 {
+		return nil, fmt.Errorf("%w: kraken API returned an empty result for pairs %s", ErrNoData, pairParam)
 	}
 
 	// Map response to domain LTPs
 	result := make([]domain.LTP, 0, len(pairs))
 	for _, pair := range pairs {
-		requestedSymbol := pairToKrakenSymbol(pair)
-		tickerData, foundSymbol, ok := findKrakenSymbolInResult(tickerResp.Result, requestedSymbol)
+		tickerData, foundSymbol, ok := findPairInResult(k, tickerResp.Result, pair)
 		if !ok {
-			return nil, fmt.Errorf("no data found for symbol %s (tried %s and variants)", pair.Value(), requestedSymbol)
+			return nil, &NoDataError{Pair: pair, Symbol: k.pairToKrakenSymbol(pair)}
 		}
 
 		if len(tickerData.C) == 0 || tickerData.C[0] == "" {
 			return nil, fmt.Errorf("invalid ticker data for symbol %s (found as %s)", pair.Value(), foundSymbol)
 		}
 
-		amount, err := strconv.ParseFloat(tickerData.C[0], 64)
+		amount, err := strconv.ParseFloat(string(tickerData.C[0]), 64)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse amount for %s (found as %s): %w", pair.Value(), foundSymbol, err)
 		}
+		if !isValidPrice(amount) {
+			return nil, fmt.Errorf("%w for %s (found as %s): %s", ErrInvalidPrice, pair.Value(), foundSymbol, tickerData.C[0])
+		}
 
 		result = append(result, domain.LTP{
-			Pair:   pair,
-			Amount: amount,
+			Pair:      pair,
+			Amount:    amount,
+			RawAmount: domain.ReportedAmount(string(tickerData.C[0]), amount),
+			Symbol:    foundSymbol,
+			Bid:       parseOptionalPrice(tickerData.B),
+			Ask:       parseOptionalPrice(tickerData.A),
 		})
 	}
 
 	return result, nil
 }
+
+// parseOptionalPrice returns the first element of a Kraken price array, or 0 when it is
+// missing or malformed. Bid and ask are informational, so they never fail a request.
+func parseOptionalPrice(values []KrakenPrice) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	price, err := strconv.ParseFloat(string(values[0]), 64)
+	if err != nil || !isValidPrice(price) {
+		return 0
+	}
+	return price
+}
+
+// isValidPrice reports whether a parsed price is finite and not negative. ParseFloat
+// accepts "NaN" and "Inf", so these must be rejected separately.
+func isValidPrice(price float64) bool {
+	return !math.IsNaN(price) && !math.IsInf(price, 0) && price >= 0
+}
+
+// splitMessages separates the entries of a Kraken error array into errors and warnings.
+// Kraken prefixes warnings with W and errors with E; anything else is treated as an error.
+func splitMessages(messages []string) (errs, warnings []string) {
+	for _, message := range messages {
+		if strings.HasPrefix(message, "W") {
+			warnings = append(warnings, message)
+		} else {
+			errs = append(errs, message)
+		}
+	}
+	return errs, warnings
+}
+
+// parseRetryAfter reads a Retry-After header given either as a number of seconds or as an
+// HTTP date relative to now. Missing, malformed and past values yield zero.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := at.Sub(now); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
@@ -1,14 +1,25 @@
 package kraken
 
 import (
+	"context"
 	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"go-exercise/internal/domain"
 
 	"github.com/h2non/gock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 func TestNewKrakenClient(t *testing.T) {
@@ -16,7 +27,7 @@ func TestNewKrakenClient(t *testing.T) {
 		client := NewKrakenClient("")
 		krakenClient, ok := client.(*KrakenClient)
 		require.True(t, ok)
-		assert.Equal(t, "https://api.kraken.com/0/public", krakenClient.baseURL)
+		assert.Equal(t, "https://api.kraken.com/0/public", krakenClient.baseURL())
 		assert.NotNil(t, krakenClient.httpClient)
 	})
 
@@ -25,8 +36,199 @@ func TestNewKrakenClient(t *testing.T) {
 		client := NewKrakenClient(customURL)
 		krakenClient, ok := client.(*KrakenClient)
 		require.True(t, ok)
-		assert.Equal(t, customURL, krakenClient.baseURL)
+		assert.Equal(t, customURL, krakenClient.baseURL())
 	})
+
+	t.Run("defaults to a 10s timeout", func(t *testing.T) {
+		krakenClient := NewKrakenClient("").(*KrakenClient)
+		assert.Equal(t, 10*time.Second, krakenClient.httpClient.Timeout)
+	})
+
+	t.Run("with timeout", func(t *testing.T) {
+		krakenClient := NewKrakenClient("", WithTimeout(3*time.Second)).(*KrakenClient)
+		assert.Equal(t, 3*time.Second, krakenClient.httpClient.Timeout)
+	})
+
+	t.Run("with upstream timeout", func(t *testing.T) {
+		krakenClient := NewKrakenClient("", WithUpstreamTimeout(2*time.Second)).(*KrakenClient)
+		assert.Equal(t, 2*time.Second, krakenClient.upstreamTimeout)
+		assert.Equal(t, DefaultTimeout, krakenClient.httpClient.Timeout)
+	})
+
+	t.Run("with transport", func(t *testing.T) {
+		transport := &http.Transport{MaxIdleConns: 42}
+		krakenClient := NewKrakenClient("", WithTransport(transport)).(*KrakenClient)
+		assert.Same(t, transport, krakenClient.httpClient.Transport)
+		assert.Equal(t, DefaultTimeout, krakenClient.httpClient.Timeout)
+	})
+
+	t.Run("with http client", func(t *testing.T) {
+		httpClient := &http.Client{}
+		krakenClient := NewKrakenClient("", WithHTTPClient(httpClient)).(*KrakenClient)
+		assert.Same(t, httpClient, krakenClient.httpClient)
+	})
+}
+
+func TestKrakenClient_RequestURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		baseURL  string
+		opts     []Option
+		expected string
+	}{
+		{
+			name:     "default host and version",
+			expected: "https://api.kraken.com/0/public/Ticker?pair=XBTUSD",
+		},
+		{
+			name:     "legacy base URL is the full prefix",
+			baseURL:  "http://localhost:8080/",
+			expected: "http://localhost:8080/Ticker?pair=XBTUSD",
+		},
+		{
+			name:     "custom host keeps the default version",
+			opts:     []Option{WithBaseURL("http://proxy.internal/kraken/")},
+			expected: "http://proxy.internal/kraken/0/public/Ticker?pair=XBTUSD",
+		},
+		{
+			name:     "custom version keeps the default host",
+			opts:     []Option{WithAPIVersion("1/public/")},
+			expected: "https://api.kraken.com/1/public/Ticker?pair=XBTUSD",
+		},
+		{
+			name:     "custom host and version",
+			opts:     []Option{WithBaseURL("https://futures.kraken.com"), WithAPIVersion("/derivatives/api/v3")},
+			expected: "https://futures.kraken.com/derivatives/api/v3/Ticker?pair=XBTUSD",
+		},
+		{
+			name:     "empty version",
+			opts:     []Option{WithBaseURL("http://kraken.test"), WithAPIVersion("")},
+			expected: "http://kraken.test/Ticker?pair=XBTUSD",
+		},
+		{
+			name:     "version on top of a legacy base URL",
+			baseURL:  "http://kraken.test",
+			opts:     []Option{WithAPIVersion("/0/public")},
+			expected: "http://kraken.test/0/public/Ticker?pair=XBTUSD",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requested string
+			httpClient := &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					requested = req.URL.String()
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(strings.NewReader(`{"error":[],"result":{"XXBTZUSD":{"c":["52000.12","0.1"]}}}`)),
+					}, nil
+				}),
+			}
+			client := NewKrakenClient(tt.baseURL, append(tt.opts, WithHTTPClient(httpClient))...)
+			pair, _ := domain.NewPair(domain.BTCUSD)
+
+			_, err := client.GetTickers(context.Background(), []domain.Pair{pair})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, requested)
+		})
+	}
+}
+
+// roundTripFunc adapts a function to http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestKrakenClient_GetTickers_UsesCustomHTTPClient(t *testing.T) {
+	var calls int
+	httpClient := &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			calls++
+			assert.Equal(t, "XBTUSD", req.URL.Query().Get("pair"))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(`{"error":[],"result":{"XXBTZUSD":{"c":["52000.12","0.1"]}}}`)),
+			}, nil
+		}),
+	}
+
+	client := NewKrakenClient("http://kraken.test", WithHTTPClient(httpClient))
+	pair, _ := domain.NewPair(domain.BTCUSD)
+
+	ltps, err := client.GetTickers(context.Background(), []domain.Pair{pair})
+
+	require.NoError(t, err)
+	require.Len(t, ltps, 1)
+	assert.Equal(t, 52000.12, ltps[0].Amount)
+	assert.Equal(t, 1, calls)
+}
+
+func TestKrakenClient_SendsUserAgentAndHeaders(t *testing.T) {
+	tests := []struct {
+		name              string
+		opts              []Option
+		expectedUserAgent string
+		expectedHeaders   map[string]string
+	}{
+		{
+			name:              "default user agent",
+			expectedUserAgent: "^go-exercise/1\\.0$",
+		},
+		{
+			name:              "custom user agent",
+			opts:              []Option{WithUserAgent("ltp-service/2.3")},
+			expectedUserAgent: "^ltp-service/2\\.3$",
+		},
+		{
+			name:              "empty user agent keeps the default",
+			opts:              []Option{WithUserAgent("")},
+			expectedUserAgent: "^go-exercise/1\\.0$",
+		},
+		{
+			name:              "default headers",
+			opts:              []Option{WithHeader("X-Gateway-Token", "secret"), WithHeader("x-tenant", "ltp")},
+			expectedUserAgent: "^go-exercise/1\\.0$",
+			expectedHeaders:   map[string]string{"X-Gateway-Token": "^secret$", "X-Tenant": "^ltp$"},
+		},
+		{
+			name:              "user agent header replaces the configured one",
+			opts:              []Option{WithUserAgent("ltp-service/2.3"), WithHeader("User-Agent", "gateway-client")},
+			expectedUserAgent: "^gateway-client$",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			defer gock.Off()
+
+			request := gock.New("https://api.kraken.com").
+				Get("/0/public/Ticker").
+				MatchParam("pair", "XBTUSD").
+				MatchHeader("User-Agent", tt.expectedUserAgent)
+			for name, value := range tt.expectedHeaders {
+				request.MatchHeader(name, value)
+			}
+			request.Reply(200).
+				BodyString(`{"error":[],"result":{"XXBTZUSD":{"c":["52000.1","0.1"]}}}`)
+
+			client := NewKrakenClient("", tt.opts...)
+			pair, _ := domain.NewPair(domain.BTCUSD)
+
+			// Act
+			ltp, err := client.GetTicker(context.Background(), pair)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, 52000.1, ltp.Amount)
+			assert.True(t, gock.IsDone())
+		})
+	}
 }
 
 func TestPairToKrakenSymbol(t *testing.T) {
@@ -46,47 +248,183 @@ func TestPairToKrakenSymbol(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := pairToKrakenSymbol(tt.pair)
+			result := NewKrakenClient("").(*KrakenClient).pairToKrakenSymbol(tt.pair)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
 }
 
+func TestKrakenClient_SupportedPairs(t *testing.T) {
+	client := NewKrakenClient("").(*KrakenClient)
+
+	pairs := client.SupportedPairs()
+
+	values := make([]string, len(pairs))
+	for i, pair := range pairs {
+		values[i] = pair.Value()
+	}
+	assert.ElementsMatch(t, []string{domain.BTCUSD, domain.BTCCHF, domain.BTCEUR}, values)
+}
+
+func TestKrakenClient_Symbol(t *testing.T) {
+	client := NewKrakenClient("").(*KrakenClient)
+	pair, _ := domain.NewPair(domain.BTCUSD)
+
+	symbol, ok := client.Symbol(pair)
+
+	assert.True(t, ok)
+	assert.Equal(t, "XBTUSD", symbol)
+}
+
+func TestKrakenClient_WithSymbols(t *testing.T) {
+	defer gock.Off()
+
+	client := NewKrakenClient("", WithSymbols(map[string]string{
+		domain.BTCUSD: "TBTCUSD",
+		"BTC/GBP":     "XBTGBP", // not a valid domain pair, so not reported
+	})).(*KrakenClient)
+
+	pairs := client.SupportedPairs()
+	require.Len(t, pairs, 1)
+	assert.Equal(t, domain.BTCUSD, pairs[0].Value())
+
+	gock.New("https://api.kraken.com").
+		Get("/0/public/Ticker").
+		MatchParam("pair", "TBTCUSD").
+		Reply(200).
+		JSON(`{"error":[],"result":{"TBTCUSD":{"c":["52000.12","0.1"]}}}`)
+
+	ltps, err := client.GetTickers(context.Background(), pairs)
+
+	require.NoError(t, err)
+	require.Len(t, ltps, 1)
+	assert.Equal(t, 52000.12, ltps[0].Amount)
+	assert.True(t, gock.IsDone())
+}
+
+func TestKrakenClient_WithSymbolOverrides(t *testing.T) {
+	defer gock.Off()
+
+	client := NewKrakenClient("", WithSymbolOverrides(map[string]string{
+		domain.BTCUSD: "XXBTZUSD",
+	})).(*KrakenClient)
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+
+	symbol, ok := client.Symbol(btcUSD)
+	assert.True(t, ok)
+	assert.Equal(t, "XXBTZUSD", symbol, "the override wins over the default mapping")
+	symbol, _ = client.Symbol(btcEUR)
+	assert.Equal(t, "XBTEUR", symbol, "pairs without an override keep the default mapping")
+
+	// The default symbol is in the result too, yet only the override is looked up
+	gock.New("https://api.kraken.com").
+		Get("/0/public/Ticker").
+		MatchParam("pair", "XXBTZUSD").
+		Reply(200).
+		JSON(`{"error":[],"result":{
+			"XBTUSD":{"c":["1.00","0.1"]},
+			"XXBTZUSD":{"c":["52000.12","0.1"]}
+		}}`)
+
+	ltps, err := client.GetTickers(context.Background(), []domain.Pair{btcUSD})
+
+	require.NoError(t, err)
+	require.Len(t, ltps, 1)
+	assert.Equal(t, 52000.12, ltps[0].Amount)
+	assert.True(t, gock.IsDone())
+}
+
+func TestKrakenClient_WithSymbolOverrides_AddsSupportedPairs(t *testing.T) {
+	defaultPairs := domain.ValidPairs()
+	t.Cleanup(func() { require.NoError(t, domain.SetValidPairs(defaultPairs)) })
+	require.NoError(t, domain.SetValidPairs(append(defaultPairs, "ETH/USD")))
+
+	client := NewKrakenClient("", WithSymbolOverrides(map[string]string{
+		domain.BTCUSD: "XXBTZUSD",
+		"ETH/USD":     "XETHZUSD",
+	})).(*KrakenClient)
+
+	pairs := client.SupportedPairs()
+
+	values := make([]string, len(pairs))
+	for i, pair := range pairs {
+		values[i] = pair.Value()
+	}
+	assert.ElementsMatch(t, []string{domain.BTCUSD, domain.BTCCHF, domain.BTCEUR, "ETH/USD"}, values)
+}
+
 func TestFindKrakenSymbolInResult(t *testing.T) {
 	t.Run("exact match", func(t *testing.T) {
 		result := map[string]KrakenTickerData{
-			"XBTUSD": {C: []string{"50000.12"}},
+			"XBTUSD": {C: []KrakenPrice{"50000.12"}},
 		}
 		tickerData, symbol, ok := findKrakenSymbolInResult(result, "XBTUSD")
 		assert.True(t, ok)
 		assert.Equal(t, "XBTUSD", symbol)
-		assert.Equal(t, "50000.12", tickerData.C[0])
+		assert.Equal(t, KrakenPrice("50000.12"), tickerData.C[0])
 	})
 
 	t.Run("variant match XXBTZUSD", func(t *testing.T) {
 		result := map[string]KrakenTickerData{
-			"XXBTZUSD": {C: []string{"50000.12"}},
+			"XXBTZUSD": {C: []KrakenPrice{"50000.12"}},
 		}
 		tickerData, symbol, ok := findKrakenSymbolInResult(result, "XBTUSD")
 		assert.True(t, ok)
 		assert.Equal(t, "XXBTZUSD", symbol)
-		assert.Equal(t, "50000.12", tickerData.C[0])
+		assert.Equal(t, KrakenPrice("50000.12"), tickerData.C[0])
 	})
 
-	t.Run("single result fallback", func(t *testing.T) {
+	t.Run("variant match for non-XBT symbols", func(t *testing.T) {
 		result := map[string]KrakenTickerData{
-			"UNKNOWN": {C: []string{"50000.12"}},
+			"XETHZUSD": {C: []KrakenPrice{"3000.12"}},
+			"XXBTZUSD": {C: []KrakenPrice{"50000.12"}},
+		}
+		tickerData, symbol, ok := findKrakenSymbolInResult(result, "ETHUSD")
+		assert.True(t, ok)
+		assert.Equal(t, "XETHZUSD", symbol)
+		assert.Equal(t, KrakenPrice("3000.12"), tickerData.C[0])
+	})
+
+	t.Run("single result fallback for a plausible symbol", func(t *testing.T) {
+		result := map[string]KrakenTickerData{
+			"XBT.USD": {C: []KrakenPrice{"50000.12"}},
 		}
 		tickerData, symbol, ok := findKrakenSymbolInResult(result, "XBTUSD")
 		assert.True(t, ok)
-		assert.Equal(t, "UNKNOWN", symbol)
-		assert.Equal(t, "50000.12", tickerData.C[0])
+		assert.Equal(t, "XBT.USD", symbol)
+		assert.Equal(t, KrakenPrice("50000.12"), tickerData.C[0])
+	})
+
+	t.Run("single result fallback via asset alias", func(t *testing.T) {
+		result := map[string]KrakenTickerData{
+			"BTCUSD": {C: []KrakenPrice{"50000.12"}},
+		}
+		_, symbol, ok := findKrakenSymbolInResult(result, "XBTUSD")
+		assert.True(t, ok)
+		assert.Equal(t, "BTCUSD", symbol)
+	})
+
+	t.Run("not found - single unrelated result", func(t *testing.T) {
+		result := map[string]KrakenTickerData{
+			"UNKNOWN": {C: []KrakenPrice{"50000.12"}},
+		}
+		_, _, ok := findKrakenSymbolInResult(result, "XBTUSD")
+		assert.False(t, ok)
+	})
+
+	t.Run("not found - single result for another pair", func(t *testing.T) {
+		result := map[string]KrakenTickerData{
+			"XXBTZEUR": {C: []KrakenPrice{"48000.12"}},
+		}
+		_, _, ok := findKrakenSymbolInResult(result, "XBTUSD")
+		assert.False(t, ok)
 	})
 
 	t.Run("not found - multiple results", func(t *testing.T) {
 		result := map[string]KrakenTickerData{
-			"OTHER1": {C: []string{"50000.12"}},
-			"OTHER2": {C: []string{"60000.12"}},
+			"OTHER1": {C: []KrakenPrice{"50000.12"}},
+			"OTHER2": {C: []KrakenPrice{"60000.12"}},
 		}
 		_, _, ok := findKrakenSymbolInResult(result, "XBTUSD")
 		assert.False(t, ok)
@@ -100,7 +438,7 @@ func TestKrakenClient_GetTicker_Success(t *testing.T) {
 	response := KrakenTickerResponse{
 		Error: []string{},
 		Result: map[string]KrakenTickerData{
-			"XXBTZUSD": {C: []string{"52000.12"}},
+			"XXBTZUSD": {C: []KrakenPrice{"52000.12"}},
 		},
 	}
 	responseBody, _ := json.Marshal(response)
@@ -114,7 +452,7 @@ func TestKrakenClient_GetTicker_Success(t *testing.T) {
 	client := NewKrakenClient("").(*KrakenClient)
 	pair, _ := domain.NewPair(domain.BTCUSD)
 
-	ltp, err := client.GetTicker(pair)
+	ltp, err := client.GetTicker(context.Background(), pair)
 
 	require.NoError(t, err)
 	assert.Equal(t, pair, ltp.Pair)
@@ -125,9 +463,12 @@ func TestKrakenClient_GetTicker_Success(t *testing.T) {
 func TestKrakenClient_GetTicker_NoData(t *testing.T) {
 	defer gock.Off()
 
+	// The result holds another pair only, so the requested one is missing
 	response := KrakenTickerResponse{
-		Error:  []string{},
-		Result: map[string]KrakenTickerData{},
+		Error: []string{},
+		Result: map[string]KrakenTickerData{
+			"XETHZUSD": {C: []KrakenPrice{"3000.0", "1.0"}},
+		},
 	}
 	responseBody, _ := json.Marshal(response)
 
@@ -140,11 +481,13 @@ func TestKrakenClient_GetTicker_NoData(t *testing.T) {
 	client := NewKrakenClient("").(*KrakenClient)
 	pair, _ := domain.NewPair(domain.BTCUSD)
 
-	_, err := client.GetTicker(pair)
+	_, err := client.GetTicker(context.Background(), pair)
 
-	assert.Error(t, err)
-	// GetTicker calls GetTickers, which will return "no data found for symbol" error
-	assert.Contains(t, err.Error(), "no data")
+	var noDataErr *NoDataError
+	require.ErrorAs(t, err, &noDataErr)
+	assert.ErrorIs(t, err, ErrNoData)
+	assert.Equal(t, pair, noDataErr.Pair)
+	assert.Equal(t, "no data for pair BTC/USD (tried XBTUSD and variants)", err.Error())
 	assert.True(t, gock.IsDone())
 }
 
@@ -154,7 +497,7 @@ func TestKrakenClient_GetTickers_Success_SinglePair(t *testing.T) {
 	response := KrakenTickerResponse{
 		Error: []string{},
 		Result: map[string]KrakenTickerData{
-			"XXBTZUSD": {C: []string{"52000.12"}},
+			"XXBTZUSD": {C: []KrakenPrice{"52000.12"}},
 		},
 	}
 	responseBody, _ := json.Marshal(response)
@@ -168,7 +511,7 @@ func TestKrakenClient_GetTickers_Success_SinglePair(t *testing.T) {
 	client := NewKrakenClient("").(*KrakenClient)
 	pair, _ := domain.NewPair(domain.BTCUSD)
 
-	ltps, err := client.GetTickers([]domain.Pair{pair})
+	ltps, err := client.GetTickers(context.Background(), []domain.Pair{pair})
 
 	require.NoError(t, err)
 	require.Len(t, ltps, 1)
@@ -177,14 +520,118 @@ func TestKrakenClient_GetTickers_Success_SinglePair(t *testing.T) {
 	assert.True(t, gock.IsDone())
 }
 
+func TestKrakenClient_GetTickers_PreservesRawAmount(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.kraken.com").
+		Get("/0/public/Ticker").
+		MatchParam("pair", "XBTUSD").
+		Reply(200).
+		JSON(`{"error":[],"result":{"XXBTZUSD":{"c":["52000.10000","0.001"]}}}`)
+
+	client := NewKrakenClient("").(*KrakenClient)
+	pair, _ := domain.NewPair(domain.BTCUSD)
+
+	ltps, err := client.GetTickers(context.Background(), []domain.Pair{pair})
+
+	require.NoError(t, err)
+	require.Len(t, ltps, 1)
+	assert.Equal(t, 52000.1, ltps[0].Amount)
+	assert.Equal(t, "52000.10000", ltps[0].RawAmount)
+	assert.True(t, gock.IsDone())
+}
+
+func TestKrakenClient_GetTickers_NormalizesNonJSONRawAmount(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.kraken.com").
+		Get("/0/public/Ticker").
+		MatchParam("pair", "XBTUSD").
+		Reply(200).
+		JSON(`{"error":[],"result":{"XXBTZUSD":{"c":[".5","0.001"]}}}`)
+
+	client := NewKrakenClient("").(*KrakenClient)
+	pair, _ := domain.NewPair(domain.BTCUSD)
+
+	ltps, err := client.GetTickers(context.Background(), []domain.Pair{pair})
+
+	require.NoError(t, err)
+	require.Len(t, ltps, 1)
+	assert.Equal(t, 0.5, ltps[0].Amount)
+	assert.Equal(t, "0.5", ltps[0].RawAmount)
+}
+
+func TestKrakenClient_GetTickers_StringAndNumberPrices(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"strings", `{"error":[],"result":{"XXBTZUSD":{"a":["52000.5","1","1.0"],"b":["51999.5","1","1.0"],"c":["52000.12","0.001"]}}}`},
+		{"numbers", `{"error":[],"result":{"XXBTZUSD":{"a":[52000.5,1,1.0],"b":[51999.5,1,1.0],"c":[52000.12,0.001]}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+
+			gock.New("https://api.kraken.com").
+				Get("/0/public/Ticker").
+				Reply(200).
+				BodyString(tt.body)
+
+			client := NewKrakenClient("").(*KrakenClient)
+			pair, _ := domain.NewPair(domain.BTCUSD)
+
+			ltps, err := client.GetTickers(context.Background(), []domain.Pair{pair})
+
+			require.NoError(t, err)
+			require.Len(t, ltps, 1)
+			assert.Equal(t, 52000.12, ltps[0].Amount)
+			assert.Equal(t, "52000.12", ltps[0].RawAmount)
+			assert.Equal(t, 51999.5, ltps[0].Bid)
+			assert.Equal(t, 52000.5, ltps[0].Ask)
+		})
+	}
+}
+
+func TestKrakenPrice_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected KrakenPrice
+		wantErr  bool
+	}{
+		{"string", `"52000.10000"`, "52000.10000", false},
+		{"number keeps its digits", `52000.10000`, "52000.10000", false},
+		{"integer", `52000`, "52000", false},
+		{"empty string", `""`, "", false},
+		{"boolean", `true`, "", true},
+		{"object", `{}`, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var price KrakenPrice
+			err := json.Unmarshal([]byte(tt.input), &price)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, price)
+		})
+	}
+}
+
 func TestKrakenClient_GetTickers_Success_MultiplePairs(t *testing.T) {
 	defer gock.Off()
 
 	response := KrakenTickerResponse{
 		Error: []string{},
 		Result: map[string]KrakenTickerData{
-			"XXBTZUSD": {C: []string{"52000.12"}},
-			"XXBTZEUR": {C: []string{"50000.12"}},
+			"XXBTZUSD": {C: []KrakenPrice{"52000.12"}},
+			"XXBTZEUR": {C: []KrakenPrice{"50000.12"}},
 		},
 	}
 	responseBody, _ := json.Marshal(response)
@@ -199,7 +646,7 @@ func TestKrakenClient_GetTickers_Success_MultiplePairs(t *testing.T) {
 	btcUSD, _ := domain.NewPair(domain.BTCUSD)
 	btcEUR, _ := domain.NewPair(domain.BTCEUR)
 
-	ltps, err := client.GetTickers([]domain.Pair{btcUSD, btcEUR})
+	ltps, err := client.GetTickers(context.Background(), []domain.Pair{btcUSD, btcEUR})
 
 	require.NoError(t, err)
 	require.Len(t, ltps, 2)
@@ -214,10 +661,138 @@ func TestKrakenClient_GetTickers_Success_MultiplePairs(t *testing.T) {
 	assert.True(t, gock.IsDone())
 }
 
+func TestKrakenClient_GetTickers_Batches(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.kraken.com").
+		Get("/0/public/Ticker").
+		MatchParam("pair", "^XBTUSD,XBTEUR$").
+		Reply(200).
+		JSON(`{"error":[],"result":{
+			"XXBTZUSD":{"c":["52000.12","0.1"]},
+			"XXBTZEUR":{"c":["50000.12","0.1"]}
+		}}`)
+	gock.New("https://api.kraken.com").
+		Get("/0/public/Ticker").
+		MatchParam("pair", "^XBTCHF$").
+		Reply(200).
+		JSON(`{"error":[],"result":{"XBTCHF":{"c":["49000.12","0.1"]}}}`)
+
+	client := NewKrakenClient("", WithBatchSize(2)).(*KrakenClient)
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	btcCHF, _ := domain.NewPair(domain.BTCCHF)
+
+	ltps, err := client.GetTickers(context.Background(), []domain.Pair{btcUSD, btcEUR, btcCHF})
+
+	require.NoError(t, err)
+	require.Len(t, ltps, 3)
+	assert.Equal(t, btcUSD, ltps[0].Pair)
+	assert.Equal(t, 52000.12, ltps[0].Amount)
+	assert.Equal(t, btcEUR, ltps[1].Pair)
+	assert.Equal(t, 50000.12, ltps[1].Amount)
+	assert.Equal(t, btcCHF, ltps[2].Pair)
+	assert.Equal(t, 49000.12, ltps[2].Amount)
+	assert.True(t, gock.IsDone(), "one call per batch")
+}
+
+func TestKrakenClient_GetTickers_BatchErrors(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	btcCHF, _ := domain.NewPair(domain.BTCCHF)
+	pairs := []domain.Pair{btcUSD, btcEUR, btcCHF}
+
+	tests := []struct {
+		name        string
+		opts        []Option
+		failing     string
+		expected    []domain.Pair
+		failed      []domain.Pair
+		expectedErr string
+	}{
+		{name: "a failed batch fails the call", failing: "^XBTCHF$", expectedErr: "status 500"},
+		{name: "partial mode skips a failed batch", opts: []Option{WithPartialBatches()}, failing: "^XBTCHF$", expected: []domain.Pair{btcUSD, btcEUR}, failed: []domain.Pair{btcCHF}},
+		{name: "partial mode fails when every batch fails", opts: []Option{WithPartialBatches()}, failing: ".*", expectedErr: "status 500"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+
+			gock.New("https://api.kraken.com").
+				Get("/0/public/Ticker").
+				MatchParam("pair", tt.failing).
+				Persist().
+				Reply(500)
+			gock.New("https://api.kraken.com").
+				Get("/0/public/Ticker").
+				MatchParam("pair", "^XBTUSD,XBTEUR$").
+				Reply(200).
+				JSON(`{"error":[],"result":{
+					"XXBTZUSD":{"c":["52000.12","0.1"]},
+					"XXBTZEUR":{"c":["50000.12","0.1"]}
+				}}`)
+
+			client := NewKrakenClient("", append([]Option{WithBatchSize(2)}, tt.opts...)...).(*KrakenClient)
+
+			ltps, err := client.GetTickers(context.Background(), pairs)
+
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, ltps)
+				return
+			}
+			if tt.failed != nil {
+				var partial *domain.PartialFetchError
+				require.ErrorAs(t, err, &partial)
+				failed := make([]domain.Pair, 0, len(partial.Failed))
+				for pair, pairErr := range partial.Failed {
+					failed = append(failed, pair)
+					assert.Contains(t, pairErr.Error(), "status 500")
+				}
+				assert.ElementsMatch(t, tt.failed, failed)
+			} else {
+				require.NoError(t, err)
+			}
+			got := make([]domain.Pair, len(ltps))
+			for i, ltp := range ltps {
+				got[i] = ltp.Pair
+			}
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestKrakenClient_GetTickers_ParsesBidAndAsk(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.kraken.com").
+		Get("/0/public/Ticker").
+		MatchParam("pair", "XBTUSD,XBTEUR").
+		Reply(200).
+		JSON(`{"error":[],"result":{` +
+			`"XXBTZUSD":{"a":["52000.50000","1","1.000"],"b":["51999.50000","2","2.000"],"c":["52000.12","0.1"]},` +
+			`"XXBTZEUR":{"a":["not-a-price","1","1.000"],"c":["50000.12","0.1"]}}}`)
+
+	client := NewKrakenClient("").(*KrakenClient)
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+
+	ltps, err := client.GetTickers(context.Background(), []domain.Pair{btcUSD, btcEUR})
+
+	require.NoError(t, err)
+	require.Len(t, ltps, 2)
+	assert.Equal(t, domain.LTP{Pair: btcUSD, Amount: 52000.12, RawAmount: "52000.12", Symbol: "XXBTZUSD", Bid: 51999.5, Ask: 52000.5}, ltps[0])
+	// Missing or malformed bid/ask never fail the last trade price
+	assert.Equal(t, domain.LTP{Pair: btcEUR, Amount: 50000.12, RawAmount: "50000.12", Symbol: "XXBTZEUR"}, ltps[1])
+	assert.True(t, gock.IsDone())
+}
+
 func TestKrakenClient_GetTickers_EmptyPairs(t *testing.T) {
 	client := NewKrakenClient("http://localhost").(*KrakenClient)
 
-	_, err := client.GetTickers([]domain.Pair{})
+	_, err := client.GetTickers(context.Background(), []domain.Pair{})
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "no pairs provided")
@@ -235,13 +810,96 @@ func TestKrakenClient_GetTickers_HTTPError(t *testing.T) {
 	client := NewKrakenClient("").(*KrakenClient)
 	pair, _ := domain.NewPair(domain.BTCUSD)
 
-	_, err := client.GetTickers([]domain.Pair{pair})
+	_, err := client.GetTickers(context.Background(), []domain.Pair{pair})
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "status 500")
 	assert.True(t, gock.IsDone())
 }
 
+func TestKrakenClient_GetTickers_UpstreamTimeout(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeout  time.Duration
+		deadline time.Duration
+		expected time.Duration
+	}{
+		{"upstream timeout fires before a generous caller deadline", 50 * time.Millisecond, time.Minute, 50 * time.Millisecond},
+		{"earlier caller deadline still applies", time.Minute, 50 * time.Millisecond, 50 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+
+			gock.New("https://api.kraken.com").
+				Get("/0/public/Ticker").
+				Reply(200).
+				Delay(5 * time.Second).
+				BodyString(`{"error":[],"result":{"XXBTZUSD":{"c":["52000.12","1"]}}}`)
+
+			client := NewKrakenClient("", WithUpstreamTimeout(tt.timeout)).(*KrakenClient)
+			pair, _ := domain.NewPair(domain.BTCUSD)
+			ctx, cancel := context.WithTimeout(context.Background(), tt.deadline)
+			defer cancel()
+
+			start := time.Now()
+			_, err := client.GetTickers(ctx, []domain.Pair{pair})
+			elapsed := time.Since(start)
+
+			require.ErrorIs(t, err, context.DeadlineExceeded)
+			assert.GreaterOrEqual(t, elapsed, tt.expected)
+			assert.Less(t, elapsed, time.Second)
+		})
+	}
+}
+
+func TestKrakenClient_GetTickers_RateLimited(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.kraken.com").
+		Get("/0/public/Ticker").
+		MatchParam("pair", "XBTUSD").
+		Reply(429).
+		SetHeader("Retry-After", "7").
+		BodyString("Too Many Requests")
+
+	client := NewKrakenClient("").(*KrakenClient)
+	pair, _ := domain.NewPair(domain.BTCUSD)
+
+	_, err := client.GetTickers(context.Background(), []domain.Pair{pair})
+
+	require.ErrorIs(t, err, domain.ErrRateLimited)
+	var rateLimitErr *domain.RateLimitError
+	require.ErrorAs(t, err, &rateLimitErr)
+	assert.Equal(t, 7*time.Second, rateLimitErr.RetryAfter)
+	assert.True(t, gock.IsDone())
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Duration
+	}{
+		{"missing", "", 0},
+		{"seconds", "120", 2 * time.Minute},
+		{"padded seconds", " 5 ", 5 * time.Second},
+		{"negative seconds", "-3", 0},
+		{"http date", "Mon, 01 Jan 2024 12:00:30 GMT", 30 * time.Second},
+		{"past http date", "Mon, 01 Jan 2024 11:59:00 GMT", 0},
+		{"malformed", "soon", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseRetryAfter(tt.value, now))
+		})
+	}
+}
+
 func TestKrakenClient_GetTickers_InvalidJSON(t *testing.T) {
 	defer gock.Off()
 
@@ -254,7 +912,7 @@ func TestKrakenClient_GetTickers_InvalidJSON(t *testing.T) {
 	client := NewKrakenClient("").(*KrakenClient)
 	pair, _ := domain.NewPair(domain.BTCUSD)
 
-	_, err := client.GetTickers([]domain.Pair{pair})
+	_, err := client.GetTickers(context.Background(), []domain.Pair{pair})
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to unmarshal")
@@ -279,21 +937,84 @@ func TestKrakenClient_GetTickers_APIError(t *testing.T) {
 	client := NewKrakenClient("").(*KrakenClient)
 	pair, _ := domain.NewPair(domain.BTCUSD)
 
-	_, err := client.GetTickers([]domain.Pair{pair})
+	_, err := client.GetTickers(context.Background(), []domain.Pair{pair})
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "kraken API error")
 	assert.True(t, gock.IsDone())
 }
 
+func TestKrakenClient_GetTickers_WarningWithData(t *testing.T) {
+	defer gock.Off()
+
+	response := KrakenTickerResponse{
+		Error: []string{"WGeneral:Temporary lockout"},
+		Result: map[string]KrakenTickerData{
+			"XXBTZUSD": {C: []KrakenPrice{"52000.12"}},
+		},
+	}
+	responseBody, _ := json.Marshal(response)
+
+	gock.New("https://api.kraken.com").
+		Get("/0/public/Ticker").
+		MatchParam("pair", "XBTUSD").
+		Reply(200).
+		JSON(responseBody)
+
+	client := NewKrakenClient("").(*KrakenClient)
+	pair, _ := domain.NewPair(domain.BTCUSD)
+
+	ltps, err := client.GetTickers(context.Background(), []domain.Pair{pair})
+
+	require.NoError(t, err)
+	require.Len(t, ltps, 1)
+	assert.Equal(t, 52000.12, ltps[0].Amount)
+	assert.True(t, gock.IsDone())
+}
+
+func TestKrakenClient_GetTickers_ErrorAlongsideWarning(t *testing.T) {
+	defer gock.Off()
+
+	response := KrakenTickerResponse{
+		Error: []string{"WGeneral:Temporary lockout", "EService:Unavailable"},
+		Result: map[string]KrakenTickerData{
+			"XXBTZUSD": {C: []KrakenPrice{"52000.12"}},
+		},
+	}
+	responseBody, _ := json.Marshal(response)
+
+	gock.New("https://api.kraken.com").
+		Get("/0/public/Ticker").
+		MatchParam("pair", "XBTUSD").
+		Reply(200).
+		JSON(responseBody)
+
+	client := NewKrakenClient("").(*KrakenClient)
+	pair, _ := domain.NewPair(domain.BTCUSD)
+
+	_, err := client.GetTickers(context.Background(), []domain.Pair{pair})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "EService:Unavailable")
+	assert.NotContains(t, err.Error(), "WGeneral")
+	assert.True(t, gock.IsDone())
+}
+
+func TestSplitMessages(t *testing.T) {
+	errs, warnings := splitMessages([]string{"EGeneral:Invalid arguments", "WGeneral:Temporary lockout", "unexpected"})
+
+	assert.Equal(t, []string{"EGeneral:Invalid arguments", "unexpected"}, errs)
+	assert.Equal(t, []string{"WGeneral:Temporary lockout"}, warnings)
+}
+
 func TestKrakenClient_GetTickers_NoDataForSymbol(t *testing.T) {
 	defer gock.Off()
 
 	response := KrakenTickerResponse{
 		Error: []string{},
 		Result: map[string]KrakenTickerData{
-			"OTHER1": {C: []string{"100.0"}},
-			"OTHER2": {C: []string{"200.0"}},
+			"OTHER1": {C: []KrakenPrice{"100.0"}},
+			"OTHER2": {C: []KrakenPrice{"200.0"}},
 		},
 	}
 	responseBody, _ := json.Marshal(response)
@@ -307,10 +1028,13 @@ func TestKrakenClient_GetTickers_NoDataForSymbol(t *testing.T) {
 	client := NewKrakenClient("").(*KrakenClient)
 	pair, _ := domain.NewPair(domain.BTCUSD)
 
-	_, err := client.GetTickers([]domain.Pair{pair})
+	_, err := client.GetTickers(context.Background(), []domain.Pair{pair})
 
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "no data found for symbol")
+	var noDataErr *NoDataError
+	require.ErrorAs(t, err, &noDataErr)
+	assert.Equal(t, pair, noDataErr.Pair)
+	assert.Equal(t, "XBTUSD", noDataErr.Symbol)
+	assert.Equal(t, "no data for pair BTC/USD (tried XBTUSD and variants)", err.Error())
 	assert.True(t, gock.IsDone())
 }
 
@@ -320,7 +1044,7 @@ func TestKrakenClient_GetTickers_InvalidTickerData(t *testing.T) {
 	response := KrakenTickerResponse{
 		Error: []string{},
 		Result: map[string]KrakenTickerData{
-			"XXBTZUSD": {C: []string{}}, // Empty price
+			"XXBTZUSD": {C: []KrakenPrice{}}, // Empty price
 		},
 	}
 	responseBody, _ := json.Marshal(response)
@@ -334,7 +1058,7 @@ func TestKrakenClient_GetTickers_InvalidTickerData(t *testing.T) {
 	client := NewKrakenClient("").(*KrakenClient)
 	pair, _ := domain.NewPair(domain.BTCUSD)
 
-	_, err := client.GetTickers([]domain.Pair{pair})
+	_, err := client.GetTickers(context.Background(), []domain.Pair{pair})
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid ticker data")
@@ -347,7 +1071,7 @@ func TestKrakenClient_GetTickers_InvalidAmount(t *testing.T) {
 	response := KrakenTickerResponse{
 		Error: []string{},
 		Result: map[string]KrakenTickerData{
-			"XXBTZUSD": {C: []string{"invalid"}},
+			"XXBTZUSD": {C: []KrakenPrice{"invalid"}},
 		},
 	}
 	responseBody, _ := json.Marshal(response)
@@ -361,10 +1085,133 @@ func TestKrakenClient_GetTickers_InvalidAmount(t *testing.T) {
 	client := NewKrakenClient("").(*KrakenClient)
 	pair, _ := domain.NewPair(domain.BTCUSD)
 
-	_, err := client.GetTickers([]domain.Pair{pair})
+	_, err := client.GetTickers(context.Background(), []domain.Pair{pair})
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to parse amount")
 	assert.True(t, gock.IsDone())
 }
 
+
+func TestKrakenClient_GetTickers_RejectsInvalidPrices(t *testing.T) {
+	for _, price := range []string{"NaN", "Inf", "-Inf", "-5"} {
+		t.Run(price, func(t *testing.T) {
+			defer gock.Off()
+
+			gock.New("https://api.kraken.com").
+				Get("/0/public/Ticker").
+				MatchParam("pair", "XBTUSD").
+				Reply(200).
+				BodyString(`{"error":[],"result":{"XXBTZUSD":{"c":["` + price + `","1"]}}}`)
+
+			client := NewKrakenClient("").(*KrakenClient)
+			pair, _ := domain.NewPair(domain.BTCUSD)
+
+			ltps, err := client.GetTickers(context.Background(), []domain.Pair{pair})
+
+			require.ErrorIs(t, err, ErrInvalidPrice)
+			assert.Contains(t, err.Error(), "invalid price for BTC/USD")
+			assert.Nil(t, ltps)
+			assert.True(t, gock.IsDone())
+		})
+	}
+}
+
+func TestParseOptionalPrice_IgnoresInvalidPrices(t *testing.T) {
+	assert.Equal(t, 51999.5, parseOptionalPrice([]KrakenPrice{"51999.5"}))
+	for _, price := range []KrakenPrice{"NaN", "Inf", "-5", "abc"} {
+		assert.Zero(t, parseOptionalPrice([]KrakenPrice{price}), price)
+	}
+}
+
+func TestKrakenClient_GetTickers_NullResult(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.kraken.com").
+		Get("/0/public/Ticker").
+		MatchParam("pair", "XBTUSD").
+		Reply(200).
+		BodyString(`{"error":[],"result":null}`)
+
+	client := NewKrakenClient("").(*KrakenClient)
+	pair, _ := domain.NewPair(domain.BTCUSD)
+
+	_, err := client.GetTickers(context.Background(), []domain.Pair{pair})
+
+	assert.ErrorIs(t, err, ErrUpstreamEmptyResult)
+	assert.ErrorIs(t, err, ErrNoData)
+	var noData *NoDataError
+	assert.NotErrorAs(t, err, &noData, "a null result is not a missing pair")
+	assert.True(t, gock.IsDone())
+}
+
+func TestKrakenClient_GetTickers_EmptyResultIsNotNull(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.kraken.com").
+		Get("/0/public/Ticker").
+		MatchParam("pair", "XBTUSD").
+		Reply(200).
+		BodyString(`{"error":[],"result":{}}`)
+
+	client := NewKrakenClient("").(*KrakenClient)
+	pair, _ := domain.NewPair(domain.BTCUSD)
+
+	_, err := client.GetTickers(context.Background(), []domain.Pair{pair})
+
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrUpstreamEmptyResult)
+	assert.ErrorIs(t, err, ErrNoData)
+	var noData *NoDataError
+	assert.NotErrorAs(t, err, &noData, "an empty result is not a missing pair")
+	assert.Contains(t, err.Error(), "empty result for pairs XBTUSD")
+	assert.True(t, gock.IsDone())
+}
+
+func TestKrakenClient_GetTicker_NullResultIsNoData(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.kraken.com").
+		Get("/0/public/Ticker").
+		MatchParam("pair", "XBTUSD").
+		Reply(200).
+		BodyString(`{"error":[],"result":null}`)
+
+	client := NewKrakenClient("")
+	pair, _ := domain.NewPair(domain.BTCUSD)
+
+	_, err := client.GetTicker(context.Background(), pair)
+
+	assert.ErrorIs(t, err, ErrNoData)
+	assert.ErrorIs(t, err, ErrUpstreamEmptyResult)
+	assert.True(t, gock.IsDone())
+}
+
+func TestKrakenClient_GetTickers_RecordsSpan(t *testing.T) {
+	defer gock.Off()
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+
+	gock.New("https://api.kraken.com").
+		Get("/0/public/Ticker").
+		MatchParam("pair", "XBTUSD,XBTEUR").
+		Reply(200).
+		JSON(`{"error":[],"result":{"XXBTZUSD":{"c":["52000.12","0.1"]},"XXBTZEUR":{"c":["50000.12","0.1"]}}}`)
+
+	client := NewKrakenClient("")
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+
+	_, err := client.GetTickers(context.Background(), []domain.Pair{btcUSD, btcEUR})
+
+	require.NoError(t, err)
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "kraken.GetTickers", spans[0].Name())
+	assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind())
+	assert.Contains(t, spans[0].Attributes(), attribute.String("url.full", "https://api.kraken.com/0/public/Ticker?pair=XBTUSD,XBTEUR"))
+	assert.Contains(t, spans[0].Attributes(), attribute.StringSlice("kraken.symbols", []string{"XBTUSD", "XBTEUR"}))
+	assert.Contains(t, spans[0].Attributes(), attribute.Int("http.response.status_code", 200))
+}
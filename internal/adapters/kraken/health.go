@@ -0,0 +1,59 @@
+package kraken
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"go-exercise/internal/domain"
+	"go-exercise/internal/ports"
+)
+
+// Ensure KrakenClient reports its health
+var _ ports.HealthChecker = (*KrakenClient)(nil)
+
+// KrakenSystemStatusResponse represents the response from Kraken SystemStatus API
+type KrakenSystemStatusResponse struct {
+	Error  []string `json:"error"`
+	Result struct {
+		Status string `json:"status"` // online, maintenance, cancel_only or post_only
+	} `json:"result"`
+}
+
+// Name identifies Kraken in readiness responses
+func (k *KrakenClient) Name() string {
+	return "kraken"
+}
+
+// CheckHealth asks Kraken for its system status. Kraken only serves reliable prices while
+// online; the restricted trading modes are reported as degraded.
+func (k *KrakenClient) CheckHealth(ctx context.Context) domain.HealthStatus {
+	req, err := k.newRequest(ctx, k.baseURL()+"/SystemStatus")
+	if err != nil {
+		return domain.HealthDown
+	}
+	resp, err := k.httpClient.Do(req)
+	if err != nil {
+		return domain.HealthDown
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return domain.HealthDown
+	}
+
+	var statusResp KrakenSystemStatusResponse
+	if err := json.NewDecoder(resp.Body).Decode(&statusResp); err != nil {
+		return domain.HealthDown
+	}
+	if errs, _ := splitMessages(statusResp.Error); len(errs) > 0 {
+		return domain.HealthDown
+	}
+	switch statusResp.Result.Status {
+	case "online":
+		return domain.HealthOK
+	case "cancel_only", "post_only":
+		return domain.HealthDegraded
+	default:
+		return domain.HealthDown
+	}
+}
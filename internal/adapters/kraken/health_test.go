@@ -0,0 +1,65 @@
+package kraken
+
+import (
+	"context"
+	"testing"
+
+	"go-exercise/internal/domain"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKrakenClient_CheckHealth(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		expected domain.HealthStatus
+	}{
+		{"online", 200, `{"error":[],"result":{"status":"online","timestamp":"2024-01-01T12:00:00Z"}}`, domain.HealthOK},
+		{"cancel only", 200, `{"error":[],"result":{"status":"cancel_only"}}`, domain.HealthDegraded},
+		{"post only", 200, `{"error":[],"result":{"status":"post_only"}}`, domain.HealthDegraded},
+		{"maintenance", 200, `{"error":[],"result":{"status":"maintenance"}}`, domain.HealthDown},
+		{"api error", 200, `{"error":["EService:Unavailable"],"result":{}}`, domain.HealthDown},
+		{"http error", 500, `{}`, domain.HealthDown},
+		{"malformed body", 200, `not json`, domain.HealthDown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+
+			gock.New("https://api.kraken.com").
+				Get("/0/public/SystemStatus").
+				Reply(tt.status).
+				BodyString(tt.body)
+
+			client := NewKrakenClient("").(*KrakenClient)
+
+			status := client.CheckHealth(context.Background())
+
+			assert.Equal(t, tt.expected, status)
+			assert.Equal(t, "kraken", client.Name())
+			assert.True(t, gock.IsDone())
+		})
+	}
+}
+
+func TestKrakenClient_CheckHealth_SendsUserAgentAndHeaders(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.kraken.com").
+		Get("/0/public/SystemStatus").
+		MatchHeader("User-Agent", "^ltp-service/2\\.3$").
+		MatchHeader("X-Gateway-Token", "^secret$").
+		Reply(200).
+		BodyString(`{"error":[],"result":{"status":"online"}}`)
+
+	client := NewKrakenClient("", WithUserAgent("ltp-service/2.3"), WithHeader("X-Gateway-Token", "secret")).(*KrakenClient)
+
+	status := client.CheckHealth(context.Background())
+
+	assert.Equal(t, domain.HealthOK, status)
+	assert.True(t, gock.IsDone())
+}
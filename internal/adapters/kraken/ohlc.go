@@ -0,0 +1,152 @@
+package kraken
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-exercise/internal/domain"
+	"go-exercise/internal/ports"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Ensure KrakenClient can serve candles
+var _ ports.CandleProvider = (*KrakenClient)(nil)
+
+// KrakenOHLCResponse represents the response from Kraken OHLC API. Next to the candles,
+// keyed by symbol, the result holds a "last" cursor, so entries are decoded per key.
+type KrakenOHLCResponse struct {
+	Error  []string                   `json:"error"`
+	Result map[string]json.RawMessage `json:"result"`
+}
+
+// GetOHLC retrieves the recent candles of a pair for the given interval in minutes, oldest first
+func (k *KrakenClient) GetOHLC(ctx context.Context, pair domain.Pair, interval int) (_ []domain.Candle, err error) {
+	symbol := k.pairToKrakenSymbol(pair)
+	url := fmt.Sprintf("%s/OHLC?pair=%s&interval=%d", k.baseURL(), symbol, interval)
+
+	ctx, span := tracer.Start(ctx, "kraken.GetOHLC", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("url.full", url),
+		attribute.String("kraken.symbol", symbol),
+		attribute.Int("kraken.interval", interval),
+	))
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	ctx, cancel := k.withUpstreamTimeout(ctx)
+	defer cancel()
+
+	req, err := k.newRequest(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build Kraken request: %w", err)
+	}
+
+	resp, err := k.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call Kraken API: %w", err)
+	}
+	defer resp.Body.Close()
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, &domain.RateLimitError{RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("kraken API returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var ohlcResp KrakenOHLCResponse
+	if err := json.Unmarshal(body, &ohlcResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	errs, warnings := splitMessages(ohlcResp.Error)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("kraken API error: %v", errs)
+	}
+	if len(warnings) > 0 {
+		log.Printf("Kraken API warnings for OHLC of %s: %v", symbol, warnings)
+		span.SetAttributes(attribute.StringSlice("kraken.warnings", warnings))
+	}
+
+	if ohlcResp.Result == nil {
+		return nil, fmt.Errorf("%w for pair %s", ErrUpstreamEmptyResult, symbol)
+	}
+	// The cursor is not a symbol; drop it so the single-result fallback still applies
+	delete(ohlcResp.Result, "last")
+
+	raw, foundSymbol, ok := findPairInResult(k, ohlcResp.Result, pair)
+	if !ok {
+		return nil, &NoDataError{Pair: pair, Symbol: symbol}
+	}
+
+	var entries [][]json.RawMessage
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal candles for %s (found as %s): %w", pair.Value(), foundSymbol, err)
+	}
+
+	candles := make([]domain.Candle, len(entries))
+	for i, entry := range entries {
+		candle, err := parseCandle(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid candle %d for %s (found as %s): %w", i, pair.Value(), foundSymbol, err)
+		}
+		candles[i] = candle
+	}
+	return candles, nil
+}
+
+// parseCandle parses a Kraken OHLC entry: [time, open, high, low, close, vwap, volume, count].
+// The time is a number of seconds while the prices and volume are decimal strings.
+func parseCandle(entry []json.RawMessage) (domain.Candle, error) {
+	if len(entry) < 7 {
+		return domain.Candle{}, fmt.Errorf("expected at least 7 fields, got %d", len(entry))
+	}
+
+	var seconds int64
+	if err := json.Unmarshal(entry[0], &seconds); err != nil {
+		return domain.Candle{}, fmt.Errorf("invalid time: %w", err)
+	}
+
+	// open, high, low, close and volume, skipping the vwap at index 5
+	indexes := []int{1, 2, 3, 4, 6}
+	values := make([]float64, len(indexes))
+	for i, index := range indexes {
+		var raw string
+		if err := json.Unmarshal(entry[index], &raw); err != nil {
+			return domain.Candle{}, fmt.Errorf("invalid field %d: %w", index, err)
+		}
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return domain.Candle{}, fmt.Errorf("invalid field %d: %w", index, err)
+		}
+		values[i] = value
+	}
+
+	return domain.Candle{
+		Time:   time.Unix(seconds, 0).UTC(),
+		Open:   values[0],
+		High:   values[1],
+		Low:    values[2],
+		Close:  values[3],
+		Volume: values[4],
+	}, nil
+}
@@ -0,0 +1,107 @@
+package kraken
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go-exercise/internal/domain"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKrakenClient_GetOHLC_Success(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.kraken.com").
+		Get("/0/public/OHLC").
+		MatchParam("pair", "XBTUSD").
+		MatchParam("interval", "5").
+		Reply(200).
+		BodyString(`{"error":[],"result":{"XXBTZUSD":[
+			[1704110400,"42000.1","42100.0","41950.5","42050.2","42030.7","12.34567890",321],
+			[1704110700,"42050.2","42080.0","42010.0","42075.9","42049.3","3.50000000",87]
+		],"last":1704110400}}`)
+
+	client := NewKrakenClient("").(*KrakenClient)
+	pair, _ := domain.NewPair(domain.BTCUSD)
+
+	candles, err := client.GetOHLC(context.Background(), pair, 5)
+
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Candle{
+		{Time: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), Open: 42000.1, High: 42100, Low: 41950.5, Close: 42050.2, Volume: 12.3456789},
+		{Time: time.Date(2024, 1, 1, 12, 5, 0, 0, time.UTC), Open: 42050.2, High: 42080, Low: 42010, Close: 42075.9, Volume: 3.5},
+	}, candles)
+	assert.True(t, gock.IsDone())
+}
+
+func TestKrakenClient_GetOHLC_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		expectedErr string
+	}{
+		{"http error", 500, `{}`, "status 500"},
+		{"api error", 200, `{"error":["EQuery:Unknown asset pair"]}`, "kraken API error"},
+		{"null result", 200, `{"error":[],"result":null}`, "null result"},
+		{"only the cursor", 200, `{"error":[],"result":{"last":1704110400}}`, "no data for pair BTC/USD"},
+		{"other pair", 200, `{"error":[],"result":{"XETHZUSD":[],"last":1704110400}}`, "no data for pair BTC/USD"},
+		{"short entry", 200, `{"error":[],"result":{"XXBTZUSD":[[1704110400,"42000.1"]]}}`, "expected at least 7 fields"},
+		{"numeric price", 200, `{"error":[],"result":{"XXBTZUSD":[[1704110400,42000.1,"1","1","1","1","1",1]]}}`, "invalid field 1"},
+		{"malformed price", 200, `{"error":[],"result":{"XXBTZUSD":[[1704110400,"abc","1","1","1","1","1",1]]}}`, "invalid field 1"},
+		{"malformed time", 200, `{"error":[],"result":{"XXBTZUSD":[["soon","1","1","1","1","1","1",1]]}}`, "invalid time"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+
+			gock.New("https://api.kraken.com").
+				Get("/0/public/OHLC").
+				Reply(tt.status).
+				BodyString(tt.body)
+
+			client := NewKrakenClient("").(*KrakenClient)
+			pair, _ := domain.NewPair(domain.BTCUSD)
+
+			candles, err := client.GetOHLC(context.Background(), pair, 1)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedErr)
+			assert.Nil(t, candles)
+		})
+	}
+}
+
+func TestKrakenClient_GetOHLC_RateLimited(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.kraken.com").
+		Get("/0/public/OHLC").
+		Reply(429)
+
+	client := NewKrakenClient("").(*KrakenClient)
+	pair, _ := domain.NewPair(domain.BTCUSD)
+
+	_, err := client.GetOHLC(context.Background(), pair, 1)
+
+	var rateLimitErr *domain.RateLimitError
+	require.ErrorAs(t, err, &rateLimitErr)
+	assert.Zero(t, rateLimitErr.RetryAfter)
+	assert.True(t, gock.IsDone())
+}
+
+func TestParseCandle_IgnoresExtraFields(t *testing.T) {
+	var entry []json.RawMessage
+	require.NoError(t, json.Unmarshal([]byte(`[1704110400,"1","2","0.5","1.5","1.2","10",5,"extra"]`), &entry))
+
+	candle, err := parseCandle(entry)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.Candle{Time: time.Unix(1704110400, 0).UTC(), Open: 1, High: 2, Low: 0.5, Close: 1.5, Volume: 10}, candle)
+}
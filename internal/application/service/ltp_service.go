@@ -1,60 +1,284 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log"
 	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"go-exercise/internal/domain"
 	"go-exercise/internal/ports"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/singleflight"
 )
 
+// tracer creates the service spans; it is a no-op unless a tracer provider is installed
+var tracer = otel.Tracer("go-exercise/internal/application/service")
+
+// ChangeWindow is how far back LTPOptions.IncludeChange looks for the baseline price
+const ChangeWindow = 24 * time.Hour
+
 // LTPService handles the business logic for LTP operations
 // It implements ports.LTPService interface
 type LTPService struct {
 	repository ports.Repository
 	external   ports.External
+	// supported holds the union of provider-supported pairs; nil disables the check
+	supported map[string]bool
+	// allowStale is the server default for falling back to expired cache entries
+	allowStale bool
+	// pairErrors tracks the pairs whose latest fetch failed
+	pairErrors pairErrors
+	// parseOpts are applied when parsing the requested pairs
+	parseOpts []domain.ParseOption
+	// sla holds the per-pair freshness SLAs checked when requested
+	sla domain.FreshnessSLA
+	// candles serves OHLC candles; nil when the provider has none
+	candles ports.CandleProvider
+	// fetches coalesces concurrent upstream fetches of the same pair set
+	fetches singleflight.Group
+	// providerName names the external price provider for response metadata
+	providerName string
+	// providers holds the providers requests may select by name
+	providers map[string]ports.External
+	// slowThreshold is the upstream call duration above which a warning is logged;
+	// zero disables the check
+	slowThreshold time.Duration
+	// slowUpstream counts the upstream calls that exceeded slowThreshold
+	slowUpstream atomic.Int64
+	// bounds are the plausible price ranges fetched prices are checked against;
+	// nil disables the check
+	bounds domain.PriceBounds
+}
+
+// Option configures optional LTPService behavior
+type Option func(*LTPService)
+
+// WithSupportedPairs makes the service reject pairs that none of the given providers
+// support before attempting any upstream call. The union is computed once, at construction.
+func WithSupportedPairs(providers ...ports.PairSupporter) Option {
+	return func(s *LTPService) {
+		s.supported = make(map[string]bool)
+		for _, provider := range providers {
+			for _, pair := range provider.SupportedPairs() {
+				s.supported[pair.Value()] = true
+			}
+		}
+	}
+}
+
+// WithAllowStale makes the service fall back to expired cache entries, flagged as stale,
+// when the external service fails. Requests can still override it via LTPOptions.AcceptStale.
+func WithAllowStale() Option {
+	return func(s *LTPService) {
+		s.allowStale = true
+	}
+}
+
+// WithSpaceSeparatedPairs makes the service accept whitespace as a pair separator,
+// e.g. "BTC/USD BTC/EUR", in addition to commas
+func WithSpaceSeparatedPairs() Option {
+	return func(s *LTPService) {
+		s.parseOpts = append(s.parseOpts, domain.WithSpaceSeparators())
+	}
+}
+
+// WithCandleProvider enables GetOHLC, served by the given provider
+func WithCandleProvider(provider ports.CandleProvider) Option {
+	return func(s *LTPService) {
+		s.candles = provider
+	}
+}
+
+// WithDefaultPairs sets the pairs served when a request names none, instead of all valid pairs
+func WithDefaultPairs(pairs []domain.Pair) Option {
+	return func(s *LTPService) {
+		s.parseOpts = append(s.parseOpts, domain.WithDefaultPairs(pairs))
+	}
+}
+
+// WithMaxPairs sets the most pairs a single request may name, domain.DefaultMaxPairs
+// unless configured; a non-positive value removes the limit
+func WithMaxPairs(max int) Option {
+	return func(s *LTPService) {
+		s.parseOpts = append(s.parseOpts, domain.WithMaxPairs(max))
+	}
+}
+
+// WithFreshnessSLA sets per-pair maximum data ages, keyed by pair value, that results
+// are checked against when LTPOptions.CheckSLA is set
+func WithFreshnessSLA(sla map[string]time.Duration) Option {
+	return func(s *LTPService) {
+		s.sla = sla
+	}
+}
+
+// WithProviderName names the external price provider (e.g. "kraken") in response metadata
+func WithProviderName(name string) Option {
+	return func(s *LTPService) {
+		s.providerName = name
+	}
+}
+
+// WithProviders makes the given providers, keyed by name, selectable per request via
+// LTPOptions.Provider. The primary provider is selected by its WithProviderName name.
+func WithProviders(providers map[string]ports.External) Option {
+	return func(s *LTPService) {
+		s.providers = providers
+	}
+}
+
+// WithSlowUpstreamThreshold logs a warning with the pairs and duration of every upstream
+// call taking longer than the threshold, and counts those calls
+func WithSlowUpstreamThreshold(threshold time.Duration) Option {
+	return func(s *LTPService) {
+		s.slowThreshold = threshold
+	}
+}
+
+// WithPriceBounds makes the service reject fetched prices that are not positive or lie
+// outside the bounds of their quote currency, so upstream glitches are neither cached
+// nor served. The whole fetch fails with a *domain.PriceBoundsError.
+func WithPriceBounds(bounds domain.PriceBounds) Option {
+	return func(s *LTPService) {
+		s.bounds = bounds
+	}
 }
 
 // Ensure LTPService implements ports.LTPService interface
 var _ ports.LTPService = (*LTPService)(nil)
 
 // NewLTPService creates a new LTP service
-func NewLTPService(repository ports.Repository, external ports.External) *LTPService {
-	return &LTPService{
+func NewLTPService(repository ports.Repository, external ports.External, opts ...Option) *LTPService {
+	s := &LTPService{
 		repository: repository,
 		external:   external,
+		parseOpts:  []domain.ParseOption{domain.WithMaxPairs(domain.DefaultMaxPairs)},
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 // GetLTPs retrieves LTPs for the requested pairs
-// If pairs is empty, returns all valid pairs
-func (s *LTPService) GetLTPs(pairsStr string) ([]domain.LTP, error) {
-	pairs, err := domain.ParsePairs(pairsStr)
+// If pairs is empty, returns the default pairs (all valid pairs unless configured)
+// With opts.ForceRefresh the cache is bypassed and refreshed with the fetched values
+// With opts.Order set to OrderRequested results keep the requested pair order
+// Pairs the provider has no price for are left out of the result
+func (s *LTPService) GetLTPs(ctx context.Context, pairsStr string, opts ports.LTPOptions) (_ []domain.LTP, err error) {
+	ctx, span := tracer.Start(ctx, "LTPService.GetLTPs", trace.WithAttributes(
+		attribute.String("ltp.pairs", pairsStr),
+		attribute.Bool("ltp.force_refresh", opts.ForceRefresh),
+	))
+	defer func() { endSpan(span, err) }()
+
+	pairs, err := s.requestedPairs(pairsStr, opts)
 	if err != nil {
-		return nil, fmt.Errorf("invalid pairs: %w", err)
+		return nil, err
 	}
 
-	// Use map to track which pairs we need to fetch
+	// A single pair with the default options takes the single-pair lookup
+	if len(pairs) == 1 && opts == (ports.LTPOptions{}) {
+		ltp, err := s.getLTP(ctx, span, pairs[0])
+		if errors.Is(err, domain.ErrPriceNotFound) {
+			return []domain.LTP{}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return []domain.LTP{ltp}, nil
+	}
+
+	// A provider other than the primary one neither reads nor fills the cache
+	alternate, err := s.alternateProvider(opts.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fail fast on pairs no provider can serve
+	supported := s.supported
+	if supporter, ok := alternate.(ports.PairSupporter); ok {
+		supported = make(map[string]bool)
+		for _, pair := range supporter.SupportedPairs() {
+			supported[pair.Value()] = true
+		}
+	}
+	if supported != nil {
+		for _, pair := range pairs {
+			if !supported[pair.Value()] {
+				return nil, fmt.Errorf("%w: %s", domain.ErrUnsupportedPair, pair.Value())
+			}
+		}
+	}
+
+	// Use map to track which pairs we need to fetch, and when each value was last updated
 	ltpMap := make(map[string]domain.LTP)
+	updatedAt := make(map[string]time.Time)
 	var pairsToFetch []domain.Pair
 
 	for _, pair := range pairs {
+		if opts.ForceRefresh || alternate != nil {
+			pairsToFetch = append(pairsToFetch, pair)
+			continue
+		}
 		cached, found := s.repository.GetLTP(pair)
 		if found && cached != nil {
-			ltpMap[pair.Value()] = cached.LTP
+			ltp := cached.LTP
+			ltp.Cached = true
+			ltpMap[pair.Value()] = ltp
+			updatedAt[pair.Value()] = cached.Timestamp
 		} else {
 			pairsToFetch = append(pairsToFetch, pair)
 		}
 	}
 
+	span.SetAttributes(attribute.Int("ltp.cache_misses", len(pairsToFetch)))
+
+	// All misses are fetched in a single batch call, so a cold request for the
+	// default pairs costs exactly one upstream round trip
 	if len(pairsToFetch) > 0 {
-		ltps, err := s.external.GetTickers(pairsToFetch)
+		var ltps []domain.LTP
+		if alternate != nil {
+			ltps, err = s.getTickers(ctx, alternate, pairsToFetch)
+			var partial *domain.PartialFetchError
+			if errors.As(err, &partial) {
+				err = nil
+			}
+		} else {
+			ltps, err = s.fetch(ctx, pairsToFetch)
+		}
 		if err != nil {
-			return nil, fmt.Errorf("failed to fetch from external service: %w", err)
+			upstreamErr := fmt.Errorf("%w: failed to fetch from external service: %w", domain.ErrUpstreamUnavailable, err)
+			// The cache only ever holds the primary provider's prices
+			if !s.acceptStale(opts) || alternate != nil {
+				return nil, upstreamErr
+			}
+			stale, ok := s.staleLTPs(pairsToFetch)
+			if !ok {
+				return nil, upstreamErr
+			}
+			ltps = make([]domain.LTP, len(stale))
+			for i, cached := range stale {
+				ltps[i] = cached.LTP
+				updatedAt[cached.LTP.Pair.Value()] = cached.Timestamp
+			}
+		} else {
+			now := time.Now()
+			for _, ltp := range ltps {
+				updatedAt[ltp.Pair.Value()] = now
+			}
 		}
 
 		for _, ltp := range ltps {
-			s.repository.SetLTP(ltp.Pair, ltp)
 			ltpMap[ltp.Pair.Value()] = ltp
 		}
 	}
@@ -63,15 +287,367 @@ func (s *LTPService) GetLTPs(pairsStr string) ([]domain.LTP, error) {
 	result := make([]domain.LTP, 0, len(pairs))
 	for _, pair := range pairs {
 		if ltp, ok := ltpMap[pair.Value()]; ok {
+			ltp.UpdatedAt = updatedAt[pair.Value()]
 			result = append(result, ltp)
 		}
 	}
 
-	// Sort by pair name for consistent output
-	sort.Slice(result, func(i, j int) bool {
-		return result[i].Pair.Value() < result[j].Pair.Value()
-	})
+	if opts.CheckSLA {
+		for i := range result {
+			if ok, applies := s.sla.Check(result[i].Pair, result[i].UpdatedAt); applies {
+				result[i].WithinSLA = &ok
+			}
+		}
+	}
+
+	if opts.IncludeChange {
+		since := time.Now().Add(-ChangeWindow)
+		for i := range result {
+			baseline, ok := s.repository.GetEarliestSince(result[i].Pair, since)
+			if !ok {
+				continue
+			}
+			if change, ok := domain.NewPriceChange(baseline.LTP.Amount, result[i].Amount); ok {
+				result[i].Change24h = &change
+			}
+		}
+	}
+
+	// Sort by pair name for consistent output unless the requested order was asked for
+	if opts.Order != ports.OrderRequested {
+		sort.Slice(result, func(i, j int) bool {
+			return result[i].Pair.Value() < result[j].Pair.Value()
+		})
+	}
 
 	return result, nil
 }
 
+// requestedPairs returns the pairs a request names, or every pair quoted in opts.Quote.
+// The pairs limit only applies to pairs the client listed.
+func (s *LTPService) requestedPairs(pairsStr string, opts ports.LTPOptions) ([]domain.Pair, error) {
+	if opts.Quote == "" {
+		pairs, err := domain.ParsePairs(pairsStr, s.parseOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pairs: %w", err)
+		}
+		return pairs, nil
+	}
+	if pairsStr != "" {
+		return nil, fmt.Errorf("%w: pairs and quote cannot be combined", domain.ErrInvalidPair)
+	}
+	return domain.PairsByQuote(opts.Quote)
+}
+
+// GetLTP retrieves the LTP of a single pair with the default options, from the cache when
+// possible. It fails with domain.ErrPriceNotFound when the provider has no price for the pair.
+func (s *LTPService) GetLTP(ctx context.Context, pairStr string) (_ domain.LTP, err error) {
+	ctx, span := tracer.Start(ctx, "LTPService.GetLTP", trace.WithAttributes(
+		attribute.String("ltp.pairs", pairStr),
+	))
+	defer func() { endSpan(span, err) }()
+
+	pairs, err := domain.ParsePairs(pairStr, s.parseOpts...)
+	if err != nil {
+		return domain.LTP{}, fmt.Errorf("invalid pairs: %w", err)
+	}
+	if len(pairs) != 1 {
+		return domain.LTP{}, fmt.Errorf("invalid pairs: %w: exactly one pair must be specified", domain.ErrInvalidPair)
+	}
+	return s.getLTP(ctx, span, pairs[0])
+}
+
+// getLTP looks up a parsed pair for GetLTP and single-pair GetLTPs calls, recording cache
+// misses on span
+func (s *LTPService) getLTP(ctx context.Context, span trace.Span, pair domain.Pair) (domain.LTP, error) {
+	pairs := []domain.Pair{pair}
+	if s.supported != nil && !s.supported[pair.Value()] {
+		return domain.LTP{}, fmt.Errorf("%w: %s", domain.ErrUnsupportedPair, pair.Value())
+	}
+
+	// The repository coalesces concurrent misses of the pair into a single fetch
+	fetched := false
+	cached, err := s.repository.GetOrFetch(pair, func() (domain.LTP, error) {
+		fetched = true
+		span.SetAttributes(attribute.Int("ltp.cache_misses", 1))
+		ltps, err := s.fetchUpstream(ctx, pairs)
+		if err != nil {
+			return domain.LTP{}, fmt.Errorf("%w: failed to fetch from external service: %w", domain.ErrUpstreamUnavailable, err)
+		}
+		for _, ltp := range ltps {
+			if ltp.Pair == pair {
+				return ltp, nil
+			}
+		}
+		return domain.LTP{}, fmt.Errorf("%w: %s", domain.ErrPriceNotFound, pair.Value())
+	})
+	if err != nil {
+		if !errors.Is(err, domain.ErrUpstreamUnavailable) || !s.allowStale {
+			return domain.LTP{}, err
+		}
+		stale, ok := s.staleLTPs(pairs)
+		if !ok {
+			return domain.LTP{}, err
+		}
+		ltp := stale[0].LTP
+		ltp.UpdatedAt = stale[0].Timestamp
+		return ltp, nil
+	}
+	ltp := cached.LTP
+	ltp.Cached = !fetched
+	ltp.UpdatedAt = cached.Timestamp
+	return ltp, nil
+}
+
+// alternateProvider returns the provider a request selected by name, or nil when it
+// selected none or the primary one
+func (s *LTPService) alternateProvider(name string) (ports.External, error) {
+	if name == "" || name == s.providerName {
+		return nil, nil
+	}
+	if provider, ok := s.providers[name]; ok {
+		return provider, nil
+	}
+	names := make([]string, 0, len(s.providers)+1)
+	if _, ok := s.providers[s.providerName]; !ok && s.providerName != "" {
+		names = append(names, s.providerName)
+	}
+	for providerName := range s.providers {
+		names = append(names, providerName)
+	}
+	sort.Strings(names)
+	return nil, fmt.Errorf("%w: %s. Valid providers are: %s", domain.ErrUnknownProvider, name, strings.Join(names, ", "))
+}
+
+// acceptStale resolves whether expired cache entries may be served for this request
+func (s *LTPService) acceptStale(opts ports.LTPOptions) bool {
+	if opts.AcceptStale != nil {
+		return *opts.AcceptStale
+	}
+	return s.allowStale
+}
+
+// staleLTPs looks up expired cache entries for all given pairs, marking them as stale.
+// It reports false if any pair has no cached value at all.
+func (s *LTPService) staleLTPs(pairs []domain.Pair) ([]domain.CachedLTP, bool) {
+	stale := make([]domain.CachedLTP, 0, len(pairs))
+	for _, pair := range pairs {
+		cached, found := s.repository.GetStaleLTP(pair)
+		if !found || cached == nil {
+			return nil, false
+		}
+		entry := *cached
+		entry.LTP.Stale = true
+		stale = append(stale, entry)
+	}
+	return stale, true
+}
+
+// RefreshLTPs fetches the given pairs from the external service and stores them in the cache
+// regardless of whether a cached value is still valid
+func (s *LTPService) RefreshLTPs(ctx context.Context, pairs []domain.Pair) (err error) {
+	ctx, span := tracer.Start(ctx, "LTPService.RefreshLTPs", trace.WithAttributes(
+		attribute.String("ltp.pairs", domain.CanonicalPairs(pairs)),
+	))
+	defer func() { endSpan(span, err) }()
+
+	if _, err := s.fetch(ctx, pairs); err != nil {
+		return fmt.Errorf("%w: failed to fetch from external service: %w", domain.ErrUpstreamUnavailable, err)
+	}
+
+	return nil
+}
+
+// fetch gets the given pairs from the external service and stores them in the cache.
+// Concurrent fetches of the same pair set share a single upstream call and its result.
+// The shared call ignores the caller's cancellation so one caller going away does not
+// fail the others; the HTTP client timeout still bounds it. A cancelled caller stops
+// waiting for it and gets the context's error.
+func (s *LTPService) fetch(ctx context.Context, pairs []domain.Pair) ([]domain.LTP, error) {
+	results := s.fetches.DoChan(domain.CanonicalPairs(pairs), func() (interface{}, error) {
+		ltps, err := s.fetchUpstream(context.WithoutCancel(ctx), pairs)
+		if err != nil {
+			return nil, err
+		}
+		s.repository.SetLTPs(ltps)
+		return ltps, nil
+	})
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-results:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		return result.Val.([]domain.LTP), nil
+	}
+}
+
+// fetchUpstream gets the given pairs from the primary provider without storing them,
+// recording the outcome as the pairs' upstream status. A partial fetch succeeds with the
+// prices that were fetched.
+func (s *LTPService) fetchUpstream(ctx context.Context, pairs []domain.Pair) ([]domain.LTP, error) {
+	ltps, err := s.getTickers(ctx, s.external, pairs)
+	s.pairErrors.record(pairs, err)
+	// The pairs the provider could not fetch are reported through UpstreamErrors only
+	var partial *domain.PartialFetchError
+	if errors.As(err, &partial) {
+		return ltps, nil
+	}
+	return ltps, err
+}
+
+// getTickers fetches the given pairs from a provider, reporting the call when it is slow
+// and rejecting its prices when any is out of bounds
+func (s *LTPService) getTickers(ctx context.Context, external ports.External, pairs []domain.Pair) ([]domain.LTP, error) {
+	start := time.Now()
+	ltps, err := external.GetTickers(ctx, pairs)
+	if elapsed := time.Since(start); s.slowThreshold > 0 && elapsed > s.slowThreshold {
+		s.slowUpstream.Add(1)
+		log.Printf("WARNING: slow upstream call for %s took %s (threshold %s)", domain.CanonicalPairs(pairs), elapsed, s.slowThreshold)
+	}
+	var partial *domain.PartialFetchError
+	if (err != nil && !errors.As(err, &partial)) || s.bounds == nil {
+		return ltps, err
+	}
+	var boundsErrs []error
+	for _, ltp := range ltps {
+		if err := s.bounds.Check(ltp); err != nil {
+			log.Printf("WARNING: rejected upstream price: %v", err)
+			boundsErrs = append(boundsErrs, err)
+		}
+	}
+	if err := errors.Join(boundsErrs...); err != nil {
+		return nil, err
+	}
+	return ltps, err
+}
+
+// SlowUpstreamTotal counts the upstream calls that exceeded the slow upstream threshold
+func (s *LTPService) SlowUpstreamTotal() int64 {
+	return s.slowUpstream.Load()
+}
+
+// ProviderName names the external price provider, or is empty when not configured
+func (s *LTPService) ProviderName() string {
+	return s.providerName
+}
+
+// GetCacheStats reports statistics about the underlying LTP cache
+func (s *LTPService) GetCacheStats() domain.CacheStats {
+	return s.repository.Stats()
+}
+
+// GetHistory returns up to limit of the newest stored prices of a pair from before the
+// given time (zero for no bound), oldest first, and whether older prices remain
+func (s *LTPService) GetHistory(pairStr string, before time.Time, limit int) (domain.HistoryPage, error) {
+	pair, err := domain.NewPair(pairStr)
+	if err != nil {
+		return domain.HistoryPage{}, err
+	}
+	if s.supported != nil && !s.supported[pair.Value()] {
+		return domain.HistoryPage{}, fmt.Errorf("%w: %s", domain.ErrUnsupportedPair, pair.Value())
+	}
+	return s.repository.GetHistory(pair, before, limit), nil
+}
+
+// GetTWAP returns the time-weighted average price of a pair over the window ending now,
+// computed from its stored history
+func (s *LTPService) GetTWAP(pairStr string, window time.Duration) (float64, error) {
+	history, err := s.GetHistory(pairStr, time.Time{}, 0)
+	if err != nil {
+		return 0, err
+	}
+	return domain.ComputeTWAP(history.Samples, window)
+}
+
+// GetOHLC retrieves the recent candles of a pair for the given interval in minutes, oldest
+// first. Candles are not cached.
+func (s *LTPService) GetOHLC(ctx context.Context, pairStr string, interval int) (_ []domain.Candle, err error) {
+	ctx, span := tracer.Start(ctx, "LTPService.GetOHLC", trace.WithAttributes(
+		attribute.String("ltp.pair", pairStr),
+		attribute.Int("ltp.interval", interval),
+	))
+	defer func() { endSpan(span, err) }()
+
+	pair, err := domain.NewPair(pairStr)
+	if err != nil {
+		return nil, err
+	}
+	if s.supported != nil && !s.supported[pair.Value()] {
+		return nil, fmt.Errorf("%w: %s", domain.ErrUnsupportedPair, pair.Value())
+	}
+	if s.candles == nil {
+		return nil, domain.ErrCandlesUnsupported
+	}
+
+	candles, err := s.candles.GetOHLC(ctx, pair, interval)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to fetch candles from external service: %w", domain.ErrUpstreamUnavailable, err)
+	}
+	return candles, nil
+}
+
+// ValidatePairs reports which entries of a pairs string are valid, without accessing the
+// cache or the external service. Valid pairs no provider can serve are reported as invalid.
+func (s *LTPService) ValidatePairs(pairsStr string) (domain.PairValidation, error) {
+	validation, err := domain.ValidatePairs(pairsStr, s.parseOpts...)
+	if err != nil {
+		return domain.PairValidation{}, fmt.Errorf("invalid pairs: %w", err)
+	}
+	if s.supported == nil {
+		return validation, nil
+	}
+
+	valid := make([]domain.Pair, 0, len(validation.Valid))
+	for _, pair := range validation.Valid {
+		if s.supported[pair.Value()] {
+			valid = append(valid, pair)
+			continue
+		}
+		validation.Invalid = append(validation.Invalid, domain.InvalidPair{
+			Value: pair.Value(),
+			Err:   fmt.Errorf("%w: %s", domain.ErrUnsupportedPair, pair.Value()),
+		})
+	}
+	validation.Valid = valid
+	return validation, nil
+}
+
+// PreloadLTPs stores the given prices in the cache without calling the external service.
+// Storing the same prices again just refreshes their timestamps. Prices are checked
+// against the configured bounds like fetched ones, and none is stored when any fails.
+func (s *LTPService) PreloadLTPs(ltps []domain.LTP) error {
+	if s.bounds != nil {
+		var boundsErrs []error
+		for _, ltp := range ltps {
+			if err := s.bounds.Check(ltp); err != nil {
+				boundsErrs = append(boundsErrs, err)
+			}
+		}
+		if err := errors.Join(boundsErrs...); err != nil {
+			return err
+		}
+	}
+	s.repository.SetLTPs(ltps)
+	return nil
+}
+
+// InvalidateLTP drops the cached price of a single pair, so the next request fetches it
+func (s *LTPService) InvalidateLTP(pair domain.Pair) {
+	s.repository.Invalidate(pair)
+}
+
+// ClearCache drops every cached price
+func (s *LTPService) ClearCache() {
+	s.repository.Clear()
+}
+
+// endSpan records err on the span, if any, and ends it
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
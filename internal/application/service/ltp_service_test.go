@@ -1,14 +1,30 @@
 package service
 
 import (
+	"bytes"
+	"context"
 	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"go-exercise/internal/domain"
+	"go-exercise/internal/ports"
 	"go-exercise/internal/ports/mocks"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 func TestNewLTPService(t *testing.T) {
@@ -44,17 +60,15 @@ func TestLTPService_GetLTPs_EmptyPairs_ReturnsAllPairs(t *testing.T) {
 	repo.On("GetLTP", btcEUR).Return((*domain.CachedLTP)(nil), false)
 
 	// Mock external service
-	external.On("GetTickers", mock.MatchedBy(func(pairs []domain.Pair) bool {
+	external.On("GetTickers", mock.Anything, mock.MatchedBy(func(pairs []domain.Pair) bool {
 		return len(pairs) == 3
 	})).Return(expectedLTPs, nil)
 
-	// Mock repository SetLTP calls
-	repo.On("SetLTP", btcUSD, expectedLTPs[0]).Return()
-	repo.On("SetLTP", btcCHF, expectedLTPs[1]).Return()
-	repo.On("SetLTP", btcEUR, expectedLTPs[2]).Return()
+	// Mock repository batch write
+	repo.On("SetLTPs", expectedLTPs).Return().Once()
 
 	// Act
-	result, err := service.GetLTPs("")
+	result, err := service.GetLTPs(context.Background(), "", ports.LTPOptions{})
 
 	// Assert
 	assert.NoError(t, err)
@@ -77,10 +91,10 @@ func TestLTPService_GetLTPs_SinglePair_FromCache(t *testing.T) {
 	cachedLTP := domain.NewCachedLTP(domain.LTP{Pair: btcUSD, Amount: 52000.12})
 
 	// Mock repository - cached data found
-	repo.On("GetLTP", btcUSD).Return(cachedLTP, true)
+	repo.On("GetOrFetch", btcUSD, mock.Anything).Return(getOrFetch(cachedLTP))
 
 	// Act
-	result, err := service.GetLTPs("BTC/USD")
+	result, err := service.GetLTPs(context.Background(), "BTC/USD", ports.LTPOptions{})
 
 	// Assert
 	assert.NoError(t, err)
@@ -89,7 +103,7 @@ func TestLTPService_GetLTPs_SinglePair_FromCache(t *testing.T) {
 	assert.Equal(t, 52000.12, result[0].Amount)
 
 	repo.AssertExpectations(t)
-	external.AssertNotCalled(t, "GetTickers")
+	external.AssertNotCalled(t, "GetTickers", mock.Anything, mock.Anything)
 }
 
 func TestLTPService_GetLTPs_SinglePair_FromExternal(t *testing.T) {
@@ -102,16 +116,13 @@ func TestLTPService_GetLTPs_SinglePair_FromExternal(t *testing.T) {
 	expectedLTP := domain.LTP{Pair: btcUSD, Amount: 52000.12}
 
 	// Mock repository - no cached data
-	repo.On("GetLTP", btcUSD).Return((*domain.CachedLTP)(nil), false)
+	repo.On("GetOrFetch", btcUSD, mock.Anything).Return(getOrFetch(nil))
 
 	// Mock external service
-	external.On("GetTickers", []domain.Pair{btcUSD}).Return([]domain.LTP{expectedLTP}, nil)
-
-	// Mock repository SetLTP call
-	repo.On("SetLTP", btcUSD, expectedLTP).Return()
+	external.On("GetTickers", mock.Anything, []domain.Pair{btcUSD}).Return([]domain.LTP{expectedLTP}, nil)
 
 	// Act
-	result, err := service.GetLTPs("BTC/USD")
+	result, err := service.GetLTPs(context.Background(), "BTC/USD", ports.LTPOptions{})
 
 	// Assert
 	assert.NoError(t, err)
@@ -123,6 +134,167 @@ func TestLTPService_GetLTPs_SinglePair_FromExternal(t *testing.T) {
 	external.AssertExpectations(t)
 }
 
+func TestLTPService_GetLTPs_SinglePair_PriceNotFound(t *testing.T) {
+	// Arrange
+	repo := new(mocks.Repository)
+	external := new(mocks.External)
+	service := NewLTPService(repo, external)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	repo.On("GetOrFetch", btcUSD, mock.Anything).Return(getOrFetch(nil))
+	external.On("GetTickers", mock.Anything, []domain.Pair{btcUSD}).Return([]domain.LTP{}, nil)
+
+	// Act
+	result, err := service.GetLTPs(context.Background(), "BTC/USD", ports.LTPOptions{})
+
+	// Assert
+	require.NoError(t, err, "a pair without a price is left out like in a batch")
+	assert.Empty(t, result)
+	external.AssertExpectations(t)
+}
+
+func TestLTPService_GetLTP(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+
+	t.Run("cache hit", func(t *testing.T) {
+		// Arrange
+		repo := new(mocks.Repository)
+		external := new(mocks.External)
+		service := NewLTPService(repo, external)
+
+		cached := domain.NewCachedLTP(domain.LTP{Pair: btcUSD, Amount: 52000.12})
+		repo.On("GetOrFetch", btcUSD, mock.Anything).Return(getOrFetch(cached))
+
+		// Act
+		result, err := service.GetLTP(context.Background(), "btc-usd")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, domain.LTP{Pair: btcUSD, Amount: 52000.12, Cached: true, UpdatedAt: cached.Timestamp}, result)
+		external.AssertNotCalled(t, "GetTickers", mock.Anything, mock.Anything)
+	})
+
+	t.Run("external fetch", func(t *testing.T) {
+		// Arrange
+		repo := new(mocks.Repository)
+		external := new(mocks.External)
+		service := NewLTPService(repo, external)
+
+		expectedLTP := domain.LTP{Pair: btcUSD, Amount: 52000.12}
+		repo.On("GetOrFetch", btcUSD, mock.Anything).Return(getOrFetch(nil))
+		external.On("GetTickers", mock.Anything, []domain.Pair{btcUSD}).Return([]domain.LTP{expectedLTP}, nil)
+
+		// Act
+		result, err := service.GetLTP(context.Background(), "BTC/USD")
+
+		// Assert
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now(), result.UpdatedAt, time.Second, "fetched prices are stamped with the time they were stored")
+		result.UpdatedAt = time.Time{}
+		assert.Equal(t, expectedLTP, result)
+		repo.AssertExpectations(t)
+		external.AssertExpectations(t)
+	})
+
+	t.Run("invalid pair", func(t *testing.T) {
+		// Arrange
+		repo := new(mocks.Repository)
+		external := new(mocks.External)
+		service := NewLTPService(repo, external)
+
+		// Act
+		result, err := service.GetLTP(context.Background(), "BTC/INVALID")
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrInvalidPair)
+		assert.Equal(t, domain.LTP{}, result)
+		repo.AssertNotCalled(t, "GetOrFetch", mock.Anything, mock.Anything)
+	})
+
+	t.Run("encoded pair list", func(t *testing.T) {
+		// Arrange
+		repo := new(mocks.Repository)
+		external := new(mocks.External)
+		service := NewLTPService(repo, external)
+
+		// Act
+		_, err := service.GetLTP(context.Background(), "BTC%2FUSD%2CBTC%2FEUR")
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrInvalidPair)
+		assert.Contains(t, err.Error(), "exactly one pair")
+		repo.AssertNotCalled(t, "GetOrFetch", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unsupported pair", func(t *testing.T) {
+		// Arrange
+		repo := new(mocks.Repository)
+		external := new(mocks.External)
+		btcCHF, _ := domain.NewPair(domain.BTCCHF)
+		service := NewLTPService(repo, external, WithSupportedPairs(supportedPairsStub{btcCHF}))
+
+		// Act
+		_, err := service.GetLTP(context.Background(), "BTC/USD")
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrUnsupportedPair)
+		repo.AssertNotCalled(t, "GetOrFetch", mock.Anything, mock.Anything)
+	})
+
+	t.Run("no price from the provider", func(t *testing.T) {
+		// Arrange
+		repo := new(mocks.Repository)
+		external := new(mocks.External)
+		service := NewLTPService(repo, external)
+
+		repo.On("GetOrFetch", btcUSD, mock.Anything).Return(getOrFetch(nil))
+		external.On("GetTickers", mock.Anything, []domain.Pair{btcUSD}).Return([]domain.LTP{}, nil)
+
+		// Act
+		_, err := service.GetLTP(context.Background(), "BTC/USD")
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrPriceNotFound)
+		assert.Contains(t, err.Error(), "BTC/USD")
+	})
+
+	t.Run("upstream down", func(t *testing.T) {
+		// Arrange
+		repo := new(mocks.Repository)
+		external := new(mocks.External)
+		service := NewLTPService(repo, external)
+
+		repo.On("GetOrFetch", btcUSD, mock.Anything).Return(getOrFetch(nil))
+		external.On("GetTickers", mock.Anything, []domain.Pair{btcUSD}).Return(nil, errors.New("connection refused"))
+
+		// Act
+		_, err := service.GetLTP(context.Background(), "BTC/USD")
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrUpstreamUnavailable)
+		repo.AssertNotCalled(t, "GetStaleLTP", mock.Anything)
+	})
+
+	t.Run("upstream down serves stale when allowed", func(t *testing.T) {
+		// Arrange
+		repo := new(mocks.Repository)
+		external := new(mocks.External)
+		service := NewLTPService(repo, external, WithAllowStale())
+
+		repo.On("GetOrFetch", btcUSD, mock.Anything).Return(getOrFetch(nil))
+		external.On("GetTickers", mock.Anything, []domain.Pair{btcUSD}).Return(nil, errors.New("connection refused"))
+		repo.On("GetStaleLTP", btcUSD).Return(domain.NewCachedLTP(domain.LTP{Pair: btcUSD, Amount: 51000}), true)
+
+		// Act
+		result, err := service.GetLTP(context.Background(), "BTC/USD")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 51000.0, result.Amount)
+		assert.True(t, result.Stale)
+	})
+}
+
 func TestLTPService_GetLTPs_MultiplePairs_MixedCache(t *testing.T) {
 	// Arrange
 	repo := new(mocks.Repository)
@@ -140,13 +312,13 @@ func TestLTPService_GetLTPs_MultiplePairs_MixedCache(t *testing.T) {
 	repo.On("GetLTP", btcEUR).Return((*domain.CachedLTP)(nil), false)
 
 	// Mock external service for missing pair
-	external.On("GetTickers", []domain.Pair{btcEUR}).Return([]domain.LTP{expectedLTP}, nil)
+	external.On("GetTickers", mock.Anything, []domain.Pair{btcEUR}).Return([]domain.LTP{expectedLTP}, nil)
 
-	// Mock repository SetLTP call
-	repo.On("SetLTP", btcEUR, expectedLTP).Return()
+	// Mock repository batch write
+	repo.On("SetLTPs", []domain.LTP{expectedLTP}).Return()
 
 	// Act
-	result, err := service.GetLTPs("BTC/USD,BTC/EUR")
+	result, err := service.GetLTPs(context.Background(), "BTC/USD,BTC/EUR", ports.LTPOptions{})
 
 	// Assert
 	assert.NoError(t, err)
@@ -154,6 +326,9 @@ func TestLTPService_GetLTPs_MultiplePairs_MixedCache(t *testing.T) {
 	// Results should be sorted by pair name
 	assert.Equal(t, btcEUR.Value(), result[0].Pair.Value())
 	assert.Equal(t, btcUSD.Value(), result[1].Pair.Value())
+	// Only the value served from the cache is marked as such
+	assert.False(t, result[0].Cached)
+	assert.True(t, result[1].Cached)
 
 	repo.AssertExpectations(t)
 	external.AssertExpectations(t)
@@ -166,15 +341,16 @@ func TestLTPService_GetLTPs_InvalidPair_ReturnsError(t *testing.T) {
 	service := NewLTPService(repo, external)
 
 	// Act
-	result, err := service.GetLTPs("BTC/INVALID")
+	result, err := service.GetLTPs(context.Background(), "BTC/INVALID", ports.LTPOptions{})
 
 	// Assert
 	assert.Error(t, err)
 	assert.Nil(t, result)
 	assert.Contains(t, err.Error(), "invalid pair")
+	assert.ErrorIs(t, err, domain.ErrInvalidPair)
 
 	repo.AssertNotCalled(t, "GetLTP")
-	external.AssertNotCalled(t, "GetTickers")
+	external.AssertNotCalled(t, "GetTickers", mock.Anything, mock.Anything)
 }
 
 func TestLTPService_GetLTPs_ExternalServiceError_ReturnsError(t *testing.T) {
@@ -187,18 +363,20 @@ func TestLTPService_GetLTPs_ExternalServiceError_ReturnsError(t *testing.T) {
 	expectedError := errors.New("external service unavailable")
 
 	// Mock repository - no cached data
-	repo.On("GetLTP", btcUSD).Return((*domain.CachedLTP)(nil), false)
+	repo.On("GetOrFetch", btcUSD, mock.Anything).Return(getOrFetch(nil))
 
 	// Mock external service error
-	external.On("GetTickers", []domain.Pair{btcUSD}).Return(nil, expectedError)
+	external.On("GetTickers", mock.Anything, []domain.Pair{btcUSD}).Return(nil, expectedError)
 
 	// Act
-	result, err := service.GetLTPs("BTC/USD")
+	result, err := service.GetLTPs(context.Background(), "BTC/USD", ports.LTPOptions{})
 
 	// Assert
 	assert.Error(t, err)
 	assert.Nil(t, result)
 	assert.Contains(t, err.Error(), "failed to fetch from external service")
+	assert.ErrorIs(t, err, domain.ErrUpstreamUnavailable)
+	assert.ErrorIs(t, err, expectedError)
 
 	repo.AssertExpectations(t)
 	external.AssertExpectations(t)
@@ -226,15 +404,15 @@ func TestLTPService_GetLTPs_ResultsAreSorted(t *testing.T) {
 		{Pair: btcCHF, Amount: 49000.12},
 	}
 
-	external.On("GetTickers", mock.MatchedBy(func(pairs []domain.Pair) bool {
+	external.On("GetTickers", mock.Anything, mock.MatchedBy(func(pairs []domain.Pair) bool {
 		return len(pairs) == 3
 	})).Return(expectedLTPs, nil)
 
-	// Mock repository SetLTP calls
-	repo.On("SetLTP", mock.Anything, mock.Anything).Return()
+	// Mock repository batch write
+	repo.On("SetLTPs", mock.Anything).Return()
 
 	// Act
-	result, err := service.GetLTPs("BTC/USD,BTC/CHF,BTC/EUR")
+	result, err := service.GetLTPs(context.Background(), "BTC/USD,BTC/CHF,BTC/EUR", ports.LTPOptions{})
 
 	// Assert
 	assert.NoError(t, err)
@@ -247,3 +425,1422 @@ func TestLTPService_GetLTPs_ResultsAreSorted(t *testing.T) {
 	repo.AssertExpectations(t)
 	external.AssertExpectations(t)
 }
+
+func TestLTPService_GetCacheStats(t *testing.T) {
+	// Arrange
+	repo := new(mocks.Repository)
+	external := new(mocks.External)
+	service := NewLTPService(repo, external)
+
+	now := time.Now()
+	expected := domain.CacheStats{Entries: 2, Expired: 1, Oldest: now.Add(-2 * time.Minute), Newest: now}
+	repo.On("Stats").Return(expected)
+
+	// Act
+	stats := service.GetCacheStats()
+
+	// Assert
+	assert.Equal(t, expected, stats)
+
+	repo.AssertExpectations(t)
+	external.AssertNotCalled(t, "GetTickers", mock.Anything, mock.Anything)
+}
+
+func TestLTPService_RefreshLTPs_StoresFreshValues(t *testing.T) {
+	// Arrange
+	repo := new(mocks.Repository)
+	external := new(mocks.External)
+	service := NewLTPService(repo, external)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	expectedLTP := domain.LTP{Pair: btcUSD, Amount: 52000.12}
+
+	external.On("GetTickers", mock.Anything, []domain.Pair{btcUSD}).Return([]domain.LTP{expectedLTP}, nil)
+	repo.On("SetLTPs", []domain.LTP{expectedLTP}).Return()
+
+	// Act
+	err := service.RefreshLTPs(context.Background(), []domain.Pair{btcUSD})
+
+	// Assert
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "GetLTP", mock.Anything)
+	external.AssertExpectations(t)
+}
+
+func TestLTPService_RefreshLTPs_SlowUpstream(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	pairs := []domain.Pair{btcUSD, btcEUR}
+
+	tests := []struct {
+		name      string
+		delay     time.Duration
+		threshold time.Duration
+		slow      bool
+	}{
+		{"slower than the threshold", 30 * time.Millisecond, 10 * time.Millisecond, true},
+		{"faster than the threshold", 0, time.Second, false},
+		{"no threshold", 30 * time.Millisecond, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var buf bytes.Buffer
+			original := log.Writer()
+			log.SetOutput(&buf)
+			defer log.SetOutput(original)
+
+			repo := new(mocks.Repository)
+			external := new(mocks.External)
+			service := NewLTPService(repo, external, WithSlowUpstreamThreshold(tt.threshold))
+
+			ltps := []domain.LTP{{Pair: btcUSD, Amount: 52000.12}, {Pair: btcEUR, Amount: 50000.12}}
+			external.On("GetTickers", mock.Anything, pairs).After(tt.delay).Return(ltps, nil)
+			repo.On("SetLTPs", ltps).Return()
+
+			// Act
+			err := service.RefreshLTPs(context.Background(), pairs)
+
+			// Assert
+			require.NoError(t, err)
+			if tt.slow {
+				assert.Contains(t, buf.String(), "WARNING: slow upstream call for BTC/EUR,BTC/USD took")
+				assert.Equal(t, int64(1), service.SlowUpstreamTotal())
+			} else {
+				assert.NotContains(t, buf.String(), "slow upstream call")
+				assert.Zero(t, service.SlowUpstreamTotal())
+			}
+		})
+	}
+}
+
+func TestLTPService_RefreshLTPs_CancelledWhileFetching(t *testing.T) {
+	// Arrange
+	repo := new(mocks.Repository)
+	external := new(mocks.External)
+	service := NewLTPService(repo, external)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	release := make(chan time.Time)
+	defer close(release)
+	external.On("GetTickers", mock.Anything, []domain.Pair{btcUSD}).WaitUntil(release).Return([]domain.LTP{{Pair: btcUSD, Amount: 52000.12}}, nil)
+	repo.On("SetLTPs", mock.Anything).Return().Maybe()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	// Act
+	err := service.RefreshLTPs(ctx, []domain.Pair{btcUSD})
+
+	// Assert
+	assert.ErrorIs(t, err, context.Canceled, "a cancelled caller stops waiting for the upstream call")
+}
+
+func TestLTPService_RefreshLTPs_ExternalError(t *testing.T) {
+	// Arrange
+	repo := new(mocks.Repository)
+	external := new(mocks.External)
+	service := NewLTPService(repo, external)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	external.On("GetTickers", mock.Anything, []domain.Pair{btcUSD}).Return(nil, errors.New("external service unavailable"))
+
+	// Act
+	err := service.RefreshLTPs(context.Background(), []domain.Pair{btcUSD})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to fetch from external service")
+	repo.AssertNotCalled(t, "SetLTPs", mock.Anything)
+}
+
+// getOrFetch stubs Repository.GetOrFetch, serving cached when set and otherwise storing
+// the result of the fetch
+func getOrFetch(cached *domain.CachedLTP) func(domain.Pair, func() (domain.LTP, error)) (*domain.CachedLTP, error) {
+	return func(_ domain.Pair, fetch func() (domain.LTP, error)) (*domain.CachedLTP, error) {
+		if cached != nil {
+			return cached, nil
+		}
+		ltp, err := fetch()
+		if err != nil {
+			return nil, err
+		}
+		return domain.NewCachedLTP(ltp), nil
+	}
+}
+
+// supportedPairsStub is a ports.PairSupporter returning a fixed set of pairs
+type supportedPairsStub []domain.Pair
+
+func (s supportedPairsStub) SupportedPairs() []domain.Pair {
+	return s
+}
+
+func TestLTPService_GetLTPs_UnsupportedByAllProviders_FailsFast(t *testing.T) {
+	// Arrange
+	repo := new(mocks.Repository)
+	external := new(mocks.External)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcCHF, _ := domain.NewPair(domain.BTCCHF)
+	service := NewLTPService(repo, external, WithSupportedPairs(
+		supportedPairsStub{btcUSD},
+		supportedPairsStub{btcCHF},
+	))
+
+	// Act
+	result, err := service.GetLTPs(context.Background(), "BTC/EUR", ports.LTPOptions{})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrUnsupportedPair)
+	assert.Contains(t, err.Error(), "BTC/EUR")
+	assert.Nil(t, result)
+
+	repo.AssertNotCalled(t, "GetLTP", mock.Anything)
+	external.AssertNotCalled(t, "GetTickers", mock.Anything, mock.Anything)
+}
+
+func TestLTPService_GetLTPs_SupportedByAnyProvider_Proceeds(t *testing.T) {
+	// Arrange
+	repo := new(mocks.Repository)
+	external := new(mocks.External)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcCHF, _ := domain.NewPair(domain.BTCCHF)
+	service := NewLTPService(repo, external, WithSupportedPairs(
+		supportedPairsStub{btcUSD},
+		supportedPairsStub{btcCHF},
+	))
+
+	cachedLTP := domain.NewCachedLTP(domain.LTP{Pair: btcCHF, Amount: 49000.12})
+	repo.On("GetOrFetch", btcCHF, mock.Anything).Return(getOrFetch(cachedLTP))
+
+	// Act
+	result, err := service.GetLTPs(context.Background(), "BTC/CHF", ports.LTPOptions{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	repo.AssertExpectations(t)
+}
+
+func TestLTPService_GetLTPs_SelectedProvider(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+
+	t.Run("alternate provider bypasses the cache", func(t *testing.T) {
+		// Arrange
+		repo := new(mocks.Repository)
+		kraken := new(mocks.External)
+		binance := new(mocks.External)
+		service := NewLTPService(repo, kraken, WithProviderName("kraken"), WithProviders(map[string]ports.External{
+			"kraken":  kraken,
+			"binance": binance,
+		}))
+
+		binance.On("GetTickers", mock.Anything, []domain.Pair{btcUSD, btcEUR}).Return([]domain.LTP{
+			{Pair: btcUSD, Amount: 52010},
+			{Pair: btcEUR, Amount: 50010},
+		}, nil)
+
+		// Act
+		result, err := service.GetLTPs(context.Background(), "BTC/USD,BTC/EUR", ports.LTPOptions{Provider: "binance"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []domain.LTP{{Pair: btcEUR, Amount: 50010}, {Pair: btcUSD, Amount: 52010}}, withoutUpdatedAt(t, result))
+		binance.AssertExpectations(t)
+		kraken.AssertNotCalled(t, "GetTickers", mock.Anything, mock.Anything)
+		repo.AssertNotCalled(t, "GetLTP", mock.Anything)
+		repo.AssertNotCalled(t, "SetLTPs", mock.Anything)
+	})
+
+	t.Run("primary provider by name uses the cache", func(t *testing.T) {
+		// Arrange
+		repo := new(mocks.Repository)
+		kraken := new(mocks.External)
+		binance := new(mocks.External)
+		service := NewLTPService(repo, kraken, WithProviderName("kraken"), WithProviders(map[string]ports.External{
+			"kraken":  kraken,
+			"binance": binance,
+		}))
+
+		repo.On("GetLTP", btcUSD).Return(domain.NewCachedLTP(domain.LTP{Pair: btcUSD, Amount: 52000}), true)
+
+		// Act
+		result, err := service.GetLTPs(context.Background(), "BTC/USD", ports.LTPOptions{Provider: "kraken"})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.True(t, result[0].Cached)
+		repo.AssertExpectations(t)
+		kraken.AssertNotCalled(t, "GetTickers", mock.Anything, mock.Anything)
+		binance.AssertNotCalled(t, "GetTickers", mock.Anything, mock.Anything)
+	})
+
+	t.Run("alternate provider failure never serves stale primary prices", func(t *testing.T) {
+		// Arrange
+		repo := new(mocks.Repository)
+		kraken := new(mocks.External)
+		binance := new(mocks.External)
+		service := NewLTPService(repo, kraken, WithProviderName("kraken"), WithAllowStale(), WithProviders(map[string]ports.External{
+			"kraken":  kraken,
+			"binance": binance,
+		}))
+
+		binance.On("GetTickers", mock.Anything, []domain.Pair{btcUSD}).Return(nil, errors.New("connection refused"))
+
+		// Act
+		result, err := service.GetLTPs(context.Background(), "BTC/USD", ports.LTPOptions{Provider: "binance"})
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrUpstreamUnavailable)
+		assert.Nil(t, result)
+		repo.AssertNotCalled(t, "GetStaleLTP", mock.Anything)
+	})
+
+	t.Run("alternate provider's supported pairs apply", func(t *testing.T) {
+		// Arrange
+		repo := new(mocks.Repository)
+		kraken := new(mocks.External)
+		binance := struct {
+			*mocks.External
+			supportedPairsStub
+		}{new(mocks.External), supportedPairsStub{btcUSD}}
+		service := NewLTPService(repo, kraken, WithProviderName("kraken"), WithProviders(map[string]ports.External{
+			"kraken":  kraken,
+			"binance": binance,
+		}))
+
+		// Act
+		_, err := service.GetLTPs(context.Background(), "BTC/EUR", ports.LTPOptions{Provider: "binance"})
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrUnsupportedPair)
+		binance.AssertNotCalled(t, "GetTickers", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		// Arrange
+		repo := new(mocks.Repository)
+		kraken := new(mocks.External)
+		service := NewLTPService(repo, kraken, WithProviderName("kraken"), WithProviders(map[string]ports.External{
+			"kraken":  kraken,
+			"binance": new(mocks.External),
+		}))
+
+		// Act
+		result, err := service.GetLTPs(context.Background(), "BTC/USD", ports.LTPOptions{Provider: "coinbase"})
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrUnknownProvider)
+		assert.Contains(t, err.Error(), "Valid providers are: binance, kraken")
+		assert.Nil(t, result)
+		repo.AssertNotCalled(t, "GetLTP", mock.Anything)
+	})
+}
+
+// countingExternal is a ports.External stub that counts upstream calls
+type countingExternal struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (c *countingExternal) GetTicker(ctx context.Context, pair domain.Pair) (domain.LTP, error) {
+	ltps, err := c.GetTickers(ctx, []domain.Pair{pair})
+	if err != nil {
+		return domain.LTP{}, err
+	}
+	return ltps[0], nil
+}
+
+func (c *countingExternal) GetTickers(_ context.Context, pairs []domain.Pair) ([]domain.LTP, error) {
+	c.mu.Lock()
+	c.calls++
+	c.mu.Unlock()
+
+	ltps := make([]domain.LTP, len(pairs))
+	for i, pair := range pairs {
+		ltps[i] = domain.LTP{Pair: pair, Amount: 50000}
+	}
+	return ltps, nil
+}
+
+func (c *countingExternal) Calls() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls
+}
+
+func TestLTPService_GetLTPs_ConcurrentMissesShareOneUpstreamCall(t *testing.T) {
+	// Arrange
+	const callers = 10
+	repo := new(mocks.Repository)
+	external := new(mocks.External)
+	service := NewLTPService(repo, external)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	var looked sync.WaitGroup
+	looked.Add(callers)
+	repo.On("GetLTP", btcUSD).Return((*domain.CachedLTP)(nil), false)
+	repo.On("GetLTP", btcEUR).Return((*domain.CachedLTP)(nil), false).Run(func(_ mock.Arguments) {
+		looked.Done()
+	})
+	repo.On("SetLTPs", mock.Anything).Return().Once()
+
+	release := make(chan struct{})
+	external.On("GetTickers", mock.Anything, []domain.Pair{btcUSD, btcEUR}).
+		Return([]domain.LTP{{Pair: btcUSD, Amount: 52000.12}, {Pair: btcEUR, Amount: 50000.12}}, nil).
+		Run(func(_ mock.Arguments) { <-release }).
+		Once()
+
+	// Act
+	var wg sync.WaitGroup
+	results := make([][]domain.LTP, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = service.GetLTPs(context.Background(), "BTC/USD,BTC/EUR", ports.LTPOptions{})
+		}(i)
+	}
+	// Let every caller miss the cache and join the in-flight fetch before it completes
+	looked.Wait()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	// Assert
+	for i := 0; i < callers; i++ {
+		require.NoError(t, errs[i])
+		require.Len(t, results[i], 2)
+		assert.Equal(t, 50000.12, results[i][0].Amount)
+		assert.Equal(t, 52000.12, results[i][1].Amount)
+	}
+	external.AssertNumberOfCalls(t, "GetTickers", 1)
+	repo.AssertNumberOfCalls(t, "SetLTPs", 1)
+}
+
+func TestLTPService_GetLTPs_ColdDefaultPairs_SingleUpstreamCall(t *testing.T) {
+	// Arrange
+	repo := new(mocks.Repository)
+	external := &countingExternal{}
+	service := NewLTPService(repo, external)
+
+	repo.On("GetLTP", mock.Anything).Return((*domain.CachedLTP)(nil), false)
+	repo.On("SetLTPs", mock.Anything).Return()
+
+	// Act
+	result, err := service.GetLTPs(context.Background(), "", ports.LTPOptions{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result, 3)
+	assert.Equal(t, 1, external.Calls(), "cold all-pairs request must be a single batch upstream call")
+}
+
+func TestLTPService_GetLTPs_ForceRefresh_BypassesCache(t *testing.T) {
+	// Arrange
+	repo := new(mocks.Repository)
+	external := new(mocks.External)
+	service := NewLTPService(repo, external)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	freshLTP := domain.LTP{Pair: btcUSD, Amount: 53000.00}
+
+	// A valid cached value exists but must not be consulted
+	repo.On("GetLTP", btcUSD).Return(domain.NewCachedLTP(domain.LTP{Pair: btcUSD, Amount: 52000.12}), true)
+	external.On("GetTickers", mock.Anything, []domain.Pair{btcUSD}).Return([]domain.LTP{freshLTP}, nil)
+	repo.On("SetLTPs", []domain.LTP{freshLTP}).Return()
+
+	// Act
+	result, err := service.GetLTPs(context.Background(), "BTC/USD", ports.LTPOptions{ForceRefresh: true})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, 53000.00, result[0].Amount)
+
+	repo.AssertNotCalled(t, "GetLTP", btcUSD)
+	repo.AssertCalled(t, "SetLTPs", []domain.LTP{freshLTP})
+	external.AssertExpectations(t)
+}
+
+func TestLTPService_GetLTPs_NoForceRefresh_UsesCache(t *testing.T) {
+	// Arrange
+	repo := new(mocks.Repository)
+	external := new(mocks.External)
+	service := NewLTPService(repo, external)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	repo.On("GetOrFetch", btcUSD, mock.Anything).Return(getOrFetch(domain.NewCachedLTP(domain.LTP{Pair: btcUSD, Amount: 52000.12})))
+
+	// Act
+	result, err := service.GetLTPs(context.Background(), "BTC/USD", ports.LTPOptions{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, 52000.12, result[0].Amount)
+
+	external.AssertNotCalled(t, "GetTickers", mock.Anything, mock.Anything)
+}
+
+func TestLTPService_GetLTPs_OrderRequested_PreservesInputOrder(t *testing.T) {
+	// Arrange
+	repo := new(mocks.Repository)
+	external := new(mocks.External)
+	service := NewLTPService(repo, external)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcCHF, _ := domain.NewPair(domain.BTCCHF)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+
+	repo.On("GetLTP", btcUSD).Return(domain.NewCachedLTP(domain.LTP{Pair: btcUSD, Amount: 52000.12}), true)
+	repo.On("GetLTP", btcEUR).Return((*domain.CachedLTP)(nil), false)
+	repo.On("GetLTP", btcCHF).Return((*domain.CachedLTP)(nil), false)
+
+	// External returns in a different order than requested
+	external.On("GetTickers", mock.Anything, []domain.Pair{btcEUR, btcCHF}).Return([]domain.LTP{
+		{Pair: btcCHF, Amount: 49000.12},
+		{Pair: btcEUR, Amount: 50000.12},
+	}, nil)
+	repo.On("SetLTPs", mock.Anything).Return()
+
+	// Act
+	result, err := service.GetLTPs(context.Background(), "BTC/USD,BTC/EUR,BTC/CHF", ports.LTPOptions{Order: ports.OrderRequested})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result, 3)
+	assert.Equal(t, btcUSD.Value(), result[0].Pair.Value())
+	assert.Equal(t, btcEUR.Value(), result[1].Pair.Value())
+	assert.Equal(t, btcCHF.Value(), result[2].Pair.Value())
+
+	repo.AssertExpectations(t)
+	external.AssertExpectations(t)
+}
+
+func TestLTPService_GetLTPs_DifferentlyCasedDuplicates(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+
+	tests := []struct {
+		name     string
+		pairs    string
+		order    ports.Order
+		fetched  []domain.Pair
+		expected []domain.Pair
+	}{
+		{"single result", "BTC/USD,btc/usd", ports.OrderRequested, []domain.Pair{btcUSD}, []domain.Pair{btcUSD}},
+		{"requested order keeps the first occurrence", "btc/eur,BTC/USD,BTC/EUR,btc/usd", ports.OrderRequested, []domain.Pair{btcEUR, btcUSD}, []domain.Pair{btcEUR, btcUSD}},
+		{"sorted order", "btc/usd,BTC/EUR,BTC/USD", ports.OrderSorted, []domain.Pair{btcUSD, btcEUR}, []domain.Pair{btcEUR, btcUSD}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			repo := new(mocks.Repository)
+			external := new(mocks.External)
+			service := NewLTPService(repo, external)
+
+			repo.On("GetLTP", mock.Anything).Return((*domain.CachedLTP)(nil), false)
+			repo.On("SetLTPs", mock.Anything).Return()
+			// Each pair is fetched once, in first-occurrence order
+			external.On("GetTickers", mock.Anything, tt.fetched).Return(func(_ context.Context, pairs []domain.Pair) ([]domain.LTP, error) {
+				ltps := make([]domain.LTP, len(pairs))
+				for i, pair := range pairs {
+					ltps[i] = domain.LTP{Pair: pair, Amount: 50000}
+				}
+				return ltps, nil
+			}).Once()
+
+			// Act
+			result, err := service.GetLTPs(context.Background(), tt.pairs, ports.LTPOptions{Order: tt.order})
+
+			// Assert
+			require.NoError(t, err)
+			pairs := make([]domain.Pair, len(result))
+			for i, ltp := range result {
+				pairs[i] = ltp.Pair
+			}
+			assert.Equal(t, tt.expected, pairs)
+			external.AssertExpectations(t)
+			repo.AssertNumberOfCalls(t, "GetLTP", len(tt.expected))
+		})
+	}
+}
+
+func TestLTPService_GetLTPs_OrderSorted_SortsByPair(t *testing.T) {
+	// Arrange
+	repo := new(mocks.Repository)
+	external := new(mocks.External)
+	service := NewLTPService(repo, external)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+
+	repo.On("GetLTP", btcUSD).Return(domain.NewCachedLTP(domain.LTP{Pair: btcUSD, Amount: 52000.12}), true)
+	repo.On("GetLTP", btcEUR).Return(domain.NewCachedLTP(domain.LTP{Pair: btcEUR, Amount: 50000.12}), true)
+
+	// Act
+	result, err := service.GetLTPs(context.Background(), "BTC/USD,BTC/EUR", ports.LTPOptions{Order: ports.OrderSorted})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, btcEUR.Value(), result[0].Pair.Value())
+	assert.Equal(t, btcUSD.Value(), result[1].Pair.Value())
+}
+
+func TestLTPService_GetLTPs_UpstreamDown_AcceptStale_ServesExpiredEntry(t *testing.T) {
+	// Arrange
+	repo := new(mocks.Repository)
+	external := new(mocks.External)
+	service := NewLTPService(repo, external)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	expired := &domain.CachedLTP{
+		LTP:       domain.LTP{Pair: btcUSD, Amount: 51000.00},
+		Timestamp: time.Now().Add(-time.Hour),
+	}
+
+	repo.On("GetLTP", btcUSD).Return((*domain.CachedLTP)(nil), false)
+	repo.On("GetStaleLTP", btcUSD).Return(expired, true)
+	external.On("GetTickers", mock.Anything, []domain.Pair{btcUSD}).Return(nil, errors.New("external service unavailable"))
+
+	acceptStale := true
+
+	// Act
+	result, err := service.GetLTPs(context.Background(), "BTC/USD", ports.LTPOptions{AcceptStale: &acceptStale})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, 51000.00, result[0].Amount)
+	assert.True(t, result[0].Stale)
+
+	repo.AssertNotCalled(t, "SetLTPs", mock.Anything)
+}
+
+func TestLTPService_GetLTPs_UpstreamDown_RejectStale_ReturnsError(t *testing.T) {
+	// Arrange
+	repo := new(mocks.Repository)
+	external := new(mocks.External)
+	service := NewLTPService(repo, external)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+
+	repo.On("GetLTP", btcUSD).Return((*domain.CachedLTP)(nil), false)
+	external.On("GetTickers", mock.Anything, []domain.Pair{btcUSD}).Return(nil, errors.New("external service unavailable"))
+
+	acceptStale := false
+
+	// Act
+	result, err := service.GetLTPs(context.Background(), "BTC/USD", ports.LTPOptions{AcceptStale: &acceptStale})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrUpstreamUnavailable)
+	assert.Nil(t, result)
+
+	repo.AssertNotCalled(t, "GetStaleLTP", mock.Anything)
+}
+
+func TestLTPService_GetLTPs_UpstreamDown_AcceptStale_MissingPairStillErrors(t *testing.T) {
+	// Arrange
+	repo := new(mocks.Repository)
+	external := new(mocks.External)
+	service := NewLTPService(repo, external)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+
+	repo.On("GetLTP", btcUSD).Return((*domain.CachedLTP)(nil), false)
+	repo.On("GetStaleLTP", btcUSD).Return((*domain.CachedLTP)(nil), false)
+	external.On("GetTickers", mock.Anything, []domain.Pair{btcUSD}).Return(nil, errors.New("external service unavailable"))
+
+	acceptStale := true
+
+	// Act
+	result, err := service.GetLTPs(context.Background(), "BTC/USD", ports.LTPOptions{AcceptStale: &acceptStale})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrUpstreamUnavailable)
+	assert.Nil(t, result)
+}
+
+func TestLTPService_GetLTPs_UpstreamDown_AllowStaleDefault(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	expired := &domain.CachedLTP{
+		LTP:       domain.LTP{Pair: btcUSD, Amount: 51000.00},
+		Timestamp: time.Now().Add(-time.Hour),
+	}
+
+	t.Run("serves expired entry without a per-request flag", func(t *testing.T) {
+		// Arrange
+		repo := new(mocks.Repository)
+		external := new(mocks.External)
+		service := NewLTPService(repo, external, WithAllowStale())
+
+		repo.On("GetOrFetch", btcUSD, mock.Anything).Return(getOrFetch(nil))
+		repo.On("GetStaleLTP", btcUSD).Return(expired, true)
+		external.On("GetTickers", mock.Anything, []domain.Pair{btcUSD}).Return(nil, errors.New("external service unavailable"))
+
+		// Act
+		result, err := service.GetLTPs(context.Background(), "BTC/USD", ports.LTPOptions{})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.True(t, result[0].Stale)
+	})
+
+	t.Run("request can opt out", func(t *testing.T) {
+		// Arrange
+		repo := new(mocks.Repository)
+		external := new(mocks.External)
+		service := NewLTPService(repo, external, WithAllowStale())
+
+		repo.On("GetLTP", btcUSD).Return((*domain.CachedLTP)(nil), false)
+		external.On("GetTickers", mock.Anything, []domain.Pair{btcUSD}).Return(nil, errors.New("external service unavailable"))
+
+		acceptStale := false
+
+		// Act
+		_, err := service.GetLTPs(context.Background(), "BTC/USD", ports.LTPOptions{AcceptStale: &acceptStale})
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrUpstreamUnavailable)
+		repo.AssertNotCalled(t, "GetStaleLTP", mock.Anything)
+	})
+}
+
+func TestLTPService_GetLTPs_SpaceSeparatedPairs(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+
+	t.Run("rejected by default", func(t *testing.T) {
+		// Arrange
+		repo := new(mocks.Repository)
+		external := new(mocks.External)
+		service := NewLTPService(repo, external)
+
+		// Act
+		result, err := service.GetLTPs(context.Background(), "BTC/USD BTC/EUR", ports.LTPOptions{})
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrInvalidPair)
+		assert.Nil(t, result)
+		external.AssertNotCalled(t, "GetTickers", mock.Anything, mock.Anything)
+	})
+
+	t.Run("surrounding spaces are still trimmed by default", func(t *testing.T) {
+		// Arrange
+		repo := new(mocks.Repository)
+		external := new(mocks.External)
+		service := NewLTPService(repo, external)
+
+		cachedAt := time.Now()
+		repo.On("GetOrFetch", btcUSD, mock.Anything).Return(getOrFetch(&domain.CachedLTP{LTP: domain.LTP{Pair: btcUSD, Amount: 52000.12}, Timestamp: cachedAt}))
+
+		// Act
+		result, err := service.GetLTPs(context.Background(), "  BTC/USD ", ports.LTPOptions{})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []domain.LTP{{Pair: btcUSD, Amount: 52000.12, Cached: true, UpdatedAt: cachedAt}}, result)
+	})
+
+	t.Run("split on spaces and commas when enabled", func(t *testing.T) {
+		// Arrange
+		repo := new(mocks.Repository)
+		external := new(mocks.External)
+		service := NewLTPService(repo, external, WithSpaceSeparatedPairs())
+
+		repo.On("GetLTP", mock.Anything).Return((*domain.CachedLTP)(nil), false)
+		repo.On("SetLTPs", mock.Anything)
+		external.On("GetTickers", mock.Anything, []domain.Pair{btcUSD, btcEUR}).Return([]domain.LTP{
+			{Pair: btcUSD, Amount: 52000.12},
+			{Pair: btcEUR, Amount: 50000.12},
+		}, nil)
+
+		// Act
+		result, err := service.GetLTPs(context.Background(), "BTC/USD  BTC/EUR,\tBTC/USD", ports.LTPOptions{})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []domain.LTP{
+			{Pair: btcEUR, Amount: 50000.12},
+			{Pair: btcUSD, Amount: 52000.12},
+		}, withoutUpdatedAt(t, result))
+		external.AssertExpectations(t)
+	})
+}
+
+func TestLTPService_GetLTPs_DefaultPairs(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+
+	t.Run("empty request returns the configured defaults", func(t *testing.T) {
+		// Arrange
+		repo := new(mocks.Repository)
+		service := NewLTPService(repo, new(mocks.External), WithDefaultPairs([]domain.Pair{btcEUR}))
+
+		cachedAt := time.Now()
+		repo.On("GetOrFetch", btcEUR, mock.Anything).Return(getOrFetch(&domain.CachedLTP{
+			LTP:       domain.LTP{Pair: btcEUR, Amount: 50000.12},
+			Timestamp: cachedAt,
+		}))
+
+		// Act
+		result, err := service.GetLTPs(context.Background(), "", ports.LTPOptions{})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []domain.LTP{{Pair: btcEUR, Amount: 50000.12, Cached: true, UpdatedAt: cachedAt}}, result)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("explicit pairs are not limited to the defaults", func(t *testing.T) {
+		// Arrange
+		repo := new(mocks.Repository)
+		service := NewLTPService(repo, new(mocks.External), WithDefaultPairs([]domain.Pair{btcEUR}))
+
+		cachedAt := time.Now()
+		repo.On("GetOrFetch", btcUSD, mock.Anything).Return(getOrFetch(&domain.CachedLTP{
+			LTP:       domain.LTP{Pair: btcUSD, Amount: 52000.12},
+			Timestamp: cachedAt,
+		}))
+
+		// Act
+		result, err := service.GetLTPs(context.Background(), domain.BTCUSD, ports.LTPOptions{})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []domain.LTP{{Pair: btcUSD, Amount: 52000.12, Cached: true, UpdatedAt: cachedAt}}, result)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestLTPService_GetLTPs_TooManyPairs(t *testing.T) {
+	// Arrange
+	repo := new(mocks.Repository)
+	external := new(mocks.External)
+	service := NewLTPService(repo, external, WithMaxPairs(2))
+
+	// Act
+	result, err := service.GetLTPs(context.Background(), "BTC/USD,BTC/EUR,BTC/CHF", ports.LTPOptions{})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrTooManyPairs)
+	assert.Nil(t, result)
+	repo.AssertNotCalled(t, "GetLTP", mock.Anything)
+	external.AssertNotCalled(t, "GetTickers", mock.Anything, mock.Anything)
+}
+
+func TestLTPService_GetLTPs_DefaultMaxPairs(t *testing.T) {
+	// Arrange
+	service := NewLTPService(new(mocks.Repository), new(mocks.External))
+	segments := make([]string, domain.DefaultMaxPairs+1)
+	for i := range segments {
+		segments[i] = fmt.Sprintf("BTC/X%d", i)
+	}
+
+	// Act
+	_, err := service.GetLTPs(context.Background(), strings.Join(segments, ","), ports.LTPOptions{})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrTooManyPairs)
+}
+
+func TestLTPService_GetLTPs_Quote(t *testing.T) {
+	t.Cleanup(func() {
+		require.NoError(t, domain.SetValidPairs([]string{domain.BTCUSD, domain.BTCCHF, domain.BTCEUR}))
+	})
+	require.NoError(t, domain.SetValidPairs([]string{domain.BTCUSD, "ETH/USD", domain.BTCEUR}))
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	ethUSD, _ := domain.NewPair("ETH/USD")
+
+	t.Run("serves every pair quoted in the currency regardless of the pairs limit", func(t *testing.T) {
+		// Arrange
+		repo := new(mocks.Repository)
+		external := new(mocks.External)
+		service := NewLTPService(repo, external, WithMaxPairs(1))
+
+		repo.On("GetLTP", mock.Anything).Return(nil, false)
+		fetched := []domain.LTP{{Pair: btcUSD, Amount: 52000.12}, {Pair: ethUSD, Amount: 2500.5}}
+		external.On("GetTickers", mock.Anything, []domain.Pair{btcUSD, ethUSD}).Return(fetched, nil)
+		repo.On("SetLTPs", fetched).Return()
+
+		// Act
+		result, err := service.GetLTPs(context.Background(), "", ports.LTPOptions{Quote: "usd"})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		assert.Equal(t, btcUSD, result[0].Pair)
+		assert.Equal(t, ethUSD, result[1].Pair)
+	})
+
+	t.Run("cannot be combined with pairs", func(t *testing.T) {
+		service := NewLTPService(new(mocks.Repository), new(mocks.External))
+
+		_, err := service.GetLTPs(context.Background(), domain.BTCEUR, ports.LTPOptions{Quote: "USD"})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidPair)
+	})
+
+	t.Run("rejects an unknown currency", func(t *testing.T) {
+		service := NewLTPService(new(mocks.Repository), new(mocks.External))
+
+		_, err := service.GetLTPs(context.Background(), "", ports.LTPOptions{Quote: "JPY"})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidCurrency)
+	})
+}
+
+func TestLTPService_GetHistory(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+
+	t.Run("returns repository history", func(t *testing.T) {
+		// Arrange
+		repo := new(mocks.Repository)
+		external := new(mocks.External)
+		service := NewLTPService(repo, external)
+
+		before := time.Now()
+		history := domain.HistoryPage{
+			Samples: []domain.CachedLTP{
+				{LTP: domain.LTP{Pair: btcUSD, Amount: 51000}, Timestamp: before.Add(-2 * time.Minute)},
+				{LTP: domain.LTP{Pair: btcUSD, Amount: 52000}, Timestamp: before.Add(-time.Minute)},
+			},
+			More: true,
+		}
+		repo.On("GetHistory", btcUSD, before, 10).Return(history)
+
+		// Act
+		result, err := service.GetHistory("btc/usd", before, 10)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, history, result)
+		external.AssertNotCalled(t, "GetTickers", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid pair", func(t *testing.T) {
+		// Arrange
+		repo := new(mocks.Repository)
+		service := NewLTPService(repo, new(mocks.External))
+
+		// Act
+		result, err := service.GetHistory("BTC/INVALID", time.Time{}, 10)
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrInvalidPair)
+		assert.Empty(t, result.Samples)
+		repo.AssertNotCalled(t, "GetHistory", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("unsupported pair", func(t *testing.T) {
+		// Arrange
+		repo := new(mocks.Repository)
+		service := NewLTPService(repo, new(mocks.External), WithSupportedPairs(supportedPairsStub{btcUSD}))
+
+		// Act
+		result, err := service.GetHistory(domain.BTCEUR, time.Time{}, 10)
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrUnsupportedPair)
+		assert.Empty(t, result.Samples)
+	})
+}
+
+func TestLTPService_GetLTPs_RecordsSpan(t *testing.T) {
+	// Arrange
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+
+	repo := new(mocks.Repository)
+	external := new(mocks.External)
+	service := NewLTPService(repo, external)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	repo.On("GetOrFetch", btcUSD, mock.Anything).Return(getOrFetch(nil))
+	external.On("GetTickers", mock.Anything, []domain.Pair{btcUSD}).Return(nil, errors.New("kraken API returned status 503"))
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+
+	// Act
+	_, err := service.GetLTPs(ctx, domain.BTCUSD, ports.LTPOptions{})
+	parent.End()
+
+	// Assert
+	require.Error(t, err)
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	span := spans[0]
+	assert.Equal(t, "LTPService.GetLTPs", span.Name())
+	assert.Equal(t, parent.SpanContext().TraceID(), span.SpanContext().TraceID())
+	assert.Equal(t, codes.Error, span.Status().Code)
+	assert.Contains(t, span.Attributes(), attribute.String("ltp.pairs", domain.BTCUSD))
+	assert.Contains(t, span.Attributes(), attribute.Int("ltp.cache_misses", 1))
+
+	// The context handed to the external client carries the service span
+	ctxArg := external.Calls[0].Arguments.Get(0).(context.Context)
+	assert.Equal(t, span.SpanContext().SpanID(), trace.SpanContextFromContext(ctxArg).SpanID())
+}
+
+func TestLTPService_GetLTPs_CheckSLA(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	btcCHF, _ := domain.NewPair(domain.BTCCHF)
+
+	sla := map[string]time.Duration{
+		domain.BTCUSD: 30 * time.Second,
+		domain.BTCEUR: time.Minute,
+	}
+
+	newService := func() (*LTPService, *mocks.Repository) {
+		repo := new(mocks.Repository)
+		repo.On("GetLTP", btcUSD).Return(&domain.CachedLTP{
+			LTP:       domain.LTP{Pair: btcUSD, Amount: 52000.12},
+			Timestamp: time.Now().Add(-10 * time.Second),
+		}, true)
+		repo.On("GetLTP", btcEUR).Return(&domain.CachedLTP{
+			LTP:       domain.LTP{Pair: btcEUR, Amount: 50000.12},
+			Timestamp: time.Now().Add(-2 * time.Minute),
+		}, true)
+		repo.On("GetLTP", btcCHF).Return(&domain.CachedLTP{
+			LTP:       domain.LTP{Pair: btcCHF, Amount: 49000.12},
+			Timestamp: time.Now().Add(-time.Hour),
+		}, true)
+		return NewLTPService(repo, new(mocks.External), WithFreshnessSLA(sla)), repo
+	}
+
+	t.Run("reports per-pair SLA compliance when requested", func(t *testing.T) {
+		// Arrange
+		service, _ := newService()
+
+		// Act
+		result, err := service.GetLTPs(context.Background(), "", ports.LTPOptions{CheckSLA: true})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, result, 3)
+		byPair := make(map[string]*bool)
+		for _, ltp := range result {
+			byPair[ltp.Pair.Value()] = ltp.WithinSLA
+		}
+		require.NotNil(t, byPair[domain.BTCUSD])
+		assert.True(t, *byPair[domain.BTCUSD])
+		require.NotNil(t, byPair[domain.BTCEUR])
+		assert.False(t, *byPair[domain.BTCEUR])
+		// No SLA is configured for BTC/CHF
+		assert.Nil(t, byPair[domain.BTCCHF])
+	})
+
+	t.Run("not evaluated unless requested", func(t *testing.T) {
+		// Arrange
+		service, _ := newService()
+
+		// Act
+		result, err := service.GetLTPs(context.Background(), "", ports.LTPOptions{})
+
+		// Assert
+		require.NoError(t, err)
+		for _, ltp := range result {
+			assert.Nil(t, ltp.WithinSLA)
+		}
+	})
+
+	t.Run("stale fallback keeps the original age", func(t *testing.T) {
+		// Arrange
+		repo := new(mocks.Repository)
+		external := new(mocks.External)
+		service := NewLTPService(repo, external, WithFreshnessSLA(sla))
+		acceptStale := true
+
+		repo.On("GetLTP", btcUSD).Return((*domain.CachedLTP)(nil), false)
+		repo.On("GetStaleLTP", btcUSD).Return(&domain.CachedLTP{
+			LTP:       domain.LTP{Pair: btcUSD, Amount: 51000},
+			Timestamp: time.Now().Add(-time.Hour),
+		}, true)
+		external.On("GetTickers", mock.Anything, []domain.Pair{btcUSD}).Return(nil, errors.New("external service unavailable"))
+
+		// Act
+		result, err := service.GetLTPs(context.Background(), domain.BTCUSD, ports.LTPOptions{CheckSLA: true, AcceptStale: &acceptStale})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.True(t, result[0].Stale)
+		require.NotNil(t, result[0].WithinSLA)
+		assert.False(t, *result[0].WithinSLA)
+	})
+}
+
+func TestLTPService_GetLTPs_IncludeChange(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+
+	newService := func() (*LTPService, *mocks.Repository) {
+		repo := new(mocks.Repository)
+		repo.On("GetLTP", btcUSD).Return(&domain.CachedLTP{
+			LTP:       domain.LTP{Pair: btcUSD, Amount: 52000},
+			Timestamp: time.Now(),
+		}, true)
+		repo.On("GetLTP", btcEUR).Return(&domain.CachedLTP{
+			LTP:       domain.LTP{Pair: btcEUR, Amount: 50000},
+			Timestamp: time.Now(),
+		}, true)
+		return NewLTPService(repo, new(mocks.External)), repo
+	}
+	withinWindow := mock.MatchedBy(func(since time.Time) bool {
+		return time.Since(since) >= ChangeWindow && time.Since(since) < ChangeWindow+time.Minute
+	})
+
+	t.Run("computes the change against the oldest sample of the window", func(t *testing.T) {
+		// Arrange
+		service, repo := newService()
+		repo.On("GetEarliestSince", btcUSD, withinWindow).Return(domain.CachedLTP{
+			LTP:       domain.LTP{Pair: btcUSD, Amount: 50000},
+			Timestamp: time.Now().Add(-23 * time.Hour),
+		}, true)
+		repo.On("GetEarliestSince", btcEUR, withinWindow).Return(domain.CachedLTP{}, false)
+
+		// Act
+		result, err := service.GetLTPs(context.Background(), "BTC/USD,BTC/EUR", ports.LTPOptions{IncludeChange: true})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		// BTC/EUR has no history in the window
+		assert.Nil(t, result[0].Change24h)
+		require.NotNil(t, result[1].Change24h)
+		assert.Equal(t, 2000.0, result[1].Change24h.Amount)
+		assert.InDelta(t, 4.0, result[1].Change24h.Percent, 1e-9)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("not computed unless requested", func(t *testing.T) {
+		// Arrange
+		service, repo := newService()
+
+		// Act
+		result, err := service.GetLTPs(context.Background(), "BTC/USD,BTC/EUR", ports.LTPOptions{})
+
+		// Assert
+		require.NoError(t, err)
+		for _, ltp := range result {
+			assert.Nil(t, ltp.Change24h)
+		}
+		repo.AssertNotCalled(t, "GetEarliestSince", mock.Anything, mock.Anything)
+	})
+}
+
+func TestLTPService_GetLTPs_PriceBounds(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	bounds := domain.PriceBounds{"USD": {Min: 1000, Max: 1000000}}
+
+	tests := []struct {
+		name        string
+		ltps        []domain.LTP
+		expectedErr string
+	}{
+		{
+			name:        "zero price",
+			ltps:        []domain.LTP{{Pair: btcUSD, Amount: 52000.12}, {Pair: btcEUR, Amount: 0}},
+			expectedErr: "BTC/EUR at 0, expected a positive price",
+		},
+		{
+			name:        "price above the maximum",
+			ltps:        []domain.LTP{{Pair: btcUSD, Amount: 5200012}, {Pair: btcEUR, Amount: 50000.12}},
+			expectedErr: "BTC/USD at 5.200012e+06, expected between 1000 and 1e+06",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var buf bytes.Buffer
+			original := log.Writer()
+			log.SetOutput(&buf)
+			defer log.SetOutput(original)
+
+			repo := new(mocks.Repository)
+			external := new(mocks.External)
+			service := NewLTPService(repo, external, WithPriceBounds(bounds))
+
+			repo.On("GetLTP", mock.Anything).Return(nil, false)
+			external.On("GetTickers", mock.Anything, []domain.Pair{btcUSD, btcEUR}).Return(tt.ltps, nil)
+
+			// Act
+			result, err := service.GetLTPs(context.Background(), "BTC/USD,BTC/EUR", ports.LTPOptions{})
+
+			// Assert
+			require.Error(t, err)
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, domain.ErrUpstreamUnavailable)
+			assert.ErrorIs(t, err, domain.ErrPriceOutOfBounds)
+			var boundsErr *domain.PriceBoundsError
+			require.ErrorAs(t, err, &boundsErr)
+			assert.Contains(t, err.Error(), tt.expectedErr)
+			assert.Contains(t, buf.String(), "WARNING: rejected upstream price")
+			repo.AssertNotCalled(t, "SetLTPs", mock.Anything)
+		})
+	}
+}
+
+func TestLTPService_GetLTPs_PriceBounds_WithinBounds(t *testing.T) {
+	// Arrange
+	repo := new(mocks.Repository)
+	external := new(mocks.External)
+	service := NewLTPService(repo, external, WithPriceBounds(domain.PriceBounds{"USD": {Min: 1000, Max: 1000000}}))
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	ltps := []domain.LTP{{Pair: btcUSD, Amount: 52000.12}}
+	repo.On("GetOrFetch", btcUSD, mock.Anything).Return(getOrFetch(nil))
+	external.On("GetTickers", mock.Anything, []domain.Pair{btcUSD}).Return(ltps, nil)
+
+	// Act
+	result, err := service.GetLTPs(context.Background(), "BTC/USD", ports.LTPOptions{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, ltps, withoutUpdatedAt(t, result))
+	repo.AssertExpectations(t)
+}
+
+func TestLTPService_GetOHLC(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	candles := []domain.Candle{{Time: time.Unix(1704110400, 0).UTC(), Open: 1, High: 2, Low: 0.5, Close: 1.5, Volume: 10}}
+
+	t.Run("delegates to the candle provider", func(t *testing.T) {
+		// Arrange
+		provider := new(mocks.CandleProvider)
+		service := NewLTPService(new(mocks.Repository), new(mocks.External), WithCandleProvider(provider))
+		provider.On("GetOHLC", mock.Anything, btcUSD, 5).Return(candles, nil)
+
+		// Act
+		result, err := service.GetOHLC(context.Background(), domain.BTCUSD, 5)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, candles, result)
+		provider.AssertExpectations(t)
+	})
+
+	t.Run("provider failures are upstream errors", func(t *testing.T) {
+		// Arrange
+		provider := new(mocks.CandleProvider)
+		service := NewLTPService(new(mocks.Repository), new(mocks.External), WithCandleProvider(provider))
+		provider.On("GetOHLC", mock.Anything, btcUSD, 1).Return(nil, errors.New("timeout"))
+
+		// Act
+		_, err := service.GetOHLC(context.Background(), domain.BTCUSD, 1)
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrUpstreamUnavailable)
+	})
+
+	t.Run("without a candle provider", func(t *testing.T) {
+		// Arrange
+		service := NewLTPService(new(mocks.Repository), new(mocks.External))
+
+		// Act
+		_, err := service.GetOHLC(context.Background(), domain.BTCUSD, 1)
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrCandlesUnsupported)
+	})
+
+	t.Run("invalid pair", func(t *testing.T) {
+		// Arrange
+		provider := new(mocks.CandleProvider)
+		service := NewLTPService(new(mocks.Repository), new(mocks.External), WithCandleProvider(provider))
+
+		// Act
+		_, err := service.GetOHLC(context.Background(), "BTC/XYZ", 1)
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrInvalidPair)
+		provider.AssertNotCalled(t, "GetOHLC", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestLTPService_PreloadLTPs(t *testing.T) {
+	// Arrange
+	repo := new(mocks.Repository)
+	external := new(mocks.External)
+	service := NewLTPService(repo, external)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	ltps := []domain.LTP{
+		{Pair: btcEUR, Amount: 50000.12},
+		{Pair: btcUSD, Amount: 52000.12},
+	}
+	repo.On("SetLTPs", ltps).Once()
+
+	// Act
+	err := service.PreloadLTPs(ltps)
+
+	// Assert
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+	external.AssertNotCalled(t, "GetTickers", mock.Anything, mock.Anything)
+}
+
+func TestLTPService_PreloadLTPs_OutOfBounds(t *testing.T) {
+	// Arrange
+	repo := new(mocks.Repository)
+	service := NewLTPService(repo, new(mocks.External), WithPriceBounds(domain.PriceBounds{"USD": {Min: 1000}}))
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+
+	// Act
+	err := service.PreloadLTPs([]domain.LTP{
+		{Pair: btcEUR, Amount: 50000.12},
+		{Pair: btcUSD, Amount: 5},
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrPriceOutOfBounds)
+	assert.Contains(t, err.Error(), "BTC/USD at 5")
+	repo.AssertNotCalled(t, "SetLTPs", mock.Anything)
+}
+
+func TestLTPService_ValidatePairs(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+
+	t.Run("reports invalid and unsupported pairs without side effects", func(t *testing.T) {
+		// Arrange
+		repo := new(mocks.Repository)
+		external := new(mocks.External)
+		service := NewLTPService(repo, external, WithSupportedPairs(supportedPairsStub{btcUSD}))
+
+		// Act
+		validation, err := service.ValidatePairs("BTC/USD,BTC/INVALID,BTC/EUR")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []domain.Pair{btcUSD}, validation.Valid)
+		require.Len(t, validation.Invalid, 2)
+		assert.Equal(t, "BTC/INVALID", validation.Invalid[0].Value)
+		assert.ErrorIs(t, validation.Invalid[0].Err, domain.ErrInvalidPair)
+		assert.Equal(t, btcEUR.Value(), validation.Invalid[1].Value)
+		assert.ErrorIs(t, validation.Invalid[1].Err, domain.ErrUnsupportedPair)
+
+		repo.AssertNotCalled(t, "GetLTP", mock.Anything)
+		external.AssertNotCalled(t, "GetTickers", mock.Anything, mock.Anything)
+	})
+
+	t.Run("applies the configured maximum", func(t *testing.T) {
+		// Arrange
+		service := NewLTPService(new(mocks.Repository), new(mocks.External), WithMaxPairs(1))
+
+		// Act
+		_, err := service.ValidatePairs("BTC/USD,BTC/EUR")
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrTooManyPairs)
+	})
+}
+
+func TestLTPService_GetTWAP(t *testing.T) {
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+
+	t.Run("computes TWAP from the full history", func(t *testing.T) {
+		// Arrange
+		repo := new(mocks.Repository)
+		service := NewLTPService(repo, new(mocks.External))
+
+		repo.On("GetHistory", btcUSD, time.Time{}, 0).Return(domain.HistoryPage{Samples: []domain.CachedLTP{
+			{LTP: domain.LTP{Pair: btcUSD, Amount: 52000}, Timestamp: time.Now().Add(-10 * time.Minute)},
+		}})
+
+		// Act
+		twap, err := service.GetTWAP(domain.BTCUSD, 5*time.Minute)
+
+		// Assert
+		require.NoError(t, err)
+		assert.InDelta(t, 52000, twap, 1e-6)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("insufficient history", func(t *testing.T) {
+		// Arrange
+		repo := new(mocks.Repository)
+		service := NewLTPService(repo, new(mocks.External))
+
+		repo.On("GetHistory", btcUSD, time.Time{}, 0).Return(domain.HistoryPage{})
+
+		// Act
+		_, err := service.GetTWAP(domain.BTCUSD, 5*time.Minute)
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrInsufficientHistory)
+	})
+
+	t.Run("invalid pair", func(t *testing.T) {
+		// Arrange
+		repo := new(mocks.Repository)
+		service := NewLTPService(repo, new(mocks.External))
+
+		// Act
+		_, err := service.GetTWAP("BTC/INVALID", 5*time.Minute)
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrInvalidPair)
+		repo.AssertNotCalled(t, "GetHistory", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestLTPService_InvalidateLTP(t *testing.T) {
+	// Arrange
+	repo := new(mocks.Repository)
+	service := NewLTPService(repo, new(mocks.External))
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	repo.On("Invalidate", btcUSD).Once()
+
+	// Act
+	service.InvalidateLTP(btcUSD)
+
+	// Assert
+	repo.AssertExpectations(t)
+}
+
+func TestLTPService_ClearCache(t *testing.T) {
+	// Arrange
+	repo := new(mocks.Repository)
+	service := NewLTPService(repo, new(mocks.External))
+	repo.On("Clear").Once()
+
+	// Act
+	service.ClearCache()
+
+	// Assert
+	repo.AssertExpectations(t)
+}
+
+// withoutUpdatedAt checks that every fetched LTP is stamped with a recent fetch time and
+// clears it, so results can be compared with the provider's values
+func withoutUpdatedAt(t *testing.T, ltps []domain.LTP) []domain.LTP {
+	t.Helper()
+	cleared := make([]domain.LTP, len(ltps))
+	for i, ltp := range ltps {
+		assert.WithinDuration(t, time.Now(), ltp.UpdatedAt, time.Second, "fetched prices are stamped with the fetch time")
+		ltp.UpdatedAt = time.Time{}
+		cleared[i] = ltp
+	}
+	return cleared
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"go-exercise/internal/domain"
+)
+
+// pairErrors holds, by pair value, the last error of the pairs whose latest fetch failed.
+// The zero value is ready to use.
+type pairErrors struct {
+	mu   sync.Mutex
+	errs map[string]domain.PairError
+}
+
+// record remembers err as the last error of every fetched pair, or forgets their errors
+// when the fetch succeeded. A failed call is attributed to all of its pairs, as the
+// provider may not tell which of them caused it, unless it is a partial fetch naming the
+// pairs that failed.
+func (p *pairErrors) record(pairs []domain.Pair, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.errs == nil {
+		p.errs = make(map[string]domain.PairError)
+	}
+	var partial *domain.PartialFetchError
+	isPartial := errors.As(err, &partial)
+	now := time.Now()
+	for _, pair := range pairs {
+		pairErr := err
+		if isPartial {
+			pairErr = partial.Failed[pair]
+		}
+		if pairErr == nil {
+			delete(p.errs, pair.Value())
+			continue
+		}
+		p.errs[pair.Value()] = domain.PairError{Pair: pair.Value(), Error: pairErr.Error(), At: now}
+	}
+}
+
+// list returns the recorded errors sorted by pair
+func (p *pairErrors) list() []domain.PairError {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	errs := make([]domain.PairError, 0, len(p.errs))
+	for _, pairErr := range p.errs {
+		errs = append(errs, pairErr)
+	}
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Pair < errs[j].Pair })
+	return errs
+}
+
+// UpstreamErrors returns the last error of each pair whose latest fetch from the external
+// service failed, sorted by pair
+func (s *LTPService) UpstreamErrors() []domain.PairError {
+	return s.pairErrors.list()
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-exercise/internal/domain"
+	"go-exercise/internal/ports"
+	"go-exercise/internal/ports/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLTPService_UpstreamErrors(t *testing.T) {
+	// Arrange
+	repo := new(mocks.Repository)
+	external := new(mocks.External)
+	service := NewLTPService(repo, external)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	btcCHF, _ := domain.NewPair(domain.BTCCHF)
+	upstreamErr := errors.New("no data found for symbol BTC/USD")
+	external.On("GetTickers", mock.Anything, []domain.Pair{btcUSD, btcEUR}).Return(nil, upstreamErr).Once()
+	external.On("GetTickers", mock.Anything, []domain.Pair{btcCHF}).Return(nil, errors.New("timeout")).Once()
+	fresh := domain.LTP{Pair: btcUSD, Amount: 52000.12}
+	external.On("GetTickers", mock.Anything, []domain.Pair{btcUSD}).Return([]domain.LTP{fresh}, nil).Once()
+	repo.On("SetLTPs", []domain.LTP{fresh}).Return()
+
+	// Act
+	before := time.Now()
+	assert.Error(t, service.RefreshLTPs(context.Background(), []domain.Pair{btcUSD, btcEUR}))
+	assert.Error(t, service.RefreshLTPs(context.Background(), []domain.Pair{btcCHF}))
+	failing := service.UpstreamErrors()
+	require.NoError(t, service.RefreshLTPs(context.Background(), []domain.Pair{btcUSD}))
+	recovered := service.UpstreamErrors()
+
+	// Assert
+	require.Len(t, failing, 3)
+	assert.Equal(t, domain.BTCCHF, failing[0].Pair)
+	assert.Equal(t, "timeout", failing[0].Error)
+	assert.Equal(t, domain.BTCEUR, failing[1].Pair)
+	assert.Equal(t, domain.BTCUSD, failing[2].Pair)
+	assert.Equal(t, upstreamErr.Error(), failing[2].Error)
+	assert.False(t, failing[2].At.Before(before))
+
+	require.Len(t, recovered, 2, "a successful fetch clears the error of its pairs only")
+	assert.Equal(t, domain.BTCCHF, recovered[0].Pair)
+	assert.Equal(t, domain.BTCEUR, recovered[1].Pair)
+	external.AssertExpectations(t)
+}
+
+func TestLTPService_UpstreamErrors_RecordedByGetLTPs(t *testing.T) {
+	// Arrange
+	repo := new(mocks.Repository)
+	external := new(mocks.External)
+	service := NewLTPService(repo, external)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	repo.On("GetOrFetch", btcUSD, mock.Anything).Return(getOrFetch(nil))
+	external.On("GetTickers", mock.Anything, []domain.Pair{btcUSD}).Return(nil, errors.New("kraken API returned status 500"))
+
+	// Act
+	_, err := service.GetLTPs(context.Background(), domain.BTCUSD, ports.LTPOptions{})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrUpstreamUnavailable)
+	pairErrs := service.UpstreamErrors()
+	require.Len(t, pairErrs, 1)
+	assert.Equal(t, domain.BTCUSD, pairErrs[0].Pair)
+	assert.Equal(t, "kraken API returned status 500", pairErrs[0].Error)
+}
+
+func TestLTPService_UpstreamErrors_PartialFetch(t *testing.T) {
+	// Arrange
+	repo := new(mocks.Repository)
+	external := new(mocks.External)
+	service := NewLTPService(repo, external)
+
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	repo.On("GetLTP", mock.Anything).Return(nil, false)
+	fresh := domain.LTP{Pair: btcUSD, Amount: 52000.12}
+	partial := &domain.PartialFetchError{Failed: map[domain.Pair]error{btcEUR: errors.New("kraken API returned status 500")}}
+	external.On("GetTickers", mock.Anything, []domain.Pair{btcEUR, btcUSD}).Return([]domain.LTP{fresh}, partial)
+	repo.On("SetLTPs", []domain.LTP{fresh}).Return()
+
+	// Act
+	ltps, err := service.GetLTPs(context.Background(), domain.BTCEUR+","+domain.BTCUSD, ports.LTPOptions{})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, ltps, 1)
+	assert.Equal(t, btcUSD, ltps[0].Pair)
+	pairErrs := service.UpstreamErrors()
+	require.Len(t, pairErrs, 1, "only the pairs the provider failed to fetch are reported")
+	assert.Equal(t, domain.BTCEUR, pairErrs[0].Pair)
+	assert.Equal(t, "kraken API returned status 500", pairErrs[0].Error)
+}
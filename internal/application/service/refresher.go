@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"go-exercise/internal/domain"
+	"go-exercise/internal/ports"
+)
+
+// DefaultRefresherFailureThreshold is how many consecutive refresh failures degrade the
+// refresher unless configured otherwise
+const DefaultRefresherFailureThreshold = 3
+
+// Ensure Refresher can be registered as a readiness check and queried for its status
+var (
+	_ ports.HealthChecker           = (*Refresher)(nil)
+	_ ports.RefresherStatusReporter = (*Refresher)(nil)
+)
+
+// Refresher keeps the cache warm by periodically refreshing pairs through the service
+// and tracks the outcome of those refreshes
+type Refresher struct {
+	service          ports.LTPService
+	pairs            []domain.Pair
+	interval         time.Duration
+	failureThreshold int
+
+	mu     sync.RWMutex
+	status domain.RefresherStatus
+}
+
+// RefresherOption configures optional Refresher behavior
+type RefresherOption func(*Refresher)
+
+// WithFailureThreshold sets how many consecutive failures degrade the refresher
+func WithFailureThreshold(n int) RefresherOption {
+	return func(r *Refresher) {
+		r.failureThreshold = n
+	}
+}
+
+// NewRefresher creates a refresher for the given pairs. It does nothing until started.
+func NewRefresher(service ports.LTPService, pairs []domain.Pair, interval time.Duration, opts ...RefresherOption) *Refresher {
+	r := &Refresher{
+		service:          service,
+		pairs:            pairs,
+		interval:         interval,
+		failureThreshold: DefaultRefresherFailureThreshold,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// StartRefresher keeps the cache warm by refreshing the given pairs through the service
+// once immediately and then on every interval tick. It runs in its own goroutine until
+// ctx is cancelled and returns a channel that is closed once the refresher has stopped.
+// When the price provider rate limits a refresh, ticks are skipped until its suggested
+// wait has passed.
+func StartRefresher(ctx context.Context, service ports.LTPService, pairs []domain.Pair, interval time.Duration) <-chan struct{} {
+	return NewRefresher(service, pairs, interval).Start(ctx)
+}
+
+// Start runs the refresher as described by StartRefresher
+func (r *Refresher) Start(ctx context.Context) <-chan struct{} {
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		var pausedUntil time.Time
+		refresh := func() {
+			if time.Now().Before(pausedUntil) {
+				return
+			}
+			err := r.service.RefreshLTPs(ctx, r.pairs)
+			if ctx.Err() != nil {
+				// Stopped mid-refresh; the outcome says nothing about the upstream
+				return
+			}
+			r.record(err)
+			if err == nil {
+				return
+			}
+			var rateLimitErr *domain.RateLimitError
+			if errors.As(err, &rateLimitErr) && rateLimitErr.RetryAfter > 0 {
+				pausedUntil = time.Now().Add(rateLimitErr.RetryAfter)
+				log.Printf("Failed to refresh LTPs, pausing refreshes for %s: %v", rateLimitErr.RetryAfter, err)
+				return
+			}
+			log.Printf("Failed to refresh LTPs: %v", err)
+		}
+
+		refresh()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				refresh()
+			}
+		}
+	}()
+
+	return done
+}
+
+// record updates the status with the outcome of a refresh
+func (r *Refresher) record(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err == nil {
+		if r.status.Degraded {
+			log.Printf("LTP refreshes recovered after %d consecutive failures", r.status.ConsecutiveFailures)
+		}
+		r.status = domain.RefresherStatus{LastSuccess: time.Now()}
+		return
+	}
+	r.status.ConsecutiveFailures++
+	r.status.LastError = err.Error()
+	if !r.status.Degraded && r.status.ConsecutiveFailures >= r.failureThreshold {
+		r.status.Degraded = true
+		log.Printf("LTP refreshes degraded after %d consecutive failures", r.status.ConsecutiveFailures)
+	}
+}
+
+// RefresherStatus returns a snapshot of the refresher's recent outcomes
+func (r *Refresher) RefresherStatus() domain.RefresherStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.status
+}
+
+// Name identifies the refresher in readiness responses
+func (r *Refresher) Name() string {
+	return "refresher"
+}
+
+// CheckHealth reports the refresher as degraded once its failures reach the threshold.
+// Prices can still be fetched on demand, so it is never reported down.
+func (r *Refresher) CheckHealth(_ context.Context) domain.HealthStatus {
+	if r.RefresherStatus().Degraded {
+		return domain.HealthDegraded
+	}
+	return domain.HealthOK
+}
+
+// RefresherController runs at most one refresher at a time and replaces it when its
+// settings are reloaded. It reports the status and health of the running refresher.
+type RefresherController struct {
+	service ports.LTPService
+	opts    []RefresherOption
+
+	mu        sync.Mutex
+	refresher *Refresher
+	stop      context.CancelFunc
+	done      <-chan struct{}
+}
+
+// Ensure RefresherController can stand in for the refresher it runs
+var (
+	_ ports.HealthChecker           = (*RefresherController)(nil)
+	_ ports.RefresherStatusReporter = (*RefresherController)(nil)
+)
+
+// NewRefresherController creates a controller whose refreshers use the given options.
+// No refresher runs until Reload is called.
+func NewRefresherController(service ports.LTPService, opts ...RefresherOption) *RefresherController {
+	return &RefresherController{service: service, opts: opts}
+}
+
+// Reload replaces the running refresher, if any, with one for the given pairs and
+// interval, and waits for the previous refresher's goroutine to exit before returning. A
+// non-positive interval leaves no refresher running. The new refresher starts with a
+// clean status.
+func (c *RefresherController) Reload(pairs []domain.Pair, interval time.Duration) {
+	c.mu.Lock()
+	done := c.stopLocked()
+	if interval > 0 {
+		ctx, stop := context.WithCancel(context.Background())
+		c.refresher = NewRefresher(c.service, pairs, interval, c.opts...)
+		c.stop = stop
+		c.done = c.refresher.Start(ctx)
+	}
+	c.mu.Unlock()
+
+	// Waiting outside the lock keeps status and health checks from being held up by
+	// the previous refresher's in-flight refresh
+	if done != nil {
+		<-done
+	}
+}
+
+// Stop stops the running refresher, if any, and waits for its goroutine to exit
+func (c *RefresherController) Stop() {
+	c.mu.Lock()
+	done := c.stopLocked()
+	c.mu.Unlock()
+
+	if done != nil {
+		<-done
+	}
+}
+
+// stopLocked cancels and detaches the running refresher without waiting for it, returning
+// the channel closed once its goroutine exits; nil when none runs. Callers must hold mu.
+func (c *RefresherController) stopLocked() <-chan struct{} {
+	if c.stop == nil {
+		return nil
+	}
+	c.stop()
+	done := c.done
+	c.refresher, c.stop, c.done = nil, nil, nil
+	return done
+}
+
+// current returns the running refresher, or nil when none runs
+func (c *RefresherController) current() *Refresher {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.refresher
+}
+
+// RefresherStatus returns the status of the running refresher; zero when none runs
+func (c *RefresherController) RefresherStatus() domain.RefresherStatus {
+	if r := c.current(); r != nil {
+		return r.RefresherStatus()
+	}
+	return domain.RefresherStatus{}
+}
+
+// Name identifies the refresher in readiness responses
+func (c *RefresherController) Name() string {
+	return "refresher"
+}
+
+// CheckHealth reports the health of the running refresher; ok when none runs
+func (c *RefresherController) CheckHealth(ctx context.Context) domain.HealthStatus {
+	if r := c.current(); r != nil {
+		return r.CheckHealth(ctx)
+	}
+	return domain.HealthOK
+}
@@ -0,0 +1,310 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go-exercise/internal/domain"
+	"go-exercise/internal/ports/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartRefresher_RefreshesPeriodically(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	pairs := []domain.Pair{btcUSD}
+
+	var calls atomic.Int32
+	ltpService.On("RefreshLTPs", mock.Anything, pairs).Run(func(_ mock.Arguments) {
+		calls.Add(1)
+	}).Return(nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Act
+	done := StartRefresher(ctx, ltpService, pairs, 10*time.Millisecond)
+
+	// Assert
+	require.Eventually(t, func() bool {
+		return calls.Load() >= 3
+	}, time.Second, 5*time.Millisecond)
+
+	cancel()
+	<-done
+	ltpService.AssertCalled(t, "RefreshLTPs", mock.Anything, pairs)
+}
+
+func TestStartRefresher_StopsOnContextCancel(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	pairs := []domain.Pair{btcUSD}
+
+	var calls atomic.Int32
+	ltpService.On("RefreshLTPs", mock.Anything, pairs).Run(func(_ mock.Arguments) {
+		calls.Add(1)
+	}).Return(nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Act
+	done := StartRefresher(ctx, ltpService, pairs, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		return calls.Load() >= 1
+	}, time.Second, 5*time.Millisecond)
+	cancel()
+
+	// Assert
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("refresher did not stop after context cancel")
+	}
+
+	stoppedAt := calls.Load()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, stoppedAt, calls.Load())
+}
+
+func TestStartRefresher_KeepsRunningOnError(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	pairs := []domain.Pair{btcUSD}
+
+	var calls atomic.Int32
+	ltpService.On("RefreshLTPs", mock.Anything, pairs).Run(func(_ mock.Arguments) {
+		calls.Add(1)
+	}).Return(errors.New("upstream down"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Act
+	done := StartRefresher(ctx, ltpService, pairs, 10*time.Millisecond)
+
+	// Assert
+	require.Eventually(t, func() bool {
+		return calls.Load() >= 2
+	}, time.Second, 5*time.Millisecond)
+
+	cancel()
+	<-done
+}
+
+func TestStartRefresher_PausesWhileRateLimited(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	pairs := []domain.Pair{btcUSD}
+
+	var calls atomic.Int32
+	ltpService.On("RefreshLTPs", mock.Anything, pairs).Run(func(_ mock.Arguments) {
+		calls.Add(1)
+	}).Return(&domain.RateLimitError{RetryAfter: 150 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Act
+	done := StartRefresher(ctx, ltpService, pairs, 10*time.Millisecond)
+
+	// Assert
+	require.Eventually(t, func() bool {
+		return calls.Load() >= 1
+	}, time.Second, 5*time.Millisecond)
+	time.Sleep(80 * time.Millisecond)
+	assert.Equal(t, int32(1), calls.Load(), "ticks within the suggested wait should be skipped")
+
+	require.Eventually(t, func() bool {
+		return calls.Load() >= 2
+	}, time.Second, 5*time.Millisecond)
+
+	cancel()
+	<-done
+}
+
+func TestRefresher_StatusTransitions(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	pairs := []domain.Pair{btcUSD}
+	refresher := NewRefresher(ltpService, pairs, time.Minute, WithFailureThreshold(2))
+	upstreamErr := errors.New("upstream down")
+
+	// Act & Assert
+	assert.Equal(t, domain.RefresherStatus{}, refresher.RefresherStatus())
+	assert.Equal(t, domain.HealthOK, refresher.CheckHealth(context.Background()))
+
+	before := time.Now()
+	refresher.record(nil)
+	status := refresher.RefresherStatus()
+	assert.False(t, status.LastSuccess.Before(before))
+	assert.Zero(t, status.ConsecutiveFailures)
+
+	refresher.record(upstreamErr)
+	assert.Equal(t, domain.RefresherStatus{LastSuccess: status.LastSuccess, ConsecutiveFailures: 1, LastError: "upstream down"}, refresher.RefresherStatus())
+	assert.Equal(t, domain.HealthOK, refresher.CheckHealth(context.Background()), "below the threshold")
+
+	refresher.record(upstreamErr)
+	assert.Equal(t, domain.RefresherStatus{LastSuccess: status.LastSuccess, ConsecutiveFailures: 2, LastError: "upstream down", Degraded: true}, refresher.RefresherStatus())
+	assert.Equal(t, domain.HealthDegraded, refresher.CheckHealth(context.Background()))
+
+	refresher.record(nil)
+	recovered := refresher.RefresherStatus()
+	assert.False(t, recovered.LastSuccess.Before(status.LastSuccess))
+	assert.Zero(t, recovered.ConsecutiveFailures)
+	assert.Empty(t, recovered.LastError)
+	assert.False(t, recovered.Degraded)
+	assert.Equal(t, domain.HealthOK, refresher.CheckHealth(context.Background()))
+}
+
+func TestRefresher_DegradesOnRepeatedFailures(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	pairs := []domain.Pair{btcUSD}
+
+	var calls atomic.Int32
+	ltpService.On("RefreshLTPs", mock.Anything, pairs).Run(func(_ mock.Arguments) {
+		calls.Add(1)
+	}).Return(errors.New("upstream down"))
+
+	refresher := NewRefresher(ltpService, pairs, 10*time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Act
+	done := refresher.Start(ctx)
+
+	// Assert
+	require.Eventually(t, func() bool {
+		return refresher.RefresherStatus().Degraded
+	}, time.Second, 5*time.Millisecond)
+
+	cancel()
+	<-done
+	status := refresher.RefresherStatus()
+	assert.Equal(t, int(calls.Load()), status.ConsecutiveFailures)
+	assert.GreaterOrEqual(t, status.ConsecutiveFailures, DefaultRefresherFailureThreshold)
+	assert.True(t, status.LastSuccess.IsZero())
+	assert.Equal(t, "upstream down", status.LastError)
+	assert.Equal(t, "refresher", refresher.Name())
+	assert.Equal(t, domain.HealthDegraded, refresher.CheckHealth(context.Background()))
+}
+
+func TestRefresherController_Reload_DrainsPreviousRefresher(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	oldPairs := []domain.Pair{btcUSD}
+	newPairs := []domain.Pair{btcEUR}
+
+	var oldCalls, newCalls atomic.Int32
+	ltpService.On("RefreshLTPs", mock.Anything, oldPairs).Run(func(_ mock.Arguments) {
+		oldCalls.Add(1)
+	}).Return(nil)
+	ltpService.On("RefreshLTPs", mock.Anything, newPairs).Run(func(_ mock.Arguments) {
+		newCalls.Add(1)
+	}).Return(nil)
+
+	controller := NewRefresherController(ltpService)
+	defer controller.Stop()
+	controller.Reload(oldPairs, 10*time.Millisecond)
+	oldDone := controller.done
+	require.Eventually(t, func() bool {
+		return oldCalls.Load() >= 2
+	}, time.Second, 5*time.Millisecond)
+
+	// Act
+	controller.Reload(newPairs, 10*time.Millisecond)
+
+	// Assert
+	select {
+	case <-oldDone:
+	default:
+		t.Fatal("the previous refresher goroutine is still running after Reload returned")
+	}
+	drained := oldCalls.Load()
+	require.Eventually(t, func() bool {
+		return newCalls.Load() >= 2
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, drained, oldCalls.Load(), "the previous pairs are no longer refreshed")
+}
+
+func TestRefresherController_Reload_DoesNotBlockStatusWhileDraining(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	btcEUR, _ := domain.NewPair(domain.BTCEUR)
+	oldPairs := []domain.Pair{btcUSD}
+	newPairs := []domain.Pair{btcEUR}
+
+	// The previous refresher's refresh ignores cancellation until released
+	started := make(chan struct{})
+	release := make(chan struct{})
+	ltpService.On("RefreshLTPs", mock.Anything, oldPairs).Run(func(_ mock.Arguments) {
+		close(started)
+		<-release
+	}).Return(nil).Once()
+	ltpService.On("RefreshLTPs", mock.Anything, newPairs).Return(errors.New("upstream down"))
+
+	controller := NewRefresherController(ltpService, WithFailureThreshold(1))
+	defer controller.Stop()
+	controller.Reload(oldPairs, time.Hour)
+	<-started
+
+	// Act
+	reloaded := make(chan struct{})
+	go func() {
+		defer close(reloaded)
+		controller.Reload(newPairs, 10*time.Millisecond)
+	}()
+
+	// Assert
+	require.Eventually(t, func() bool {
+		return controller.CheckHealth(context.Background()) == domain.HealthDegraded
+	}, time.Second, 5*time.Millisecond, "the new refresher reports while the previous one drains")
+	select {
+	case <-reloaded:
+		t.Fatal("Reload returned before the previous refresher exited")
+	default:
+	}
+	close(release)
+	<-reloaded
+}
+
+func TestRefresherController_StopAndDisable(t *testing.T) {
+	// Arrange
+	ltpService := new(mocks.LTPService)
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	pairs := []domain.Pair{btcUSD}
+	ltpService.On("RefreshLTPs", mock.Anything, pairs).Return(errors.New("upstream down"))
+
+	controller := NewRefresherController(ltpService, WithFailureThreshold(1))
+	controller.Reload(pairs, 10*time.Millisecond)
+	done := controller.done
+	require.Eventually(t, func() bool {
+		return controller.CheckHealth(context.Background()) == domain.HealthDegraded
+	}, time.Second, 5*time.Millisecond)
+
+	// Act
+	controller.Reload(pairs, 0)
+
+	// Assert
+	<-done
+	assert.Nil(t, controller.current(), "a non-positive interval leaves no refresher running")
+	assert.Equal(t, domain.RefresherStatus{}, controller.RefresherStatus())
+	assert.Equal(t, domain.HealthOK, controller.CheckHealth(context.Background()))
+	assert.Equal(t, "refresher", controller.Name())
+	assert.NotPanics(t, controller.Stop, "stopping again is a no-op")
+}
@@ -0,0 +1,371 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-exercise/internal/domain"
+)
+
+// DefaultPort is the port the server listens on when PORT is unset
+const DefaultPort = "8080"
+
+// ValidationError lists every invalid setting found by Load
+type ValidationError struct {
+	Errors []error
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("invalid configuration: %s", strings.Join(messages, "; "))
+}
+
+// Unwrap exposes the individual errors to errors.Is and errors.As
+func (e *ValidationError) Unwrap() []error {
+	return e.Errors
+}
+
+// Config is the server configuration read from the environment at startup. Zero values
+// mean the setting is unset and the component's own default applies, unless noted.
+//
+// Settings naming pairs are parsed after PairsConfig has replaced the valid pairs.
+type Config struct {
+	// Port is the TCP port to listen on; DefaultPort unless configured
+	Port string
+	// ListenSocket is a Unix domain socket to listen on instead of Port
+	ListenSocket string
+	// OTLPEndpoint enables tracing, exporting spans to the given endpoint
+	OTLPEndpoint string
+	// PairsConfig is a file replacing the compiled-in pairs
+	PairsConfig string
+	// PairDefinitions are the pairs loaded from PairsConfig, nil without it
+	PairDefinitions []PairDefinition
+
+	// PriceSource is the primary price provider; "kraken" unless configured
+	PriceSource string
+	// AggregateMode is how the aggregate provider combines prices, "mean" or
+	// "median"; "mean" unless configured
+	AggregateMode string
+	// StartupSelfTest is "off", "warn" or "fail"; "off" unless configured
+	StartupSelfTest string
+	KrakenTimeout   time.Duration
+	UpstreamTimeout time.Duration
+	KrakenBatchSize int
+	// KrakenPartialBatches serves the batches that succeeded when others fail
+	KrakenPartialBatches bool
+	// KrakenUserAgent is the User-Agent sent to Kraken; kraken.DefaultUserAgent unless configured
+	KrakenUserAgent string
+	// SymbolOverrides are Kraken symbols by pair value, from "PAIR:SYMBOL,..."
+	SymbolOverrides  map[string]string
+	BinanceUSDTAsUSD bool
+
+	// CacheTTL is the global time-to-live of cached prices; domain.DefaultTTL unless configured
+	CacheTTL time.Duration
+	// CacheMinTTL are per-pair minimum residency floors by pair value, from "PAIR=DURATION,..."
+	CacheMinTTL       map[string]time.Duration
+	CacheMaxEntries   int
+	HistorySize       int
+	CacheSnapshotFile string
+
+	// FreshnessSLA are per-pair maximum data ages by pair value, from "PAIR=DURATION,..."
+	FreshnessSLA map[string]time.Duration
+	// PriceBounds are plausible price ranges by quote currency, from "CURRENCY=MIN:MAX,..."
+	PriceBounds         domain.PriceBounds
+	AllowStale          bool
+	PairsSpaceSeparated bool
+	MaxPairs            int
+	// DefaultPairs are the pairs served when a request names none; all valid pairs unless
+	// configured
+	DefaultPairs          []domain.Pair
+	SlowUpstreamThreshold time.Duration
+
+	RefreshInterval         time.Duration
+	RefreshFailureThreshold int
+	// ReloadConfig is a file the refresher and cache TTL settings are reloaded from on SIGHUP
+	ReloadConfig string
+
+	EventsInterval time.Duration
+	// CompressionMinLength is the smallest response body compressed; nil keeps the default
+	CompressionMinLength *int
+	// APIKeys are secrets and must never be exposed
+	APIKeys            []string
+	CORSAllowedOrigins []string
+	CORSAllowedMethods []string
+	CORSAllowedHeaders []string
+	DebugHTTP          bool
+}
+
+// Load reads the configuration from the environment and validates it. When any setting
+// is invalid, it returns a *ValidationError listing all of them at once. The settings
+// naming pairs are checked against the pairs of PAIRS_CONFIG, if any, which the caller
+// then applies with domain.SetValidPairs.
+func Load() (Config, error) {
+	return load(os.Getenv)
+}
+
+// load reads the configuration through getenv, so tests need not touch the environment
+func load(getenv func(string) string) (Config, error) {
+	env := envReader{getenv: getenv}
+	cfg := Config{
+		Port:         env.str("PORT"),
+		ListenSocket: env.str("LISTEN_SOCKET"),
+		OTLPEndpoint: env.str("OTEL_EXPORTER_OTLP_ENDPOINT"),
+		PairsConfig:  env.str("PAIRS_CONFIG"),
+
+		PriceSource:          env.str("PRICE_SOURCE"),
+		AggregateMode:        strings.ToLower(env.str("AGGREGATE_MODE")),
+		StartupSelfTest:      env.str("STARTUP_SELFTEST"),
+		KrakenTimeout:        env.duration("KRAKEN_TIMEOUT", "10s"),
+		UpstreamTimeout:      env.duration("UPSTREAM_TIMEOUT", "3s"),
+		KrakenBatchSize:      env.positiveInt("KRAKEN_BATCH_SIZE"),
+		KrakenPartialBatches: env.boolean("KRAKEN_PARTIAL_BATCHES"),
+		KrakenUserAgent:      env.str("KRAKEN_USER_AGENT"),
+		BinanceUSDTAsUSD:     env.boolean("BINANCE_USDT_AS_USD"),
+
+		CacheTTL:          env.duration("CACHE_TTL", "1m"),
+		CacheMaxEntries:   env.nonNegativeInt("CACHE_MAX_ENTRIES"),
+		HistorySize:       env.nonNegativeInt("HISTORY_SIZE"),
+		CacheSnapshotFile: env.str("CACHE_SNAPSHOT_FILE"),
+
+		AllowStale:            env.boolean("ALLOW_STALE"),
+		PairsSpaceSeparated:   env.boolean("PAIRS_SPACE_SEPARATED"),
+		MaxPairs:              env.positiveInt("MAX_PAIRS"),
+		SlowUpstreamThreshold: env.duration("SLOW_UPSTREAM_THRESHOLD", "2s"),
+
+		RefreshInterval:         env.duration("REFRESH_INTERVAL", "30s"),
+		RefreshFailureThreshold: env.positiveInt("REFRESH_FAILURE_THRESHOLD"),
+		ReloadConfig:            env.str("RELOAD_CONFIG"),
+
+		EventsInterval:     env.duration("EVENTS_INTERVAL", "5s"),
+		APIKeys:            env.list("API_KEYS"),
+		CORSAllowedOrigins: env.list("CORS_ALLOWED_ORIGINS"),
+		CORSAllowedMethods: env.list("CORS_ALLOWED_METHODS"),
+		CORSAllowedHeaders: env.list("CORS_ALLOWED_HEADERS"),
+		DebugHTTP:          env.boolean("DEBUG_HTTP"),
+	}
+	if getenv("COMPRESSION_MIN_LENGTH") != "" {
+		n := env.nonNegativeInt("COMPRESSION_MIN_LENGTH")
+		cfg.CompressionMinLength = &n
+	}
+	if getenv("API_KEYS") != "" && len(cfg.APIKeys) == 0 {
+		env.fail("invalid API_KEYS: at least one non-empty key is required")
+	}
+
+	// Settings naming pairs are parsed against the pairs of PAIRS_CONFIG; when it cannot
+	// be applied they are skipped, as their errors would only echo its error
+	pairsApplied := true
+	if cfg.PairsConfig != "" {
+		defs, err := LoadPairs(cfg.PairsConfig)
+		if err != nil {
+			env.fail("invalid PAIRS_CONFIG %q: %v", cfg.PairsConfig, err)
+			pairsApplied = false
+		} else {
+			cfg.PairDefinitions = defs
+			env.pairOpts = []domain.ParseOption{domain.WithValidPairs(Names(defs))}
+		}
+	}
+	if pairsApplied {
+		cfg.SymbolOverrides = env.symbolOverrides("SYMBOL_OVERRIDES")
+		cfg.CacheMinTTL = env.pairDurations("CACHE_MIN_TTL")
+		cfg.FreshnessSLA = env.pairDurations("FRESHNESS_SLA")
+		cfg.PriceBounds = env.priceBounds("PRICE_BOUNDS")
+		cfg.DefaultPairs = env.pairs("DEFAULT_PAIRS")
+	}
+
+	if cfg.ListenSocket == "" {
+		port, err := resolvePort(cfg.Port)
+		if err != nil {
+			env.fail("invalid PORT: %v", err)
+		}
+		cfg.Port = port
+	}
+	switch cfg.PriceSource {
+	case "":
+		cfg.PriceSource = "kraken"
+	case "kraken", "binance", "aggregate", "fake":
+	default:
+		env.fail("invalid PRICE_SOURCE %q: must be kraken, binance, aggregate or fake", cfg.PriceSource)
+	}
+	switch cfg.StartupSelfTest {
+	case "":
+		cfg.StartupSelfTest = "off"
+	case "off", "warn", "fail":
+	default:
+		env.fail("invalid STARTUP_SELFTEST %q: must be off, warn or fail", cfg.StartupSelfTest)
+	}
+	switch cfg.AggregateMode {
+	case "":
+		cfg.AggregateMode = "mean"
+	case "mean", "median":
+	default:
+		env.fail("invalid AGGREGATE_MODE %q: must be mean or median", cfg.AggregateMode)
+	}
+	if cfg.CacheTTL == 0 {
+		cfg.CacheTTL = domain.DefaultTTL
+	}
+
+	if len(env.errs) > 0 {
+		return Config{}, &ValidationError{Errors: env.errs}
+	}
+	return cfg, nil
+}
+
+// resolvePort validates a PORT value, defaulting to DefaultPort when empty
+func resolvePort(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return DefaultPort, nil
+	}
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		return "", fmt.Errorf("%q must be a TCP port number between 1 and 65535", value)
+	}
+	return strconv.Itoa(port), nil
+}
+
+// envReader parses environment values, collecting every error it runs into
+type envReader struct {
+	getenv func(string) string
+	errs   []error
+	// pairOpts check the settings naming pairs against the configured pairs
+	pairOpts []domain.ParseOption
+}
+
+// fail records an invalid setting
+func (r *envReader) fail(format string, args ...any) {
+	r.errs = append(r.errs, fmt.Errorf(format, args...))
+}
+
+// str returns the value of name, empty when unset
+func (r *envReader) str(name string) string {
+	return r.getenv(name)
+}
+
+// duration parses a positive duration; example shows a valid value in the error
+func (r *envReader) duration(name, example string) time.Duration {
+	value := r.getenv(name)
+	if value == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		r.fail("invalid %s %q: must be a positive duration (e.g. %s)", name, value, example)
+		return 0
+	}
+	return d
+}
+
+// positiveInt parses a positive integer
+func (r *envReader) positiveInt(name string) int {
+	value := r.getenv(name)
+	if value == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		r.fail("invalid %s %q: must be a positive integer", name, value)
+		return 0
+	}
+	return n
+}
+
+// nonNegativeInt parses a non-negative integer
+func (r *envReader) nonNegativeInt(name string) int {
+	value := r.getenv(name)
+	if value == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		r.fail("invalid %s %q: must be a non-negative integer", name, value)
+		return 0
+	}
+	return n
+}
+
+// boolean parses a boolean; false when unset
+func (r *envReader) boolean(name string) bool {
+	value := r.getenv(name)
+	if value == "" {
+		return false
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		r.fail("invalid %s %q: must be a boolean", name, value)
+		return false
+	}
+	return enabled
+}
+
+// list splits a comma-separated value, dropping empty entries
+func (r *envReader) list(name string) []string {
+	var values []string
+	for _, value := range strings.Split(r.getenv(name), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// pairs parses a comma-separated list of valid pairs; nil when unset
+func (r *envReader) pairs(name string) []domain.Pair {
+	value := r.getenv(name)
+	if value == "" {
+		return nil
+	}
+	pairs, err := domain.ParsePairs(value, r.pairOpts...)
+	if err != nil {
+		r.fail("invalid %s %q: %v", name, value, err)
+		return nil
+	}
+	return pairs
+}
+
+// pairDurations parses per-pair durations, see parsePairDurations; nil when unset
+func (r *envReader) pairDurations(name string) map[string]time.Duration {
+	value := r.getenv(name)
+	if value == "" {
+		return nil
+	}
+	durations, err := parsePairDurations(value, r.pairOpts...)
+	if err != nil {
+		r.fail("invalid %s %q: %v", name, value, err)
+		return nil
+	}
+	return durations
+}
+
+// symbolOverrides parses upstream symbols per pair, see parseSymbolOverrides; nil when unset
+func (r *envReader) symbolOverrides(name string) map[string]string {
+	value := r.getenv(name)
+	if value == "" {
+		return nil
+	}
+	overrides, err := parseSymbolOverrides(value, r.pairOpts...)
+	if err != nil {
+		r.fail("invalid %s %q: %v", name, value, err)
+		return nil
+	}
+	return overrides
+}
+
+// priceBounds parses per-currency price bounds, see parsePriceBounds; nil when unset
+func (r *envReader) priceBounds(name string) domain.PriceBounds {
+	value := r.getenv(name)
+	if value == "" {
+		return nil
+	}
+	bounds, err := parsePriceBounds(value, r.pairOpts...)
+	if err != nil {
+		r.fail("invalid %s %q: %v", name, value, err)
+		return nil
+	}
+	return bounds
+}
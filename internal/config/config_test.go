@@ -0,0 +1,234 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"go-exercise/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// envOf returns a getenv reading from the given values
+func envOf(values map[string]string) func(string) string {
+	return func(name string) string {
+		return values[name]
+	}
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	cfg, err := load(envOf(nil))
+
+	require.NoError(t, err)
+	assert.Equal(t, Config{
+		Port:            DefaultPort,
+		PriceSource:     "kraken",
+		StartupSelfTest: "off",
+		AggregateMode:   "mean",
+		CacheTTL:        domain.DefaultTTL,
+	}, cfg)
+}
+
+func TestLoad_ParsesValues(t *testing.T) {
+	cfg, err := load(envOf(map[string]string{
+		"PRICE_SOURCE":           "binance",
+		"CACHE_TTL":              "90s",
+		"REFRESH_INTERVAL":       "30s",
+		"KRAKEN_BATCH_SIZE":      "5",
+		"KRAKEN_USER_AGENT":      "ltp-service/2.3",
+		"ALLOW_STALE":            "true",
+		"COMPRESSION_MIN_LENGTH": "0",
+		"API_KEYS":               "first, ,second",
+		"DEFAULT_PAIRS":          "BTC/USD",
+		"AGGREGATE_MODE":         "Median",
+		"CACHE_MIN_TTL":          "btc/usd=2m",
+		"FRESHNESS_SLA":          "BTC/EUR=90s",
+		"PRICE_BOUNDS":           "USD=1000:",
+		"SYMBOL_OVERRIDES":       "BTC/USD:XXBTZUSD",
+	}))
+
+	require.NoError(t, err)
+	assert.Equal(t, "binance", cfg.PriceSource)
+	assert.Equal(t, 90*time.Second, cfg.CacheTTL)
+	assert.Equal(t, 30*time.Second, cfg.RefreshInterval)
+	assert.Equal(t, 5, cfg.KrakenBatchSize)
+	assert.Equal(t, "ltp-service/2.3", cfg.KrakenUserAgent)
+	assert.True(t, cfg.AllowStale)
+	require.NotNil(t, cfg.CompressionMinLength)
+	assert.Zero(t, *cfg.CompressionMinLength)
+	assert.Equal(t, []string{"first", "second"}, cfg.APIKeys)
+	btcUSD, _ := domain.NewPair(domain.BTCUSD)
+	assert.Equal(t, []domain.Pair{btcUSD}, cfg.DefaultPairs)
+	assert.Equal(t, "median", cfg.AggregateMode)
+	assert.Equal(t, map[string]time.Duration{domain.BTCUSD: 2 * time.Minute}, cfg.CacheMinTTL)
+	assert.Equal(t, map[string]time.Duration{domain.BTCEUR: 90 * time.Second}, cfg.FreshnessSLA)
+	assert.Equal(t, domain.PriceBounds{"USD": {Min: 1000}}, cfg.PriceBounds)
+	assert.Equal(t, map[string]string{domain.BTCUSD: "XXBTZUSD"}, cfg.SymbolOverrides)
+}
+
+func TestLoad_PairsConfig(t *testing.T) {
+	path := writeFile(t, "pairs.yaml", "pairs:\n  - name: ETH/USD\n    symbol: XETHZUSD\n  - name: BTC/USD\n    symbol: XXBTZUSD\n")
+
+	cfg, err := load(envOf(map[string]string{
+		"PAIRS_CONFIG":  path,
+		"DEFAULT_PAIRS": "ETH/USD",
+		"CACHE_MIN_TTL": "ETH/USD=2m",
+	}))
+
+	require.NoError(t, err)
+	ethUSD, _ := domain.NewPair("ETH/USD", domain.WithValidPairs(Names(cfg.PairDefinitions)))
+	assert.Equal(t, []PairDefinition{{Name: "ETH/USD", Symbol: "XETHZUSD"}, {Name: domain.BTCUSD, Symbol: "XXBTZUSD"}}, cfg.PairDefinitions)
+	assert.Equal(t, []domain.Pair{ethUSD}, cfg.DefaultPairs, "pairs are checked against the loaded pairs")
+	assert.Equal(t, map[string]time.Duration{"ETH/USD": 2 * time.Minute}, cfg.CacheMinTTL)
+	assert.Equal(t, []string{domain.BTCUSD, domain.BTCCHF, domain.BTCEUR}, domain.ValidPairs(), "loading leaves the valid pairs to the caller")
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected []string
+	}{
+		{
+			name:     "duration",
+			env:      map[string]string{"CACHE_TTL": "-1m"},
+			expected: []string{`invalid CACHE_TTL "-1m": must be a positive duration (e.g. 1m)`},
+		},
+		{
+			name:     "port",
+			env:      map[string]string{"PORT": "localhost:8080"},
+			expected: []string{`invalid PORT: "localhost:8080" must be a TCP port number between 1 and 65535`},
+		},
+		{
+			name:     "price source",
+			env:      map[string]string{"PRICE_SOURCE": "coinbase"},
+			expected: []string{`invalid PRICE_SOURCE "coinbase": must be kraken, binance, aggregate or fake`},
+		},
+		{
+			name:     "self-test mode",
+			env:      map[string]string{"STARTUP_SELFTEST": "strict"},
+			expected: []string{`invalid STARTUP_SELFTEST "strict": must be off, warn or fail`},
+		},
+		{
+			name:     "positive integer",
+			env:      map[string]string{"MAX_PAIRS": "0"},
+			expected: []string{`invalid MAX_PAIRS "0": must be a positive integer`},
+		},
+		{
+			name:     "non-negative integer",
+			env:      map[string]string{"COMPRESSION_MIN_LENGTH": "-1"},
+			expected: []string{`invalid COMPRESSION_MIN_LENGTH "-1": must be a non-negative integer`},
+		},
+		{
+			name:     "boolean",
+			env:      map[string]string{"ALLOW_STALE": "sometimes"},
+			expected: []string{`invalid ALLOW_STALE "sometimes": must be a boolean`},
+		},
+		{
+			name:     "empty API keys",
+			env:      map[string]string{"API_KEYS": " , "},
+			expected: []string{"invalid API_KEYS: at least one non-empty key is required"},
+		},
+		{
+			name:     "aggregate mode",
+			env:      map[string]string{"AGGREGATE_MODE": "mode"},
+			expected: []string{`invalid AGGREGATE_MODE "mode": must be mean or median`},
+		},
+		{
+			name: "settings naming pairs",
+			env: map[string]string{
+				"SYMBOL_OVERRIDES": "BTC/USD=XXBTZUSD",
+				"CACHE_MIN_TTL":    "BTC/USD=soon",
+				"FRESHNESS_SLA":    "BTC/USD",
+				"PRICE_BOUNDS":     "USD=1000:10",
+				"DEFAULT_PAIRS":    "ETH/USD",
+			},
+			expected: []string{
+				`invalid SYMBOL_OVERRIDES "BTC/USD=XXBTZUSD": entry "BTC/USD=XXBTZUSD" must be in the form PAIR:SYMBOL`,
+				`invalid CACHE_MIN_TTL "BTC/USD=soon": invalid duration for BTC/USD: "soon"`,
+				`invalid FRESHNESS_SLA "BTC/USD": entry "BTC/USD" must be in the form PAIR=DURATION`,
+				`invalid PRICE_BOUNDS "USD=1000:10": invalid maximum for USD: "10"`,
+				`invalid DEFAULT_PAIRS "ETH/USD": invalid pair: ETH/USD. Valid pairs are: BTC/USD, BTC/CHF, BTC/EUR`,
+			},
+		},
+		{
+			name:     "pairs config skips settings naming pairs",
+			env:      map[string]string{"PAIRS_CONFIG": "pairs.toml", "DEFAULT_PAIRS": "ETH/USD"},
+			expected: []string{`invalid PAIRS_CONFIG "pairs.toml": failed to read pairs config: open pairs.toml: no such file or directory`},
+		},
+		{
+			name: "every invalid setting at once",
+			env: map[string]string{
+				"PORT":             "0",
+				"CACHE_TTL":        "soon",
+				"REFRESH_INTERVAL": "0s",
+				"DEBUG_HTTP":       "maybe",
+			},
+			expected: []string{
+				`invalid CACHE_TTL "soon": must be a positive duration (e.g. 1m)`,
+				`invalid REFRESH_INTERVAL "0s": must be a positive duration (e.g. 30s)`,
+				`invalid DEBUG_HTTP "maybe": must be a boolean`,
+				`invalid PORT: "0" must be a TCP port number between 1 and 65535`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := load(envOf(tt.env))
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			require.Len(t, validationErr.Errors, len(tt.expected))
+			for i, expected := range tt.expected {
+				assert.EqualError(t, validationErr.Errors[i], expected)
+			}
+			assert.True(t, strings.HasPrefix(err.Error(), "invalid configuration: "))
+			assert.Equal(t, Config{}, cfg)
+		})
+	}
+}
+
+func TestLoad_SocketSkipsPort(t *testing.T) {
+	cfg, err := load(envOf(map[string]string{"LISTEN_SOCKET": "/tmp/ltp.sock", "PORT": "invalid"}))
+
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/ltp.sock", cfg.ListenSocket)
+}
+
+func TestResolvePort(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      string
+		expected string
+		wantErr  bool
+	}{
+		{name: "empty defaults to 8080", env: "", expected: "8080"},
+		{name: "valid port", env: "9090", expected: "9090"},
+		{name: "surrounding whitespace is trimmed", env: " 3000 ", expected: "3000"},
+		{name: "lowest port", env: "1", expected: "1"},
+		{name: "highest port", env: "65535", expected: "65535"},
+		{name: "leading zeros are normalized", env: "0080", expected: "80"},
+		{name: "non-numeric", env: "abc", wantErr: true},
+		{name: "zero", env: "0", wantErr: true},
+		{name: "negative", env: "-1", wantErr: true},
+		{name: "out of range", env: "65536", wantErr: true},
+		{name: "host and port", env: "localhost:8080", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			port, err := resolvePort(tt.env)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "between 1 and 65535")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, port)
+		})
+	}
+}
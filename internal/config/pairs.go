@@ -0,0 +1,96 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go-exercise/internal/domain"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidPairsConfig is returned when a pairs config file is malformed
+var ErrInvalidPairsConfig = errors.New("invalid pairs config")
+
+// PairDefinition describes a supported pair and the upstream symbol used to fetch it
+type PairDefinition struct {
+	// Name is the canonical pair name, e.g. BTC/USD
+	Name string `json:"name" yaml:"name"`
+	// Symbol is the upstream (Kraken) symbol, e.g. XBTUSD
+	Symbol string `json:"symbol" yaml:"symbol"`
+}
+
+// pairsFile is the on-disk layout of a pairs config file
+type pairsFile struct {
+	Pairs []PairDefinition `json:"pairs" yaml:"pairs"`
+}
+
+// LoadPairs reads pair definitions from a YAML (.yaml, .yml) or JSON (.json) file:
+//
+//	pairs:
+//	  - name: BTC/USD
+//	    symbol: XBTUSD
+func LoadPairs(path string) ([]PairDefinition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pairs config: %w", err)
+	}
+
+	var file pairsFile
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		err = json.Unmarshal(data, &file)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &file)
+	default:
+		return nil, fmt.Errorf("%w: unsupported file extension %q, expected .yaml, .yml or .json", ErrInvalidPairsConfig, ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPairsConfig, err)
+	}
+
+	if len(file.Pairs) == 0 {
+		return nil, fmt.Errorf("%w: no pairs defined", ErrInvalidPairsConfig)
+	}
+	seen := make(map[string]bool, len(file.Pairs))
+	for i := range file.Pairs {
+		def := &file.Pairs[i]
+		def.Symbol = strings.TrimSpace(def.Symbol)
+		if strings.TrimSpace(def.Name) == "" || def.Symbol == "" {
+			return nil, fmt.Errorf("%w: entry %d needs both a name and a symbol", ErrInvalidPairsConfig, i+1)
+		}
+		name, err := domain.NormalizePair(def.Name)
+		if err != nil {
+			return nil, fmt.Errorf("%w: entry %d: %w", ErrInvalidPairsConfig, i+1, err)
+		}
+		def.Name = name
+		if seen[def.Name] {
+			return nil, fmt.Errorf("%w: pair %s is defined more than once", ErrInvalidPairsConfig, def.Name)
+		}
+		seen[def.Name] = true
+	}
+
+	return file.Pairs, nil
+}
+
+// Names returns the canonical names of the given definitions, in order
+func Names(defs []PairDefinition) []string {
+	names := make([]string, len(defs))
+	for i, def := range defs {
+		names[i] = def.Name
+	}
+	return names
+}
+
+// Symbols returns the pair-name-to-symbol mapping of the given definitions
+func Symbols(defs []PairDefinition) map[string]string {
+	symbols := make(map[string]string, len(defs))
+	for _, def := range defs {
+		symbols[def.Name] = def.Symbol
+	}
+	return symbols
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadPairs_YAML(t *testing.T) {
+	path := writeFile(t, "pairs.yaml", `
+pairs:
+  - name: btc/usd
+    symbol: XBTUSD
+  - name: eth-usd
+    symbol: ETHUSD
+`)
+
+	defs, err := LoadPairs(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, []PairDefinition{
+		{Name: "BTC/USD", Symbol: "XBTUSD"},
+		{Name: "ETH/USD", Symbol: "ETHUSD"},
+	}, defs)
+	assert.Equal(t, []string{"BTC/USD", "ETH/USD"}, Names(defs))
+	assert.Equal(t, map[string]string{"BTC/USD": "XBTUSD", "ETH/USD": "ETHUSD"}, Symbols(defs))
+}
+
+func TestLoadPairs_JSON(t *testing.T) {
+	path := writeFile(t, "pairs.json", `{"pairs":[{"name":"BTC/EUR","symbol":"XBTEUR"}]}`)
+
+	defs, err := LoadPairs(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, []PairDefinition{{Name: "BTC/EUR", Symbol: "XBTEUR"}}, defs)
+}
+
+func TestLoadPairs_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{"unsupported extension", "pairs.toml", `pairs = []`},
+		{"malformed yaml", "pairs.yaml", "pairs: [\n"},
+		{"malformed json", "pairs.json", `{"pairs":`},
+		{"no pairs", "pairs.yaml", "pairs: []\n"},
+		{"missing symbol", "pairs.json", `{"pairs":[{"name":"BTC/USD"}]}`},
+		{"duplicate pair", "pairs.json", `{"pairs":[{"name":"BTC/USD","symbol":"XBTUSD"},{"name":"btc-usd","symbol":"XBTUSD"}]}`},
+		{"malformed name", "pairs.json", `{"pairs":[{"name":"BTCUSD","symbol":"XBTUSD"}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeFile(t, tt.file, tt.content)
+
+			defs, err := LoadPairs(path)
+
+			assert.ErrorIs(t, err, ErrInvalidPairsConfig)
+			assert.Nil(t, defs)
+		})
+	}
+}
+
+func TestLoadPairs_MissingFile(t *testing.T) {
+	_, err := LoadPairs(filepath.Join(t.TempDir(), "missing.yaml"))
+
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
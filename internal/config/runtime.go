@@ -0,0 +1,85 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidRuntimeConfig is returned when a runtime config file is malformed
+var ErrInvalidRuntimeConfig = errors.New("invalid runtime config")
+
+// RuntimeSettings are the settings that can be reloaded without restarting the process.
+// Zero values mean the setting is not given and keeps its current value.
+type RuntimeSettings struct {
+	// RefreshInterval is how often the background refresher runs
+	RefreshInterval time.Duration
+	// RefreshPairs are the pairs the background refresher keeps warm, e.g. BTC/USD
+	RefreshPairs []string
+	// CacheTTL is the global time-to-live of cached prices
+	CacheTTL time.Duration
+}
+
+// runtimeFile is the on-disk layout of a runtime config file
+type runtimeFile struct {
+	RefreshInterval string   `json:"refresh_interval" yaml:"refresh_interval"`
+	RefreshPairs    []string `json:"refresh_pairs" yaml:"refresh_pairs"`
+	CacheTTL        string   `json:"cache_ttl" yaml:"cache_ttl"`
+}
+
+// LoadRuntime reads reloadable settings from a YAML (.yaml, .yml) or JSON (.json) file:
+//
+//	refresh_interval: 30s
+//	refresh_pairs: [BTC/USD, BTC/EUR]
+//	cache_ttl: 1m
+func LoadRuntime(path string) (RuntimeSettings, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return RuntimeSettings{}, fmt.Errorf("failed to read runtime config: %w", err)
+	}
+
+	var file runtimeFile
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		err = json.Unmarshal(data, &file)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &file)
+	default:
+		return RuntimeSettings{}, fmt.Errorf("%w: unsupported file extension %q, expected .yaml, .yml or .json", ErrInvalidRuntimeConfig, ext)
+	}
+	if err != nil {
+		return RuntimeSettings{}, fmt.Errorf("%w: %w", ErrInvalidRuntimeConfig, err)
+	}
+
+	var settings RuntimeSettings
+	if settings.RefreshInterval, err = parsePositiveDuration("refresh_interval", file.RefreshInterval); err != nil {
+		return RuntimeSettings{}, err
+	}
+	if settings.CacheTTL, err = parsePositiveDuration("cache_ttl", file.CacheTTL); err != nil {
+		return RuntimeSettings{}, err
+	}
+	for _, pair := range file.RefreshPairs {
+		if pair = strings.TrimSpace(pair); pair != "" {
+			settings.RefreshPairs = append(settings.RefreshPairs, pair)
+		}
+	}
+	return settings, nil
+}
+
+// parsePositiveDuration parses an optional duration setting; empty means zero
+func parsePositiveDuration(name, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("%w: %s must be a positive duration (e.g. 30s), got %q", ErrInvalidRuntimeConfig, name, value)
+	}
+	return d, nil
+}
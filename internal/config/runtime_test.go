@@ -0,0 +1,68 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadRuntime(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		expected RuntimeSettings
+	}{
+		{
+			name: "yaml",
+			file: "runtime.yaml",
+			content: `
+refresh_interval: 30s
+refresh_pairs: [BTC/USD, " BTC/EUR "]
+cache_ttl: 1m
+`,
+			expected: RuntimeSettings{RefreshInterval: 30 * time.Second, RefreshPairs: []string{"BTC/USD", "BTC/EUR"}, CacheTTL: time.Minute},
+		},
+		{
+			name:     "json with omitted settings",
+			file:     "runtime.json",
+			content:  `{"cache_ttl":"90s"}`,
+			expected: RuntimeSettings{CacheTTL: 90 * time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings, err := LoadRuntime(writeFile(t, tt.file, tt.content))
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, settings)
+		})
+	}
+}
+
+func TestLoadRuntime_Invalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		file        string
+		content     string
+		expectedErr string
+	}{
+		{"malformed yaml", "runtime.yaml", "refresh_interval: [", "invalid runtime config"},
+		{"unknown extension", "runtime.toml", "", "unsupported file extension"},
+		{"invalid interval", "runtime.yaml", "refresh_interval: soon", "refresh_interval must be a positive duration"},
+		{"negative ttl", "runtime.json", `{"cache_ttl":"-1m"}`, "cache_ttl must be a positive duration"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadRuntime(writeFile(t, tt.file, tt.content))
+
+			require.Error(t, err)
+			assert.ErrorIs(t, err, ErrInvalidRuntimeConfig)
+			assert.Contains(t, err.Error(), tt.expectedErr)
+		})
+	}
+}
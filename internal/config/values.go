@@ -0,0 +1,88 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-exercise/internal/domain"
+)
+
+// parsePairDurations parses per-pair durations in the form "BTC/USD=2m,BTC/EUR=90s".
+// The options set the pairs they are checked against, see domain.WithValidPairs.
+func parsePairDurations(raw string, opts ...domain.ParseOption) (map[string]time.Duration, error) {
+	floors := make(map[string]time.Duration)
+	for _, entry := range strings.Split(raw, ",") {
+		pairStr, durationStr, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			return nil, fmt.Errorf("entry %q must be in the form PAIR=DURATION", entry)
+		}
+		pair, err := domain.NewPair(pairStr, opts...)
+		if err != nil {
+			return nil, err
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(durationStr))
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid duration for %s: %q", pair.Value(), durationStr)
+		}
+		floors[pair.Value()] = d
+	}
+	return floors, nil
+}
+
+// parseSymbolOverrides parses upstream symbols per pair in the form
+// "BTC/USD:XXBTZUSD,BTC/EUR:XXBTZEUR"
+func parseSymbolOverrides(raw string, opts ...domain.ParseOption) (map[string]string, error) {
+	overrides := make(map[string]string)
+	for _, entry := range strings.Split(raw, ",") {
+		pairStr, symbol, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok {
+			return nil, fmt.Errorf("entry %q must be in the form PAIR:SYMBOL", entry)
+		}
+		pair, err := domain.NewPair(pairStr, opts...)
+		if err != nil {
+			return nil, err
+		}
+		symbol = strings.ToUpper(strings.TrimSpace(symbol))
+		if symbol == "" {
+			return nil, fmt.Errorf("empty symbol for %s", pair.Value())
+		}
+		overrides[pair.Value()] = symbol
+	}
+	return overrides, nil
+}
+
+// parsePriceBounds parses per-currency price bounds in the form "USD=1000:1000000,EUR=1000:".
+// Either bound may be left empty to leave that side open.
+func parsePriceBounds(raw string, opts ...domain.ParseOption) (domain.PriceBounds, error) {
+	bounds := make(domain.PriceBounds)
+	for _, entry := range strings.Split(raw, ",") {
+		currency, rangeStr, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			return nil, fmt.Errorf("entry %q must be in the form CURRENCY=MIN:MAX", entry)
+		}
+		pairs, err := domain.PairsByQuote(currency, opts...)
+		if err != nil {
+			return nil, err
+		}
+		quote := pairs[0].Quote()
+		minStr, maxStr, ok := strings.Cut(rangeStr, ":")
+		if !ok {
+			return nil, fmt.Errorf("entry %q must be in the form CURRENCY=MIN:MAX", entry)
+		}
+		var amountBounds domain.AmountBounds
+		if minStr = strings.TrimSpace(minStr); minStr != "" {
+			if amountBounds.Min, err = strconv.ParseFloat(minStr, 64); err != nil || amountBounds.Min < 0 {
+				return nil, fmt.Errorf("invalid minimum for %s: %q", quote, minStr)
+			}
+		}
+		if maxStr = strings.TrimSpace(maxStr); maxStr != "" {
+			if amountBounds.Max, err = strconv.ParseFloat(maxStr, 64); err != nil || amountBounds.Max <= amountBounds.Min {
+				return nil, fmt.Errorf("invalid maximum for %s: %q", quote, maxStr)
+			}
+		}
+		bounds[quote] = amountBounds
+	}
+	return bounds, nil
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"go-exercise/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSymbolOverrides(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected map[string]string
+		wantErr  string
+	}{
+		{name: "single pair", raw: "BTC/USD:XXBTZUSD", expected: map[string]string{"BTC/USD": "XXBTZUSD"}},
+		{
+			name:     "normalized pairs and symbols",
+			raw:      " btc/usd : xxbtzusd , BTC/EUR:XXBTZEUR",
+			expected: map[string]string{"BTC/USD": "XXBTZUSD", "BTC/EUR": "XXBTZEUR"},
+		},
+		{name: "missing separator", raw: "BTC/USD=XXBTZUSD", wantErr: "PAIR:SYMBOL"},
+		{name: "empty symbol", raw: "BTC/USD:", wantErr: "empty symbol for BTC/USD"},
+		{name: "invalid pair", raw: "BTC/GBP:XXBTZGBP", wantErr: "invalid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			overrides, err := parseSymbolOverrides(tt.raw)
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, strings.ToLower(err.Error()), strings.ToLower(tt.wantErr))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, overrides)
+		})
+	}
+}
+
+func TestParsePriceBounds(t *testing.T) {
+	tests := []struct {
+		name        string
+		raw         string
+		expected    domain.PriceBounds
+		expectedErr string
+	}{
+		{
+			name:     "min and max",
+			raw:      "usd=1000:1000000, EUR=500:",
+			expected: domain.PriceBounds{"USD": {Min: 1000, Max: 1000000}, "EUR": {Min: 500}},
+		},
+		{name: "missing separator", raw: "USD", expectedErr: "must be in the form CURRENCY=MIN:MAX"},
+		{name: "missing range separator", raw: "USD=1000", expectedErr: "must be in the form CURRENCY=MIN:MAX"},
+		{name: "unknown currency", raw: "JPY=1:2", expectedErr: "invalid currency"},
+		{name: "invalid minimum", raw: "USD=low:", expectedErr: "invalid minimum for USD"},
+		{name: "maximum below minimum", raw: "USD=1000:10", expectedErr: "invalid maximum for USD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bounds, err := parsePriceBounds(tt.raw)
+
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, bounds)
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// Candle is an OHLC summary of the trades of a pair during one interval
+type Candle struct {
+	// Time is when the interval starts
+	Time   time.Time
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume float64
+}
+
+// CandleIntervals lists the supported candle intervals in minutes
+var CandleIntervals = []int{1, 5, 15, 30, 60, 240, 1440, 10080, 21600}
+
+// IsValidCandleInterval reports whether minutes is one of CandleIntervals
+func IsValidCandleInterval(minutes int) bool {
+	for _, interval := range CandleIntervals {
+		if interval == minutes {
+			return true
+		}
+	}
+	return false
+}
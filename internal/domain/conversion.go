@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BaseCurrency is the base currency shared by every supported pair
+const BaseCurrency = "BTC"
+
+// PairForQuote returns the BTC pair quoted in the given currency, e.g. "EUR" -> BTC/EUR
+func PairForQuote(currency string) (Pair, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	value := BaseCurrency + "/" + currency
+	if !validPairs[value] {
+		return Pair{}, fmt.Errorf("%w: %s. Valid currencies are: %s", ErrInvalidCurrency, currency, strings.Join(quoteCurrencies(), ", "))
+	}
+	return Pair{value: value}, nil
+}
+
+// PairsByQuote returns every valid pair quoted in the given currency, in the default
+// pair order, e.g. "usd" -> BTC/USD. Of the options, only WithValidPairs applies.
+func PairsByQuote(quote string, opts ...ParseOption) ([]Pair, error) {
+	quote = strings.ToUpper(strings.TrimSpace(quote))
+	order := newParseConfig(opts).validOrder()
+	var pairs []Pair
+	quotes := make([]string, 0, len(order))
+	seen := make(map[string]bool)
+	for _, value := range order {
+		pair := Pair{value: value}
+		if pair.Quote() == quote {
+			pairs = append(pairs, pair)
+		}
+		if !seen[pair.Quote()] {
+			quotes = append(quotes, pair.Quote())
+			seen[pair.Quote()] = true
+		}
+	}
+	if len(pairs) == 0 {
+		return nil, fmt.Errorf("%w: %s. Valid quote currencies are: %s", ErrInvalidCurrency, quote, strings.Join(quotes, ", "))
+	}
+	return pairs, nil
+}
+
+// quoteCurrencies returns the quote currencies of the valid BTC pairs
+func quoteCurrencies() []string {
+	var quotes []string
+	for _, value := range pairOrder {
+		pair := Pair{value: value}
+		if pair.Base() == BaseCurrency {
+			quotes = append(quotes, pair.Quote())
+		}
+	}
+	return quotes
+}
+
+// Base returns the base currency of the pair, e.g. BTC for BTC/USD
+func (p Pair) Base() string {
+	base, _ := SplitPair(p)
+	return base
+}
+
+// Quote returns the quote currency of the pair, e.g. USD for BTC/USD
+func (p Pair) Quote() string {
+	_, quote := SplitPair(p)
+	return quote
+}
+
+// CrossRate derives the implied rate between the quote currencies of two LTPs sharing
+// the same base. Given BTC/EUR (from) and BTC/USD (to) it returns how many USD one EUR buys.
+func CrossRate(from, to LTP) (float64, error) {
+	if from.Pair.Base() != to.Pair.Base() {
+		return 0, fmt.Errorf("%w: %s and %s do not share a base currency", ErrRateUnavailable, from.Pair, to.Pair)
+	}
+	if from.Amount <= 0 {
+		return 0, fmt.Errorf("%w: no usable price for %s", ErrRateUnavailable, from.Pair)
+	}
+	if to.Amount <= 0 {
+		return 0, fmt.Errorf("%w: no usable price for %s", ErrRateUnavailable, to.Pair)
+	}
+	return to.Amount / from.Amount, nil
+}
+
+// CrossQuote is the price of a base currency in one quote currency
+type CrossQuote struct {
+	Quote  string
+	Amount float64
+	// Via names the intermediate currency of a derived price; empty for a direct pair
+	Via string
+}
+
+// Derived reports whether the price was derived through an intermediate currency
+func (q CrossQuote) Derived() bool {
+	return q.Via != ""
+}
+
+// CrossRatePairs returns the valid pairs that involve the base or any of the quote
+// currencies, which are all CrossRates needs to price the base in each quote
+func CrossRatePairs(base string, quotes []string) ([]Pair, error) {
+	base = strings.ToUpper(strings.TrimSpace(base))
+	currencies := knownCurrencies()
+	wanted := map[string]bool{base: true}
+	if !currencies[base] {
+		return nil, fmt.Errorf("%w: %s is not part of any valid pair", ErrInvalidCurrency, base)
+	}
+	for _, quote := range quotes {
+		quote = strings.ToUpper(strings.TrimSpace(quote))
+		if !currencies[quote] {
+			return nil, fmt.Errorf("%w: %s is not part of any valid pair", ErrInvalidCurrency, quote)
+		}
+		if quote == base {
+			return nil, fmt.Errorf("%w: %s cannot be quoted in itself", ErrInvalidCurrency, quote)
+		}
+		wanted[quote] = true
+	}
+
+	var pairs []Pair
+	for _, value := range pairOrder {
+		pair := Pair{value: value}
+		if wanted[pair.Base()] || wanted[pair.Quote()] {
+			pairs = append(pairs, pair)
+		}
+	}
+	return pairs, nil
+}
+
+// CrossRates prices the base currency in each quote currency from the given LTPs. A direct
+// BASE/QUOTE price is used when available; otherwise the price is derived through an
+// intermediate currency X from BASE/X and either X/QUOTE or QUOTE/X.
+func CrossRates(base string, quotes []string, ltps []LTP) ([]CrossQuote, error) {
+	base = strings.ToUpper(strings.TrimSpace(base))
+	prices := make(map[string]float64, len(ltps))
+	for _, ltp := range ltps {
+		if ltp.Amount > 0 {
+			prices[ltp.Pair.Value()] = ltp.Amount
+		}
+	}
+
+	result := make([]CrossQuote, 0, len(quotes))
+	for _, quote := range quotes {
+		quote = strings.ToUpper(strings.TrimSpace(quote))
+		crossQuote, ok := crossQuote(base, quote, ltps, prices)
+		if !ok {
+			return nil, fmt.Errorf("%w: no direct or derived price of %s in %s", ErrRateUnavailable, base, quote)
+		}
+		result = append(result, crossQuote)
+	}
+	return result, nil
+}
+
+// crossQuote prices base in quote, directly or through a single intermediate currency
+func crossQuote(base, quote string, ltps []LTP, prices map[string]float64) (CrossQuote, bool) {
+	if amount, ok := prices[base+"/"+quote]; ok {
+		return CrossQuote{Quote: quote, Amount: amount}, true
+	}
+	for _, ltp := range ltps {
+		via := ltp.Pair.Quote()
+		if ltp.Pair.Base() != base || ltp.Amount <= 0 || via == quote {
+			continue
+		}
+		if rate, ok := prices[via+"/"+quote]; ok {
+			return CrossQuote{Quote: quote, Amount: ltp.Amount * rate, Via: via}, true
+		}
+		if rate, ok := prices[quote+"/"+via]; ok {
+			return CrossQuote{Quote: quote, Amount: ltp.Amount / rate, Via: via}, true
+		}
+	}
+	return CrossQuote{}, false
+}
+
+// knownCurrencies returns the currencies appearing in any valid pair
+func knownCurrencies() map[string]bool {
+	currencies := make(map[string]bool)
+	for _, value := range pairOrder {
+		pair := Pair{value: value}
+		currencies[pair.Base()] = true
+		currencies[pair.Quote()] = true
+	}
+	return currencies
+}
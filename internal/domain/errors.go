@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidPair is returned when a requested pair is malformed or not a known pair
+	ErrInvalidPair = errors.New("invalid pair")
+	// ErrTooManyPairs is returned when a request names more pairs than the configured maximum
+	ErrTooManyPairs = errors.New("too many pairs")
+	// ErrUnsupportedPair is returned when a valid pair is not supported by any configured provider
+	ErrUnsupportedPair = errors.New("pair not supported by any provider")
+	// ErrUpstreamUnavailable is returned when the external price provider cannot be reached or fails
+	ErrUpstreamUnavailable = errors.New("upstream unavailable")
+	// ErrInvalidCurrency is returned when a currency is not among the supported quote currencies
+	ErrInvalidCurrency = errors.New("invalid currency")
+	// ErrRateUnavailable is returned when a cross rate cannot be derived from the available prices
+	ErrRateUnavailable = errors.New("rate unavailable")
+	// ErrCandlesUnsupported is returned when the configured price provider cannot serve candles
+	ErrCandlesUnsupported = errors.New("candles not supported by the price provider")
+	// ErrInsufficientHistory is returned when stored history does not cover a requested time window
+	ErrInsufficientHistory = errors.New("insufficient history")
+	// ErrPriceNotFound is returned when the external price provider has no price for a requested pair
+	ErrPriceNotFound = errors.New("price not found")
+	// ErrUnknownProvider is returned when a request selects a price provider that is not configured
+	ErrUnknownProvider = errors.New("unknown provider")
+)
+
+// ErrRateLimited is returned when the external price provider rejects a request for exceeding its rate limit
+var ErrRateLimited = errors.New("rate limited by the price provider")
+
+// RateLimitError carries the wait suggested by the price provider along with ErrRateLimited.
+// A zero RetryAfter means the provider did not suggest one.
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter <= 0 {
+		return ErrRateLimited.Error()
+	}
+	return fmt.Sprintf("%s, retry after %s", ErrRateLimited, e.RetryAfter)
+}
+
+// Is makes errors.Is(err, ErrRateLimited) match a RateLimitError
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// ErrPriceOutOfBounds is returned when the external price provider reports an implausible price
+var ErrPriceOutOfBounds = errors.New("price out of bounds")
+
+// PriceBoundsError carries the rejected price along with ErrPriceOutOfBounds
+type PriceBoundsError struct {
+	Pair   Pair
+	Amount float64
+	Bounds AmountBounds
+}
+
+func (e *PriceBoundsError) Error() string {
+	switch {
+	case e.Bounds.Max > 0:
+		return fmt.Sprintf("%s: %s at %g, expected between %g and %g", ErrPriceOutOfBounds, e.Pair.Value(), e.Amount, e.Bounds.Min, e.Bounds.Max)
+	case e.Bounds.Min > 0:
+		return fmt.Sprintf("%s: %s at %g, expected at least %g", ErrPriceOutOfBounds, e.Pair.Value(), e.Amount, e.Bounds.Min)
+	default:
+		return fmt.Sprintf("%s: %s at %g, expected a positive price", ErrPriceOutOfBounds, e.Pair.Value(), e.Amount)
+	}
+}
+
+// Is makes errors.Is(err, ErrPriceOutOfBounds) match a PriceBoundsError
+func (e *PriceBoundsError) Is(target error) bool {
+	return target == ErrPriceOutOfBounds
+}
+
+// PartialFetchError is returned along with the prices that were fetched when a provider
+// could not fetch some of the requested pairs. Failed holds the error of each missing pair.
+type PartialFetchError struct {
+	Failed map[Pair]error
+}
+
+func (e *PartialFetchError) Error() string {
+	failed := make([]string, 0, len(e.Failed))
+	for pair, err := range e.Failed {
+		failed = append(failed, fmt.Sprintf("%s: %v", pair.Value(), err))
+	}
+	sort.Strings(failed)
+	return fmt.Sprintf("failed to fetch %d of the pairs: %s", len(e.Failed), strings.Join(failed, "; "))
+}
+
+// Unwrap exposes the errors of the failed pairs to errors.Is and errors.As
+func (e *PartialFetchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failed))
+	for _, err := range e.Failed {
+		errs = append(errs, err)
+	}
+	return errs
+}
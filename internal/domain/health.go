@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// HealthStatus is the state of a component as reported by readiness checks
+type HealthStatus string
+
+const (
+	// HealthOK means the component works normally
+	HealthOK HealthStatus = "ok"
+	// HealthDegraded means the component works with reduced functionality
+	HealthDegraded HealthStatus = "degraded"
+	// HealthDown means the component does not work
+	HealthDown HealthStatus = "down"
+)
+
+// RefresherStatus is a snapshot of the background refresher's recent outcomes
+type RefresherStatus struct {
+	// LastSuccess is when a refresh last succeeded; zero until the first success
+	LastSuccess time.Time
+	// ConsecutiveFailures counts the refreshes that failed since the last success
+	ConsecutiveFailures int
+	// LastError describes the most recent failure; empty after a success
+	LastError string
+	// Degraded is set once the failures reach the refresher's threshold
+	Degraded bool
+}
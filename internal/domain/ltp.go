@@ -1,6 +1,9 @@
 package domain
 
 import (
+	"math"
+	"regexp"
+	"strconv"
 	"time"
 )
 
@@ -8,6 +11,69 @@ import (
 type LTP struct {
 	Pair   Pair
 	Amount float64
+	// RawAmount is the amount exactly as the provider reported it, so it can be
+	// served without float rounding; empty when unknown
+	RawAmount string
+	// Symbol is the upstream symbol the provider reported the price under, e.g. XXBTZUSD;
+	// empty when unknown
+	Symbol string
+	// Bid and Ask are the best bid/ask prices when the provider reports them; zero means unknown
+	Bid float64
+	Ask float64
+	// Stale marks a value served from an expired cache entry because the upstream failed
+	Stale bool
+	// Cached marks a value served from a fresh cache entry rather than fetched
+	Cached bool
+	// UpdatedAt is when the value was fetched from the provider, as set by the service;
+	// zero when unknown
+	UpdatedAt time.Time
+	// At is the time the provider reported for the value, by its own clock; zero when the
+	// provider does not report one, as Kraken's ticker doesn't
+	At time.Time
+	// WithinSLA reports whether the value's age meets its pair's freshness SLA;
+	// nil when no SLA applies or the check was not requested
+	WithinSLA *bool
+	// Change24h is the movement since the oldest stored sample of the last 24 hours;
+	// nil when not requested or there is no history to compare against
+	Change24h *PriceChange
+}
+
+// PriceChange is the movement of a price relative to an earlier price
+type PriceChange struct {
+	Amount  float64
+	Percent float64
+}
+
+// NewPriceChange computes the change from one price to another. ok is false when the
+// earlier price is zero and no percentage can be derived.
+func NewPriceChange(from, to float64) (change PriceChange, ok bool) {
+	if from == 0 {
+		return PriceChange{}, false
+	}
+	return PriceChange{Amount: to - from, Percent: (to - from) / from * 100}, true
+}
+
+// PreciseAmount returns the amount as a decimal string, preferring the provider's
+// exact representation over the float
+func (l LTP) PreciseAmount() string {
+	if l.RawAmount != "" {
+		return l.RawAmount
+	}
+	return strconv.FormatFloat(l.Amount, 'f', -1, 64)
+}
+
+// jsonNumber matches the JSON number grammar, which is stricter than strconv.ParseFloat:
+// it has no leading plus sign, no bare leading or trailing dot, no hex and no NaN or Inf
+var jsonNumber = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// ReportedAmount returns the amount a provider reported as raw when it is a valid JSON
+// number, so it can be served exactly as reported, and otherwise the parsed amount as a
+// decimal string. Adapters use it to set RawAmount.
+func ReportedAmount(raw string, amount float64) string {
+	if jsonNumber.MatchString(raw) {
+		return raw
+	}
+	return strconv.FormatFloat(amount, 'f', -1, 64)
 }
 
 // CachedLTP represents an LTP with timestamp for cache management
@@ -16,16 +82,97 @@ type CachedLTP struct {
 	Timestamp time.Time
 }
 
-// IsExpired checks if the cached LTP has expired (older than 1 minute)
-func (c *CachedLTP) IsExpired() bool {
-	return time.Since(c.Timestamp) > time.Minute
+// HistoryPage is a page of stored samples of a pair, oldest first
+type HistoryPage struct {
+	Samples []CachedLTP
+	// More reports whether older samples exist before this page
+	More bool
+}
+
+// DefaultTTL is how long a cached LTP stays fresh unless configured otherwise
+const DefaultTTL = time.Minute
+
+// TTLPolicy decides how long cached LTPs stay fresh
+type TTLPolicy struct {
+	// TTL is the global time-to-live; zero means DefaultTTL
+	TTL time.Duration
+	// MinTTL holds per-pair minimum residency floors, keyed by pair value.
+	// A floor only applies when it is longer than the global TTL.
+	MinTTL map[string]time.Duration
+}
+
+// For returns the effective TTL for the given pair
+func (p TTLPolicy) For(pair Pair) time.Duration {
+	ttl := p.TTL
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	if floor, ok := p.MinTTL[pair.Value()]; ok && floor > ttl {
+		ttl = floor
+	}
+	return ttl
+}
+
+// IsExpired checks if the cached LTP is older than the TTL the policy assigns to its pair
+func (c *CachedLTP) IsExpired(policy TTLPolicy) bool {
+	return c.IsExpiredAt(policy, time.Now())
+}
+
+// IsExpiredAt checks if the cached LTP is older than its TTL as of now
+func (c *CachedLTP) IsExpiredAt(policy TTLPolicy, now time.Time) bool {
+	return now.Sub(c.Timestamp) > policy.For(c.LTP.Pair)
+}
+
+// FreshnessSLA holds per-pair maximum data ages, keyed by pair value
+type FreshnessSLA map[string]time.Duration
+
+// Check reports whether data for the pair last updated at updatedAt meets its SLA.
+// applies is false when no SLA is configured for the pair.
+func (s FreshnessSLA) Check(pair Pair, updatedAt time.Time) (ok bool, applies bool) {
+	maxAge, applies := s[pair.Value()]
+	if !applies {
+		return false, false
+	}
+	return time.Since(updatedAt) <= maxAge, true
+}
+
+// AmountBounds is a range of plausible prices; a zero Max means no upper bound
+type AmountBounds struct {
+	Min float64
+	Max float64
+}
+
+// PriceBounds holds plausible price ranges, keyed by quote currency (e.g. "USD")
+type PriceBounds map[string]AmountBounds
+
+// Check returns a *PriceBoundsError when the amount of the LTP is not a finite positive
+// number or lies outside the bounds of its quote currency. Currencies without bounds only
+// need a finite positive amount.
+func (b PriceBounds) Check(ltp LTP) error {
+	bounds := b[ltp.Pair.Quote()]
+	if math.IsNaN(ltp.Amount) || math.IsInf(ltp.Amount, 0) || ltp.Amount <= 0 || ltp.Amount < bounds.Min || (bounds.Max > 0 && ltp.Amount > bounds.Max) {
+		return &PriceBoundsError{Pair: ltp.Pair, Amount: ltp.Amount, Bounds: bounds}
+	}
+	return nil
+}
+
+// CacheStats summarizes the current state of an LTP cache
+type CacheStats struct {
+	Entries int
+	Expired int
+	Oldest  time.Time
+	Newest  time.Time
 }
 
 // NewCachedLTP creates a new CachedLTP with current timestamp
 func NewCachedLTP(ltp LTP) *CachedLTP {
+	return NewCachedLTPAt(ltp, time.Now())
+}
+
+// NewCachedLTPAt creates a new CachedLTP stored at the given time
+func NewCachedLTPAt(ltp LTP, at time.Time) *CachedLTP {
 	return &CachedLTP{
 		LTP:       ltp,
-		Timestamp: time.Now(),
+		Timestamp: at,
 	}
 }
-
@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLTP_PreciseAmount(t *testing.T) {
+	t.Run("prefers the provider's raw amount", func(t *testing.T) {
+		ltp := LTP{Amount: 52000.1, RawAmount: "52000.10000"}
+		assert.Equal(t, "52000.10000", ltp.PreciseAmount())
+	})
+
+	t.Run("falls back to the shortest float representation", func(t *testing.T) {
+		ltp := LTP{Amount: 52000.12}
+		assert.Equal(t, "52000.12", ltp.PreciseAmount())
+	})
+}
+
+func TestReportedAmount(t *testing.T) {
+	tests := []struct {
+		raw      string
+		amount   float64
+		expected string
+	}{
+		{"52000.10000", 52000.1, "52000.10000"},
+		{"-1.5e3", -1500, "-1.5e3"},
+		{"0", 0, "0"},
+		{"+5", 5, "5"},
+		{".5", 0.5, "0.5"},
+		{"5.", 5, "5"},
+		{"0x1p3", 8, "8"},
+		{"007", 7, "7"},
+		{"1_000", 1000, "1000"},
+		{"", 52000.12, "52000.12"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			amount := ReportedAmount(tt.raw, tt.amount)
+
+			assert.Equal(t, tt.expected, amount)
+			_, err := json.Marshal(json.Number(amount))
+			assert.NoError(t, err, "the amount must be servable as a JSON number")
+		})
+	}
+}
+
+func TestNewPriceChange(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to float64
+		expected PriceChange
+		ok       bool
+	}{
+		{"increase", 50000, 52000, PriceChange{Amount: 2000, Percent: 4}, true},
+		{"decrease", 50000, 49000, PriceChange{Amount: -1000, Percent: -2}, true},
+		{"unchanged", 50000, 50000, PriceChange{}, true},
+		{"zero baseline", 0, 50000, PriceChange{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			change, ok := NewPriceChange(tt.from, tt.to)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, change)
+		})
+	}
+}
+
+func TestCachedLTP_IsExpiredAt(t *testing.T) {
+	btcUSD, _ := NewPair(BTCUSD)
+	storedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	cached := NewCachedLTPAt(LTP{Pair: btcUSD, Amount: 52000.12}, storedAt)
+	policy := TTLPolicy{TTL: time.Minute}
+
+	assert.Equal(t, storedAt, cached.Timestamp)
+	assert.False(t, cached.IsExpiredAt(policy, storedAt))
+	assert.False(t, cached.IsExpiredAt(policy, storedAt.Add(time.Minute)))
+	assert.True(t, cached.IsExpiredAt(policy, storedAt.Add(time.Minute+time.Nanosecond)))
+}
+
+func TestPriceBounds_Check(t *testing.T) {
+	btcUSD, _ := NewPair(BTCUSD)
+	btcEUR, _ := NewPair(BTCEUR)
+	bounds := PriceBounds{"USD": {Min: 1000, Max: 1000000}, "EUR": {Min: 1000}}
+
+	tests := []struct {
+		name   string
+		ltp    LTP
+		within bool
+	}{
+		{"within bounds", LTP{Pair: btcUSD, Amount: 52000.12}, true},
+		{"zero", LTP{Pair: btcUSD, Amount: 0}, false},
+		{"below the minimum", LTP{Pair: btcUSD, Amount: 999}, false},
+		{"above the maximum", LTP{Pair: btcUSD, Amount: 1000001}, false},
+		{"no maximum", LTP{Pair: btcEUR, Amount: 1e9}, true},
+		{"NaN", LTP{Pair: btcEUR, Amount: math.NaN()}, false},
+		{"infinite", LTP{Pair: btcEUR, Amount: math.Inf(1)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := bounds.Check(tt.ltp)
+
+			if tt.within {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrPriceOutOfBounds)
+		})
+	}
+}
+
+func TestPriceBounds_Check_UnboundedCurrencyNeedsPositiveAmount(t *testing.T) {
+	btcCHF, _ := NewPair(BTCCHF)
+	bounds := PriceBounds{"USD": {Min: 1000}}
+
+	assert.NoError(t, bounds.Check(LTP{Pair: btcCHF, Amount: 1}))
+	assert.EqualError(t, bounds.Check(LTP{Pair: btcCHF, Amount: -1}), "price out of bounds: BTC/CHF at -1, expected a positive price")
+	assert.ErrorIs(t, bounds.Check(LTP{Pair: btcCHF, Amount: math.NaN()}), ErrPriceOutOfBounds)
+}
@@ -1,9 +1,11 @@
 package domain
 
 import (
-	"errors"
 	"fmt"
+	"net/url"
+	"sort"
 	"strings"
+	"unicode"
 )
 
 // Pair represents a currency pair value object
@@ -18,19 +20,128 @@ const (
 	BTCEUR = "BTC/EUR"
 )
 
-var validPairs = map[string]bool{
-	BTCUSD: true,
-	BTCCHF: true,
-	BTCEUR: true,
+// pairOrder lists the valid pairs in their default order; validPairs indexes it
+var (
+	pairOrder  = []string{BTCUSD, BTCCHF, BTCEUR}
+	validPairs = map[string]bool{
+		BTCUSD: true,
+		BTCCHF: true,
+		BTCEUR: true,
+	}
+)
+
+// SetValidPairs replaces the compiled-in set of valid pairs, e.g. with pairs loaded
+// from configuration. Values must be in BASE/QUOTE form. It must be called at
+// startup, before pairs are parsed concurrently.
+func SetValidPairs(values []string) error {
+	if len(values) == 0 {
+		return fmt.Errorf("%w: at least one valid pair must be configured", ErrInvalidPair)
+	}
+
+	order := make([]string, 0, len(values))
+	valid := make(map[string]bool, len(values))
+	for _, value := range values {
+		value, err := NormalizePair(value)
+		if err != nil {
+			return err
+		}
+		if valid[value] {
+			return fmt.Errorf("%w: %s is configured more than once", ErrInvalidPair, value)
+		}
+		order = append(order, value)
+		valid[value] = true
+	}
+
+	pairOrder = order
+	validPairs = valid
+	return nil
+}
+
+// ValidPairs returns the valid pair values in their default order
+func ValidPairs() []string {
+	return append([]string(nil), pairOrder...)
+}
+
+// SupportedPairs returns the valid pair values in sorted order
+func SupportedPairs() []string {
+	values := make([]string, 0, len(validPairs))
+	for value := range validPairs {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	return values
+}
+
+// urlDecode decodes a still URL-encoded pair or pairs string, e.g. BTC%2FUSD, leaving
+// anything else as is. It is the only place pairs are decoded, and it decodes once, so a
+// double-encoded BTC%252FUSD stays invalid.
+func urlDecode(value string) string {
+	if strings.Contains(value, "%") {
+		if decoded, err := url.PathUnescape(value); err == nil {
+			return decoded
+		}
+	}
+	return value
+}
+
+// normalizePairValue brings the spellings clients send into BASE/QUOTE form: it trims and
+// upper-cases an already decoded value and accepts - as the separator (BTC-USD)
+func normalizePairValue(value string) string {
+	return strings.ReplaceAll(strings.ToUpper(strings.TrimSpace(value)), "-", "/")
+}
+
+// NormalizePair brings a raw pair into its canonical BASE/QUOTE form. The value is
+// trimmed and upper-cased, a still URL-encoded one is decoded, and - is accepted as the
+// separator, so "btc-usd" and "BTC%2FUSD" both normalize to BTC/USD. Base and quote must
+// be non-empty and alphanumeric. Whether the pair is a valid one is not checked; see
+// NewPair for that.
+func NormalizePair(raw string) (string, error) {
+	return normalizeDecodedPair(urlDecode(strings.TrimSpace(raw)))
+}
+
+// normalizeDecodedPair is NormalizePair for a value that was already URL-decoded
+func normalizeDecodedPair(raw string) (string, error) {
+	value := normalizePairValue(raw)
+	base, quote, ok := strings.Cut(value, "/")
+	if !ok || !isCurrencyCode(base) || !isCurrencyCode(quote) {
+		return "", fmt.Errorf("%w: %s. Pairs must be in BASE/QUOTE form", ErrInvalidPair, value)
+	}
+	return value, nil
+}
+
+// isCurrencyCode reports whether code is a non-empty run of upper-case letters and digits
+func isCurrencyCode(code string) bool {
+	if code == "" {
+		return false
+	}
+	for _, r := range code {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// SplitPair returns the base and quote currencies of a pair, e.g. BTC and USD for BTC/USD
+func SplitPair(p Pair) (base, quote string) {
+	base, quote, _ = strings.Cut(p.value, "/")
+	return base, quote
 }
 
-// NewPair creates a new Pair value object
-func NewPair(value string) (Pair, error) {
-	value = strings.ToUpper(strings.TrimSpace(value))
-	if !validPairs[value] {
-		return Pair{}, fmt.Errorf("invalid pair: %s. Valid pairs are: BTC/USD, BTC/CHF, BTC/EUR", value)
+// NewPair creates a new Pair value object. The value is normalized first with
+// NormalizePair, so "btc/usd", "BTC-USD" and "BTC%2FUSD" all create BTC/USD. Of the
+// options, only WithValidPairs applies.
+func NewPair(value string, opts ...ParseOption) (Pair, error) {
+	return newDecodedPair(urlDecode(strings.TrimSpace(value)), newParseConfig(opts))
+}
+
+// newDecodedPair is NewPair for a value that was already URL-decoded
+func newDecodedPair(value string, cfg parseConfig) (Pair, error) {
+	normalized, err := normalizeDecodedPair(value)
+	if err != nil || !cfg.isValid(normalized) {
+		return Pair{}, fmt.Errorf("%w: %s. Valid pairs are: %s", ErrInvalidPair, normalizePairValue(value), strings.Join(cfg.validOrder(), ", "))
 	}
-	return Pair{value: value}, nil
+	return Pair{value: normalized}, nil
 }
 
 // Value returns the string value of the pair
@@ -45,28 +156,125 @@ func (p Pair) String() string {
 
 // IsValid checks if a string is a valid pair
 func IsValidPair(value string) bool {
-	value = strings.ToUpper(strings.TrimSpace(value))
+	normalized, err := NormalizePair(value)
+	return err == nil && validPairs[normalized]
+}
+
+// ParseOption configures optional ParsePairs behavior, and that of the other functions
+// taking it where it applies
+type ParseOption func(*parseConfig)
+
+type parseConfig struct {
+	spaceSeparated bool
+	maxPairs       int
+	defaults       []Pair
+	// order and valid replace the valid pairs when set, see WithValidPairs
+	order []string
+	valid map[string]bool
+}
+
+// newParseConfig applies the given options to a default configuration
+func newParseConfig(opts []ParseOption) parseConfig {
+	var cfg parseConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// validOrder returns the valid pairs in their default order
+func (cfg parseConfig) validOrder() []string {
+	if cfg.valid != nil {
+		return cfg.order
+	}
+	return pairOrder
+}
+
+// isValid reports whether a normalized pair value is a valid pair
+func (cfg parseConfig) isValid(value string) bool {
+	if cfg.valid != nil {
+		return cfg.valid[value]
+	}
 	return validPairs[value]
 }
 
-// ParsePairs parses a comma-separated string of pairs
-func ParsePairs(pairsStr string) ([]Pair, error) {
-	// If empty, return all valid pairs
+// DefaultMaxPairs is the most pairs a client request may name unless configured otherwise.
+// ParsePairs only enforces a limit when given WithMaxPairs.
+const DefaultMaxPairs = 20
+
+// WithSpaceSeparators makes ParsePairs treat whitespace as a separator in addition to commas,
+// so "BTC/USD BTC/EUR" parses as two pairs. Without it surrounding whitespace is only trimmed.
+func WithSpaceSeparators() ParseOption {
+	return func(cfg *parseConfig) {
+		cfg.spaceSeparated = true
+	}
+}
+
+// WithMaxPairs sets the most distinct pairs ParsePairs accepts in one string; without it,
+// or with a non-positive value, there is no limit. Segments are counted once normalized,
+// so repeating a pair, in any spelling, does not count against the limit.
+func WithMaxPairs(max int) ParseOption {
+	return func(cfg *parseConfig) {
+		cfg.maxPairs = max
+	}
+}
+
+// WithValidPairs checks pairs against the given values instead of the valid pairs set
+// with SetValidPairs, e.g. to validate settings against pairs loaded from configuration
+// before they are applied. Values are normalized like in NormalizePair; malformed ones
+// are ignored.
+func WithValidPairs(values []string) ParseOption {
+	return func(cfg *parseConfig) {
+		cfg.order = make([]string, 0, len(values))
+		cfg.valid = make(map[string]bool, len(values))
+		for _, value := range values {
+			value, err := NormalizePair(value)
+			if err != nil || cfg.valid[value] {
+				continue
+			}
+			cfg.order = append(cfg.order, value)
+			cfg.valid[value] = true
+		}
+	}
+}
+
+// WithDefaultPairs sets the pairs ParsePairs returns for an empty string. Without it,
+// or with an empty set, all valid pairs are returned.
+func WithDefaultPairs(pairs []Pair) ParseOption {
+	return func(cfg *parseConfig) {
+		cfg.defaults = pairs
+	}
+}
+
+// ParsePairs parses a comma-separated string of pairs. Each segment is trimmed and
+// empty segments (e.g. from "BTC/USD,,BTC/EUR,") are skipped; duplicates are dropped.
+// Pairs are normalized like in NewPair, and a still URL-encoded string is decoded first.
+func ParsePairs(pairsStr string, opts ...ParseOption) ([]Pair, error) {
+	cfg := newParseConfig(opts)
+
+	// If empty, return the configured defaults or else all valid pairs
 	if pairsStr == "" {
-		return []Pair{
-			{value: BTCUSD},
-			{value: BTCCHF},
-			{value: BTCEUR},
-		}, nil
+		if len(cfg.defaults) > 0 {
+			return append([]Pair(nil), cfg.defaults...), nil
+		}
+		order := cfg.validOrder()
+		pairs := make([]Pair, len(order))
+		for i, value := range order {
+			pairs[i] = Pair{value: value}
+		}
+		return pairs, nil
+	}
+
+	pairs, err := splitPairs(pairsStr, cfg)
+	if err != nil {
+		return nil, err
 	}
 
-	// Parse and validate each pair
-	pairs := strings.Split(pairsStr, ",")
 	result := make([]Pair, 0, len(pairs))
 	seen := make(map[string]bool)
 
 	for _, p := range pairs {
-		pair, err := NewPair(p)
+		pair, err := newDecodedPair(p, cfg)
 		if err != nil {
 			return nil, err
 		}
@@ -78,9 +286,98 @@ func ParsePairs(pairsStr string) ([]Pair, error) {
 	}
 
 	if len(result) == 0 {
-		return nil, errors.New("at least one valid pair must be specified")
+		return nil, fmt.Errorf("%w: at least one valid pair must be specified", ErrInvalidPair)
 	}
 
 	return result, nil
 }
 
+// CanonicalPairs returns the sorted, comma-separated form of the given pairs
+func CanonicalPairs(pairs []Pair) string {
+	values := make([]string, len(pairs))
+	for i, pair := range pairs {
+		values[i] = pair.Value()
+	}
+	sort.Strings(values)
+	return strings.Join(values, ",")
+}
+
+// splitPairs splits a non-empty pairs string into its non-empty, trimmed segments,
+// rejecting strings with more distinct pairs than the configured maximum
+func splitPairs(pairsStr string, cfg parseConfig) ([]string, error) {
+	// A still URL-encoded string may hide its separators, e.g. BTC%2FUSD%2CBTC%2FEUR, so
+	// it is decoded as a whole and its segments are not decoded again
+	pairsStr = urlDecode(pairsStr)
+	segments := strings.Split(pairsStr, ",")
+	if cfg.spaceSeparated {
+		segments = strings.FieldsFunc(pairsStr, func(r rune) bool {
+			return r == ',' || unicode.IsSpace(r)
+		})
+	}
+
+	result := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		if segment = strings.TrimSpace(segment); segment != "" {
+			result = append(result, segment)
+		}
+	}
+	// Check the size before validating so oversized lists are rejected cheaply
+	if cfg.maxPairs > 0 && len(result) > cfg.maxPairs {
+		distinct := make(map[string]bool, len(result))
+		for _, segment := range result {
+			distinct[normalizePairValue(segment)] = true
+		}
+		if len(distinct) > cfg.maxPairs {
+			return nil, fmt.Errorf("%w: %d requested, at most %d allowed", ErrTooManyPairs, len(distinct), cfg.maxPairs)
+		}
+	}
+	return result, nil
+}
+
+// InvalidPair is an entry of a pairs string that cannot be served, with the reason
+type InvalidPair struct {
+	Value string
+	Err   error
+}
+
+// PairValidation reports which entries of a pairs string are valid, each listed once
+// in the order first given
+type PairValidation struct {
+	Valid   []Pair
+	Invalid []InvalidPair
+}
+
+// ValidatePairs checks a pairs string like ParsePairs does, but collects every invalid
+// entry instead of stopping at the first. It only fails for strings with too many pairs.
+func ValidatePairs(pairsStr string, opts ...ParseOption) (PairValidation, error) {
+	if pairsStr == "" {
+		pairs, err := ParsePairs(pairsStr, opts...)
+		return PairValidation{Valid: pairs}, err
+	}
+
+	cfg := newParseConfig(opts)
+	segments, err := splitPairs(pairsStr, cfg)
+	if err != nil {
+		return PairValidation{}, err
+	}
+
+	var validation PairValidation
+	seen := make(map[string]bool)
+	for _, segment := range segments {
+		pair, err := newDecodedPair(segment, cfg)
+		key, normalizeErr := normalizeDecodedPair(segment)
+		if normalizeErr != nil {
+			key = strings.ToUpper(segment)
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if err != nil {
+			validation.Invalid = append(validation.Invalid, InvalidPair{Value: segment, Err: err})
+		} else {
+			validation.Valid = append(validation.Valid, pair)
+		}
+	}
+	return validation, nil
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+// PairError is the last failure to fetch a pair from the upstream, kept until a fetch of
+// the pair succeeds
+type PairError struct {
+	Pair  string
+	Error string
+	// At is when the fetch failed
+	At time.Time
+}
@@ -0,0 +1,625 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// restoreValidPairs resets the valid pairs to the compiled-in defaults after a test
+func restoreValidPairs(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() {
+		require.NoError(t, SetValidPairs([]string{BTCUSD, BTCCHF, BTCEUR}))
+	})
+}
+
+func TestSetValidPairs_ReplacesDefaults(t *testing.T) {
+	restoreValidPairs(t)
+
+	require.NoError(t, SetValidPairs([]string{"eth/usd", BTCUSD}))
+
+	assert.Equal(t, []string{"ETH/USD", BTCUSD}, ValidPairs())
+	assert.True(t, IsValidPair("ETH/USD"))
+	assert.False(t, IsValidPair(BTCEUR))
+
+	pairs, err := ParsePairs("")
+	require.NoError(t, err)
+	require.Len(t, pairs, 2)
+	assert.Equal(t, "ETH/USD", pairs[0].Value())
+	assert.Equal(t, BTCUSD, pairs[1].Value())
+
+	_, err = NewPair(BTCEUR)
+	assert.ErrorIs(t, err, ErrInvalidPair)
+	assert.Contains(t, err.Error(), "Valid pairs are: ETH/USD, BTC/USD")
+}
+
+func TestSetValidPairs_Invalid(t *testing.T) {
+	restoreValidPairs(t)
+
+	tests := []struct {
+		name   string
+		values []string
+	}{
+		{"empty", nil},
+		{"missing quote", []string{"BTC/"}},
+		{"no separator", []string{"BTCUSD"}},
+		{"too many parts", []string{"BTC/USD/EUR"}},
+		{"duplicate", []string{BTCUSD, "btc/usd"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := SetValidPairs(tt.values)
+
+			assert.ErrorIs(t, err, ErrInvalidPair)
+			// The previous pairs are kept on error
+			assert.Equal(t, []string{BTCUSD, BTCCHF, BTCEUR}, ValidPairs())
+		})
+	}
+}
+
+func TestSupportedPairs_Sorted(t *testing.T) {
+	restoreValidPairs(t)
+
+	assert.Equal(t, []string{BTCCHF, BTCEUR, BTCUSD}, SupportedPairs())
+
+	require.NoError(t, SetValidPairs([]string{"ETH/USD", BTCUSD, "ADA/EUR"}))
+	assert.Equal(t, []string{"ADA/EUR", BTCUSD, "ETH/USD"}, SupportedPairs())
+}
+
+func TestParsePairs(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{"single pair", "BTC/USD", []string{BTCUSD}},
+		{"trailing comma", "BTC/USD,", []string{BTCUSD}},
+		{"leading comma", ",BTC/USD", []string{BTCUSD}},
+		{"doubled commas", "BTC/USD,,BTC/EUR", []string{BTCUSD, BTCEUR}},
+		{"spaces around entries", " BTC/USD , BTC/EUR ", []string{BTCUSD, BTCEUR}},
+		{"blank segment", "BTC/USD, ,BTC/EUR", []string{BTCUSD, BTCEUR}},
+		{"mixed case", "btc/usd,Btc/Eur", []string{BTCUSD, BTCEUR}},
+		{"duplicates in different case", "BTC/USD,btc/usd, BTC/USD ", []string{BTCUSD}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pairs, err := ParsePairs(tt.input)
+
+			require.NoError(t, err)
+			values := make([]string, len(pairs))
+			for i, pair := range pairs {
+				values[i] = pair.Value()
+			}
+			assert.Equal(t, tt.expected, values)
+		})
+	}
+}
+
+func TestNormalizePair(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"canonical", "BTC/USD", "BTC/USD"},
+		{"lower case", "eth/eur", "ETH/EUR"},
+		{"mixed case", "Btc/uSd", "BTC/USD"},
+		{"surrounding whitespace", " \tBTC/USD\n", "BTC/USD"},
+		{"dash separator", "btc-chf", "BTC/CHF"},
+		{"url encoded slash", "BTC%2FUSD", "BTC/USD"},
+		{"lower case url encoding", "btc%2fusd", "BTC/USD"},
+		{"encoded surrounding space", "%20BTC%2FUSD%20", "BTC/USD"},
+		{"digits", "1inch/usdt", "1INCH/USDT"},
+		{"not a valid pair but well formed", "DOGE/XYZ", "DOGE/XYZ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			normalized, err := NormalizePair(tt.input)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, normalized)
+		})
+	}
+}
+
+func TestNormalizePair_Malformed(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"empty", ""},
+		{"blank", "   "},
+		{"no separator", "BTCUSD"},
+		{"missing base", "/USD"},
+		{"missing quote", "BTC-"},
+		{"too many parts", "BTC/USD/EUR"},
+		{"double dash", "BTC--USD"},
+		{"underscore separator", "BTC_USD"},
+		{"inner whitespace", "BTC/ USD"},
+		{"malformed encoding", "BTC%2"},
+		{"non-ASCII letters", "BTC/ÜSD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			normalized, err := NormalizePair(tt.input)
+
+			// Assert
+			assert.ErrorIs(t, err, ErrInvalidPair)
+			assert.Contains(t, err.Error(), "BASE/QUOTE")
+			assert.Empty(t, normalized)
+		})
+	}
+}
+
+func TestSplitPair(t *testing.T) {
+	restoreValidPairs(t)
+	require.NoError(t, SetValidPairs([]string{BTCUSD, "eth-eur"}))
+
+	tests := []struct {
+		input         string
+		expectedBase  string
+		expectedQuote string
+	}{
+		{"BTC/USD", "BTC", "USD"},
+		{"btc-usd", "BTC", "USD"},
+		{"ETH/EUR", "ETH", "EUR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			// Arrange
+			pair, err := NewPair(tt.input)
+			require.NoError(t, err)
+
+			// Act
+			base, quote := SplitPair(pair)
+
+			// Assert
+			assert.Equal(t, tt.expectedBase, base)
+			assert.Equal(t, tt.expectedQuote, quote)
+			assert.Equal(t, pair.Base(), base)
+			assert.Equal(t, pair.Quote(), quote)
+		})
+	}
+
+	t.Run("zero pair", func(t *testing.T) {
+		base, quote := SplitPair(Pair{})
+
+		assert.Empty(t, base)
+		assert.Empty(t, quote)
+	})
+}
+
+func TestNewPair_NormalizesVariants(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"canonical", "BTC/USD"},
+		{"lower case", "btc/usd"},
+		{"surrounding spaces", "  BTC/USD "},
+		{"dash separator", "BTC-USD"},
+		{"lower case dash", "btc-usd"},
+		{"url encoded slash", "BTC%2FUSD"},
+		{"lower case url encoding", "btc%2fusd"},
+		{"encoded surrounding space", "%20BTC%2FUSD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pair, err := NewPair(tt.input)
+
+			require.NoError(t, err)
+			assert.Equal(t, BTCUSD, pair.Value())
+			assert.True(t, IsValidPair(tt.input))
+		})
+	}
+}
+
+func TestNewPair_RejectsUnknownVariants(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"unknown quote with dash", "BTC-XYZ"},
+		{"unknown quote encoded", "BTC%2FXYZ"},
+		{"no separator", "BTCUSD"},
+		{"underscore separator", "BTC_USD"},
+		{"malformed encoding", "BTC%2"},
+		{"double-encoded slash", "BTC%252FUSD"},
+		{"double dash", "BTC--USD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewPair(tt.input)
+
+			assert.ErrorIs(t, err, ErrInvalidPair)
+			assert.False(t, IsValidPair(tt.input))
+		})
+	}
+}
+
+func TestParsePairs_NormalizesVariants(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{"dash separators", "BTC-USD,btc-eur", []string{BTCUSD, BTCEUR}},
+		{"mixed spellings deduplicated", "BTC-USD,btc/usd,BTC%2FUSD", []string{BTCUSD}},
+		{"encoded pairs", "BTC%2FUSD,BTC%2FEUR", []string{BTCUSD, BTCEUR}},
+		{"encoded commas", "BTC%2FUSD%2CBTC%2FCHF", []string{BTCUSD, BTCCHF}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pairs, err := ParsePairs(tt.input)
+
+			require.NoError(t, err)
+			values := make([]string, len(pairs))
+			for i, pair := range pairs {
+				values[i] = pair.Value()
+			}
+			assert.Equal(t, tt.expected, values)
+		})
+	}
+}
+
+func TestParsePairs_DuplicatesKeepFirstOccurrence(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{"same pair differently cased", "BTC/USD,btc/usd", []string{BTCUSD}},
+		{"lowercase first", "btc/usd,BTC/USD", []string{BTCUSD}},
+		{"position of the first occurrence", "btc/eur,BTC/USD,BTC/EUR,Btc/Usd,BTC/CHF", []string{BTCEUR, BTCUSD, BTCCHF}},
+		{"padded duplicates", " BTC/CHF , btc/chf,BTC/EUR", []string{BTCCHF, BTCEUR}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pairs, err := ParsePairs(tt.input)
+
+			require.NoError(t, err)
+			values := make([]string, len(pairs))
+			for i, pair := range pairs {
+				values[i] = pair.Value()
+			}
+			assert.Equal(t, tt.expected, values)
+		})
+	}
+}
+
+func TestParsePairs_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"invalid pair", "BTC/USD,BTC/INVALID"},
+		{"only commas", ",,"},
+		{"only whitespace", "   "},
+		{"malformed pair", "BTCUSD"},
+		{"double-encoded slash", "BTC%252FUSD"},
+		{"double-encoded pairs", "BTC%252FUSD,BTC%252FEUR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pairs, err := ParsePairs(tt.input)
+
+			assert.ErrorIs(t, err, ErrInvalidPair)
+			assert.Nil(t, pairs)
+		})
+	}
+}
+
+func TestParsePairs_DefaultPairs(t *testing.T) {
+	btcEUR, _ := NewPair(BTCEUR)
+	btcUSD, _ := NewPair(BTCUSD)
+
+	t.Run("empty string returns the configured defaults", func(t *testing.T) {
+		pairs, err := ParsePairs("", WithDefaultPairs([]Pair{btcEUR, btcUSD}))
+
+		require.NoError(t, err)
+		assert.Equal(t, []Pair{btcEUR, btcUSD}, pairs)
+	})
+
+	t.Run("explicit pairs ignore the defaults", func(t *testing.T) {
+		pairs, err := ParsePairs(BTCCHF, WithDefaultPairs([]Pair{btcEUR}))
+
+		require.NoError(t, err)
+		require.Len(t, pairs, 1)
+		assert.Equal(t, BTCCHF, pairs[0].Value())
+	})
+
+	t.Run("without defaults returns all valid pairs", func(t *testing.T) {
+		pairs, err := ParsePairs("", WithDefaultPairs(nil))
+
+		require.NoError(t, err)
+		assert.Len(t, pairs, len(ValidPairs()))
+	})
+}
+
+func TestParsePairs_MaxPairs(t *testing.T) {
+	t.Run("no limit by default", func(t *testing.T) {
+		segments := make([]string, DefaultMaxPairs+1)
+		for i := range segments {
+			segments[i] = fmt.Sprintf("BTC/X%d", i)
+		}
+
+		_, err := ParsePairs(strings.Join(segments, ","))
+
+		assert.ErrorIs(t, err, ErrInvalidPair, "every pair is validated rather than the list rejected for its size")
+		assert.NotErrorIs(t, err, ErrTooManyPairs)
+	})
+
+	t.Run("accepts up to the configured maximum", func(t *testing.T) {
+		pairs, err := ParsePairs("BTC/USD,BTC/EUR", WithMaxPairs(2))
+
+		require.NoError(t, err)
+		assert.Len(t, pairs, 2)
+	})
+
+	t.Run("rejects more than the configured maximum", func(t *testing.T) {
+		_, err := ParsePairs("BTC/USD,BTC/EUR,BTC/CHF", WithMaxPairs(2))
+
+		assert.ErrorIs(t, err, ErrTooManyPairs)
+		assert.Contains(t, err.Error(), "3 requested, at most 2 allowed")
+	})
+
+	t.Run("empty segments do not count", func(t *testing.T) {
+		_, err := ParsePairs("BTC/USD,,BTC/EUR,", WithMaxPairs(2))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("duplicates count once", func(t *testing.T) {
+		repeated := strings.TrimSuffix(strings.Repeat(BTCUSD+",btc-usd,", DefaultMaxPairs+1), ",")
+
+		pairs, err := ParsePairs(repeated, WithMaxPairs(DefaultMaxPairs))
+
+		require.NoError(t, err)
+		assert.Len(t, pairs, 1)
+	})
+
+	t.Run("non-positive maximum removes the limit", func(t *testing.T) {
+		pairs, err := ParsePairs("BTC/USD,BTC/EUR,BTC/CHF", WithMaxPairs(0))
+
+		require.NoError(t, err)
+		assert.Len(t, pairs, 3)
+	})
+}
+
+func TestWithValidPairs(t *testing.T) {
+	opt := WithValidPairs([]string{"eth-usd", BTCUSD, "malformed"})
+
+	t.Run("checks pairs against the given values", func(t *testing.T) {
+		pair, err := NewPair("ETH/USD", opt)
+		require.NoError(t, err)
+		assert.Equal(t, "ETH/USD", pair.Value())
+
+		_, err = NewPair(BTCEUR, opt)
+		assert.ErrorIs(t, err, ErrInvalidPair)
+		assert.Contains(t, err.Error(), "Valid pairs are: ETH/USD, BTC/USD")
+	})
+
+	t.Run("empty string returns the given values", func(t *testing.T) {
+		pairs, err := ParsePairs("", opt)
+
+		require.NoError(t, err)
+		require.Len(t, pairs, 2)
+		assert.Equal(t, "ETH/USD", pairs[0].Value())
+		assert.Equal(t, BTCUSD, pairs[1].Value())
+	})
+
+	t.Run("pairs by quote", func(t *testing.T) {
+		pairs, err := PairsByQuote("usd", opt)
+
+		require.NoError(t, err)
+		require.Len(t, pairs, 2)
+		assert.Equal(t, "ETH/USD", pairs[0].Value())
+		assert.Equal(t, BTCUSD, pairs[1].Value())
+	})
+
+	t.Run("leaves the valid pairs untouched", func(t *testing.T) {
+		assert.Equal(t, []string{BTCUSD, BTCCHF, BTCEUR}, ValidPairs())
+		assert.False(t, IsValidPair("ETH/USD"))
+	})
+}
+
+func TestValidatePairs(t *testing.T) {
+	btcUSD, _ := NewPair(BTCUSD)
+	btcEUR, _ := NewPair(BTCEUR)
+
+	t.Run("collects every invalid entry", func(t *testing.T) {
+		validation, err := ValidatePairs(" btc/usd,BTC/INVALID,,BTC/EUR,BTCUSD ")
+
+		require.NoError(t, err)
+		assert.Equal(t, []Pair{btcUSD, btcEUR}, validation.Valid)
+		require.Len(t, validation.Invalid, 2)
+		assert.Equal(t, "BTC/INVALID", validation.Invalid[0].Value)
+		assert.ErrorIs(t, validation.Invalid[0].Err, ErrInvalidPair)
+		assert.Equal(t, "BTCUSD", validation.Invalid[1].Value)
+	})
+
+	t.Run("lists duplicates once", func(t *testing.T) {
+		validation, err := ValidatePairs("BTC/USD,btc/usd,BTC/X,btc/x")
+
+		require.NoError(t, err)
+		assert.Equal(t, []Pair{btcUSD}, validation.Valid)
+		require.Len(t, validation.Invalid, 1)
+		assert.Equal(t, "BTC/X", validation.Invalid[0].Value)
+	})
+
+	t.Run("lists spellings of the same invalid pair once", func(t *testing.T) {
+		validation, err := ValidatePairs("BTC/X,btc-x,BTC%2FX")
+
+		require.NoError(t, err)
+		assert.Empty(t, validation.Valid)
+		require.Len(t, validation.Invalid, 1)
+		assert.Equal(t, "BTC/X", validation.Invalid[0].Value)
+	})
+
+	t.Run("honors space separators", func(t *testing.T) {
+		validation, err := ValidatePairs("BTC/USD BTC/X", WithSpaceSeparators())
+
+		require.NoError(t, err)
+		assert.Equal(t, []Pair{btcUSD}, validation.Valid)
+		require.Len(t, validation.Invalid, 1)
+	})
+
+	t.Run("empty string validates the defaults", func(t *testing.T) {
+		validation, err := ValidatePairs("", WithDefaultPairs([]Pair{btcEUR}))
+
+		require.NoError(t, err)
+		assert.Equal(t, []Pair{btcEUR}, validation.Valid)
+		assert.Empty(t, validation.Invalid)
+	})
+
+	t.Run("rejects too many pairs", func(t *testing.T) {
+		_, err := ValidatePairs("BTC/USD,BTC/X,BTC/Y", WithMaxPairs(2))
+
+		assert.ErrorIs(t, err, ErrTooManyPairs)
+	})
+}
+
+func TestPairsByQuote(t *testing.T) {
+	t.Run("default pairs", func(t *testing.T) {
+		pairs, err := PairsByQuote(" usd ")
+
+		require.NoError(t, err)
+		assert.Equal(t, []Pair{{value: BTCUSD}}, pairs)
+	})
+
+	t.Run("every base quoted in the currency", func(t *testing.T) {
+		restoreValidPairs(t)
+		require.NoError(t, SetValidPairs([]string{"ETH/USD", BTCEUR, BTCUSD}))
+
+		pairs, err := PairsByQuote("USD")
+
+		require.NoError(t, err)
+		assert.Equal(t, []Pair{{value: "ETH/USD"}, {value: BTCUSD}}, pairs)
+	})
+
+	t.Run("unsupported quote", func(t *testing.T) {
+		pairs, err := PairsByQuote("JPY")
+
+		assert.ErrorIs(t, err, ErrInvalidCurrency)
+		assert.Contains(t, err.Error(), "USD, CHF, EUR")
+		assert.Nil(t, pairs)
+	})
+}
+
+func TestCrossRatePairs(t *testing.T) {
+	t.Run("pairs involving the base or a quote", func(t *testing.T) {
+		restoreValidPairs(t)
+		require.NoError(t, SetValidPairs([]string{BTCUSD, "ETH/BTC", "ETH/USD", "SOL/CHF"}))
+
+		pairs, err := CrossRatePairs(" eth ", []string{"usd"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []Pair{{value: BTCUSD}, {value: "ETH/BTC"}, {value: "ETH/USD"}}, pairs)
+	})
+
+	t.Run("unknown currency", func(t *testing.T) {
+		_, err := CrossRatePairs("BTC", []string{"USD", "JPY"})
+
+		assert.ErrorIs(t, err, ErrInvalidCurrency)
+		assert.Contains(t, err.Error(), "JPY")
+	})
+
+	t.Run("unknown base", func(t *testing.T) {
+		_, err := CrossRatePairs("DOGE", []string{"USD"})
+
+		assert.ErrorIs(t, err, ErrInvalidCurrency)
+	})
+
+	t.Run("quote equal to base", func(t *testing.T) {
+		_, err := CrossRatePairs("BTC", []string{"BTC"})
+
+		assert.ErrorIs(t, err, ErrInvalidCurrency)
+	})
+}
+
+func TestCrossRates(t *testing.T) {
+	ltp := func(value string, amount float64) LTP {
+		return LTP{Pair: Pair{value: value}, Amount: amount}
+	}
+
+	tests := []struct {
+		name     string
+		base     string
+		quotes   []string
+		ltps     []LTP
+		expected []CrossQuote
+	}{
+		{
+			name:   "direct pairs",
+			base:   "BTC",
+			quotes: []string{"USD", "eur", "CHF"},
+			ltps:   []LTP{ltp(BTCUSD, 52000), ltp(BTCCHF, 49000), ltp(BTCEUR, 50000)},
+			expected: []CrossQuote{
+				{Quote: "USD", Amount: 52000},
+				{Quote: "EUR", Amount: 50000},
+				{Quote: "CHF", Amount: 49000},
+			},
+		},
+		{
+			name:     "derived through the intermediate's base",
+			base:     "ETH",
+			quotes:   []string{"EUR"},
+			ltps:     []LTP{ltp("ETH/BTC", 0.05), ltp(BTCEUR, 50000)},
+			expected: []CrossQuote{{Quote: "EUR", Amount: 2500, Via: "BTC"}},
+		},
+		{
+			name:     "derived through the intermediate's quote",
+			base:     "BTC",
+			quotes:   []string{"ETH"},
+			ltps:     []LTP{ltp(BTCUSD, 50000), ltp("ETH/USD", 2500)},
+			expected: []CrossQuote{{Quote: "ETH", Amount: 20, Via: "USD"}},
+		},
+		{
+			name:     "direct preferred over derived",
+			base:     "ETH",
+			quotes:   []string{"USD"},
+			ltps:     []LTP{ltp("ETH/BTC", 0.05), ltp(BTCUSD, 52000), ltp("ETH/USD", 2590)},
+			expected: []CrossQuote{{Quote: "USD", Amount: 2590}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quotes, err := CrossRates(tt.base, tt.quotes, tt.ltps)
+
+			require.NoError(t, err)
+			require.Len(t, quotes, len(tt.expected))
+			for i, expected := range tt.expected {
+				assert.Equal(t, expected.Quote, quotes[i].Quote)
+				assert.InDelta(t, expected.Amount, quotes[i].Amount, 1e-9)
+				assert.Equal(t, expected.Via, quotes[i].Via)
+				assert.Equal(t, expected.Via != "", quotes[i].Derived())
+			}
+		})
+	}
+
+	t.Run("no direct or derived price", func(t *testing.T) {
+		quotes, err := CrossRates("BTC", []string{"USD", "EUR"}, []LTP{ltp(BTCUSD, 52000), ltp(BTCEUR, 0)})
+
+		assert.ErrorIs(t, err, ErrRateUnavailable)
+		assert.Contains(t, err.Error(), "EUR")
+		assert.Nil(t, quotes)
+	})
+}
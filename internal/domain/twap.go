@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// ComputeTWAP returns the time-weighted average price over the window ending now.
+// Samples must be ordered oldest first. Each sample is weighted by the time until the
+// next sample (or until now, for the newest one), clipped to the window. The oldest
+// sample must be at or before the start of the window so the whole window is covered.
+func ComputeTWAP(samples []CachedLTP, window time.Duration) (float64, error) {
+	return computeTWAP(samples, window, time.Now())
+}
+
+func computeTWAP(samples []CachedLTP, window time.Duration, end time.Time) (float64, error) {
+	if window <= 0 {
+		return 0, fmt.Errorf("window must be positive, got %s", window)
+	}
+	start := end.Add(-window)
+	if len(samples) == 0 || samples[0].Timestamp.After(start) {
+		return 0, fmt.Errorf("%w: samples do not cover the last %s", ErrInsufficientHistory, window)
+	}
+
+	var weighted float64
+	for i, sample := range samples {
+		from := sample.Timestamp
+		if from.Before(start) {
+			from = start
+		}
+		to := end
+		if i+1 < len(samples) {
+			to = samples[i+1].Timestamp
+		}
+		if to.After(end) {
+			to = end
+		}
+		if !to.After(from) {
+			continue
+		}
+		weighted += sample.LTP.Amount * to.Sub(from).Seconds()
+	}
+	return weighted / window.Seconds(), nil
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeTWAP(t *testing.T) {
+	end := time.Date(2024, 1, 1, 12, 10, 0, 0, time.UTC)
+	sample := func(amount float64, ago time.Duration) CachedLTP {
+		return CachedLTP{LTP: LTP{Amount: amount}, Timestamp: end.Add(-ago)}
+	}
+
+	tests := []struct {
+		name     string
+		samples  []CachedLTP
+		window   time.Duration
+		expected float64
+	}{
+		{
+			name:     "single sample covering the window",
+			samples:  []CachedLTP{sample(100, 10*time.Minute)},
+			window:   5 * time.Minute,
+			expected: 100,
+		},
+		{
+			name: "weights each sample by the time until the next",
+			samples: []CachedLTP{
+				sample(100, 5*time.Minute), // 4 minutes
+				sample(200, time.Minute),   // 1 minute
+			},
+			window:   5 * time.Minute,
+			expected: (100*4 + 200*1) / 5.0,
+		},
+		{
+			name: "clips samples that start before the window",
+			samples: []CachedLTP{
+				sample(50, 9*time.Minute),  // 1 minute inside the window of 4
+				sample(100, 3*time.Minute), // 2 minutes
+				sample(300, time.Minute),   // 1 minute
+			},
+			window:   4 * time.Minute,
+			expected: (50*1 + 100*2 + 300*1) / 4.0,
+		},
+		{
+			name: "ignores samples that end before the window",
+			samples: []CachedLTP{
+				sample(1, 9*time.Minute),
+				sample(100, 6*time.Minute),
+			},
+			window:   5 * time.Minute,
+			expected: 100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			twap, err := computeTWAP(tt.samples, tt.window, end)
+
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expected, twap, 1e-9)
+		})
+	}
+}
+
+func TestComputeTWAP_InsufficientHistory(t *testing.T) {
+	end := time.Date(2024, 1, 1, 12, 10, 0, 0, time.UTC)
+
+	t.Run("no samples", func(t *testing.T) {
+		_, err := computeTWAP(nil, time.Minute, end)
+		assert.ErrorIs(t, err, ErrInsufficientHistory)
+	})
+
+	t.Run("oldest sample inside the window", func(t *testing.T) {
+		samples := []CachedLTP{{LTP: LTP{Amount: 100}, Timestamp: end.Add(-2 * time.Minute)}}
+		_, err := computeTWAP(samples, 5*time.Minute, end)
+		assert.ErrorIs(t, err, ErrInsufficientHistory)
+	})
+
+	t.Run("non-positive window", func(t *testing.T) {
+		samples := []CachedLTP{{LTP: LTP{Amount: 100}, Timestamp: end.Add(-2 * time.Minute)}}
+		_, err := computeTWAP(samples, 0, end)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,9 @@
+package ports
+
+import "time"
+
+// Clock tells the current time, so time-dependent behavior can be tested without sleeping
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+}
@@ -1,12 +1,33 @@
 package ports
 
-import "go-exercise/internal/domain"
+import (
+	"context"
+
+	"go-exercise/internal/domain"
+)
 
 // External defines the interface for external service clients (e.g., Kraken API)
 type External interface {
 	// GetTicker retrieves the ticker information for a given pair
-	GetTicker(pair domain.Pair) (domain.LTP, error)
+	GetTicker(ctx context.Context, pair domain.Pair) (domain.LTP, error)
 	// GetTickers retrieves ticker information for multiple pairs
-	GetTickers(pairs []domain.Pair) ([]domain.LTP, error)
+	GetTickers(ctx context.Context, pairs []domain.Pair) ([]domain.LTP, error)
+}
+
+// PairSupporter is implemented by external clients that can report which pairs they support
+type PairSupporter interface {
+	// SupportedPairs returns the pairs the client is able to fetch
+	SupportedPairs() []domain.Pair
 }
 
+// SymbolResolver is implemented by external clients that can report the upstream symbol of a pair
+type SymbolResolver interface {
+	// Symbol returns the upstream symbol used for the pair, if the client has one
+	Symbol(pair domain.Pair) (string, bool)
+}
+
+// CandleProvider is implemented by external clients that can fetch OHLC candles
+type CandleProvider interface {
+	// GetOHLC retrieves the recent candles of a pair for the given interval in minutes, oldest first
+	GetOHLC(ctx context.Context, pair domain.Pair, interval int) ([]domain.Candle, error)
+}
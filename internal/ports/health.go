@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+
+	"go-exercise/internal/domain"
+)
+
+// HealthChecker reports the health of a named dependency for readiness checks
+type HealthChecker interface {
+	// Name identifies the component in readiness responses, e.g. "kraken"
+	Name() string
+	// CheckHealth reports the component's current state. It should honor the context's
+	// deadline and report HealthDown when it cannot tell.
+	CheckHealth(ctx context.Context) domain.HealthStatus
+}
+
+// RefresherStatusReporter exposes the state of the background cache refresher
+type RefresherStatusReporter interface {
+	// RefresherStatus returns a snapshot of the refresher's recent outcomes. It is safe
+	// to call concurrently with running refreshes.
+	RefresherStatus() domain.RefresherStatus
+}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	domain "go-exercise/internal/domain"
 
 	"github.com/stretchr/testify/mock"
@@ -13,14 +15,14 @@ type External struct {
 	mock.Mock
 }
 
-// GetTicker provides a mock function with given fields: pair
-func (_m *External) GetTicker(pair domain.Pair) (domain.LTP, error) {
-	ret := _m.Called(pair)
+// GetTicker provides a mock function with given fields: ctx, pair
+func (_m *External) GetTicker(ctx context.Context, pair domain.Pair) (domain.LTP, error) {
+	ret := _m.Called(ctx, pair)
 
 	var r0 domain.LTP
 	var r1 error
-	if rf, ok := ret.Get(0).(func(domain.Pair) (domain.LTP, error)); ok {
-		return rf(pair)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Pair) (domain.LTP, error)); ok {
+		return rf(ctx, pair)
 	}
 	if ret.Get(0) != nil {
 		r0 = ret.Get(0).(domain.LTP)
@@ -32,14 +34,14 @@ func (_m *External) GetTicker(pair domain.Pair) (domain.LTP, error) {
 	return r0, r1
 }
 
-// GetTickers provides a mock function with given fields: pairs
-func (_m *External) GetTickers(pairs []domain.Pair) ([]domain.LTP, error) {
-	ret := _m.Called(pairs)
+// GetTickers provides a mock function with given fields: ctx, pairs
+func (_m *External) GetTickers(ctx context.Context, pairs []domain.Pair) ([]domain.LTP, error) {
+	ret := _m.Called(ctx, pairs)
 
 	var r0 []domain.LTP
 	var r1 error
-	if rf, ok := ret.Get(0).(func([]domain.Pair) ([]domain.LTP, error)); ok {
-		return rf(pairs)
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.Pair) ([]domain.LTP, error)); ok {
+		return rf(ctx, pairs)
 	}
 	if ret.Get(0) != nil {
 		r0 = ret.Get(0).([]domain.LTP)
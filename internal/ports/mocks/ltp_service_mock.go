@@ -3,7 +3,11 @@
 package mocks
 
 import (
+	context "context"
+	time "time"
+
 	domain "go-exercise/internal/domain"
+	ports "go-exercise/internal/ports"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -13,14 +17,14 @@ type LTPService struct {
 	mock.Mock
 }
 
-// GetLTPs provides a mock function with given fields: pairsStr
-func (_m *LTPService) GetLTPs(pairsStr string) ([]domain.LTP, error) {
-	ret := _m.Called(pairsStr)
+// GetLTPs provides a mock function with given fields: ctx, pairsStr, opts
+func (_m *LTPService) GetLTPs(ctx context.Context, pairsStr string, opts ports.LTPOptions) ([]domain.LTP, error) {
+	ret := _m.Called(ctx, pairsStr, opts)
 
 	var r0 []domain.LTP
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]domain.LTP, error)); ok {
-		return rf(pairsStr)
+	if rf, ok := ret.Get(0).(func(context.Context, string, ports.LTPOptions) ([]domain.LTP, error)); ok {
+		return rf(ctx, pairsStr, opts)
 	}
 	if ret.Get(0) != nil {
 		r0 = ret.Get(0).([]domain.LTP)
@@ -32,3 +36,193 @@ func (_m *LTPService) GetLTPs(pairsStr string) ([]domain.LTP, error) {
 	return r0, r1
 }
 
+// GetLTP provides a mock function with given fields: ctx, pairStr
+func (_m *LTPService) GetLTP(ctx context.Context, pairStr string) (domain.LTP, error) {
+	ret := _m.Called(ctx, pairStr)
+
+	var r0 domain.LTP
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.LTP, error)); ok {
+		return rf(ctx, pairStr)
+	}
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).(domain.LTP)
+	}
+	if ret.Get(1) != nil {
+		r1 = ret.Get(1).(error)
+	}
+
+	return r0, r1
+}
+
+// RefreshLTPs provides a mock function with given fields: ctx, pairs
+func (_m *LTPService) RefreshLTPs(ctx context.Context, pairs []domain.Pair) error {
+	ret := _m.Called(ctx, pairs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.Pair) error); ok {
+		return rf(ctx, pairs)
+	}
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).(error)
+	}
+
+	return r0
+}
+
+// ProviderName provides a mock function with given fields:
+func (_m *LTPService) ProviderName() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		return rf()
+	}
+	r0 = ret.Get(0).(string)
+
+	return r0
+}
+
+// SlowUpstreamTotal provides a mock function with given fields:
+func (_m *LTPService) SlowUpstreamTotal() int64 {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		return rf()
+	}
+	r0 = ret.Get(0).(int64)
+
+	return r0
+}
+
+// GetCacheStats provides a mock function with given fields:
+func (_m *LTPService) GetCacheStats() domain.CacheStats {
+	ret := _m.Called()
+
+	var r0 domain.CacheStats
+	if rf, ok := ret.Get(0).(func() domain.CacheStats); ok {
+		return rf()
+	}
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).(domain.CacheStats)
+	}
+
+	return r0
+}
+
+// UpstreamErrors provides a mock function with given fields:
+func (_m *LTPService) UpstreamErrors() []domain.PairError {
+	ret := _m.Called()
+
+	var r0 []domain.PairError
+	if rf, ok := ret.Get(0).(func() []domain.PairError); ok {
+		return rf()
+	}
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]domain.PairError)
+	}
+
+	return r0
+}
+
+// GetHistory provides a mock function with given fields: pairStr, before, limit
+func (_m *LTPService) GetHistory(pairStr string, before time.Time, limit int) (domain.HistoryPage, error) {
+	ret := _m.Called(pairStr, before, limit)
+
+	var r0 domain.HistoryPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Time, int) (domain.HistoryPage, error)); ok {
+		return rf(pairStr, before, limit)
+	}
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).(domain.HistoryPage)
+	}
+	if ret.Get(1) != nil {
+		r1 = ret.Get(1).(error)
+	}
+
+	return r0, r1
+}
+
+// GetTWAP provides a mock function with given fields: pairStr, window
+func (_m *LTPService) GetTWAP(pairStr string, window time.Duration) (float64, error) {
+	ret := _m.Called(pairStr, window)
+
+	var r0 float64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Duration) (float64, error)); ok {
+		return rf(pairStr, window)
+	}
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).(float64)
+	}
+	if ret.Get(1) != nil {
+		r1 = ret.Get(1).(error)
+	}
+
+	return r0, r1
+}
+
+// GetOHLC provides a mock function with given fields: ctx, pairStr, interval
+func (_m *LTPService) GetOHLC(ctx context.Context, pairStr string, interval int) ([]domain.Candle, error) {
+	ret := _m.Called(ctx, pairStr, interval)
+
+	var r0 []domain.Candle
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]domain.Candle, error)); ok {
+		return rf(ctx, pairStr, interval)
+	}
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]domain.Candle)
+	}
+	if ret.Get(1) != nil {
+		r1 = ret.Get(1).(error)
+	}
+
+	return r0, r1
+}
+
+// ValidatePairs provides a mock function with given fields: pairsStr
+func (_m *LTPService) ValidatePairs(pairsStr string) (domain.PairValidation, error) {
+	ret := _m.Called(pairsStr)
+
+	var r0 domain.PairValidation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (domain.PairValidation, error)); ok {
+		return rf(pairsStr)
+	}
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).(domain.PairValidation)
+	}
+	if ret.Get(1) != nil {
+		r1 = ret.Get(1).(error)
+	}
+
+	return r0, r1
+}
+
+// PreloadLTPs provides a mock function with given fields: ltps
+func (_m *LTPService) PreloadLTPs(ltps []domain.LTP) error {
+	ret := _m.Called(ltps)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]domain.LTP) error); ok {
+		return rf(ltps)
+	}
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).(error)
+	}
+
+	return r0
+}
+
+// InvalidateLTP provides a mock function with given fields: pair
+func (_m *LTPService) InvalidateLTP(pair domain.Pair) {
+	_m.Called(pair)
+}
+
+// ClearCache provides a mock function with given fields:
+func (_m *LTPService) ClearCache() {
+	_m.Called()
+}
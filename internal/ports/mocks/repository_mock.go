@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	time "time"
+
 	domain "go-exercise/internal/domain"
 
 	"github.com/stretchr/testify/mock"
@@ -18,6 +20,40 @@ func (_m *Repository) Clear() {
 	_m.Called()
 }
 
+// GetEarliestSince provides a mock function with given fields: pair, since
+func (_m *Repository) GetEarliestSince(pair domain.Pair, since time.Time) (domain.CachedLTP, bool) {
+	ret := _m.Called(pair, since)
+
+	var r0 domain.CachedLTP
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(domain.Pair, time.Time) (domain.CachedLTP, bool)); ok {
+		return rf(pair, since)
+	}
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).(domain.CachedLTP)
+	}
+	if ret.Get(1) != nil {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// GetHistory provides a mock function with given fields: pair, before, limit
+func (_m *Repository) GetHistory(pair domain.Pair, before time.Time, limit int) domain.HistoryPage {
+	ret := _m.Called(pair, before, limit)
+
+	var r0 domain.HistoryPage
+	if rf, ok := ret.Get(0).(func(domain.Pair, time.Time, int) domain.HistoryPage); ok {
+		return rf(pair, before, limit)
+	}
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).(domain.HistoryPage)
+	}
+
+	return r0
+}
+
 // GetLTP provides a mock function with given fields: pair
 func (_m *Repository) GetLTP(pair domain.Pair) (*domain.CachedLTP, bool) {
 	ret := _m.Called(pair)
@@ -37,7 +73,70 @@ func (_m *Repository) GetLTP(pair domain.Pair) (*domain.CachedLTP, bool) {
 	return r0, r1
 }
 
+// GetOrFetch provides a mock function with given fields: pair, fetch
+func (_m *Repository) GetOrFetch(pair domain.Pair, fetch func() (domain.LTP, error)) (*domain.CachedLTP, error) {
+	ret := _m.Called(pair, fetch)
+
+	var r0 *domain.CachedLTP
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Pair, func() (domain.LTP, error)) (*domain.CachedLTP, error)); ok {
+		return rf(pair, fetch)
+	}
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*domain.CachedLTP)
+	}
+	if ret.Get(1) != nil {
+		r1 = ret.Get(1).(error)
+	}
+
+	return r0, r1
+}
+
+// GetStaleLTP provides a mock function with given fields: pair
+func (_m *Repository) GetStaleLTP(pair domain.Pair) (*domain.CachedLTP, bool) {
+	ret := _m.Called(pair)
+
+	var r0 *domain.CachedLTP
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(domain.Pair) (*domain.CachedLTP, bool)); ok {
+		return rf(pair)
+	}
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*domain.CachedLTP)
+	}
+	if ret.Get(1) != nil {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// Invalidate provides a mock function with given fields: pair
+func (_m *Repository) Invalidate(pair domain.Pair) {
+	_m.Called(pair)
+}
+
 // SetLTP provides a mock function with given fields: pair, ltp
 func (_m *Repository) SetLTP(pair domain.Pair, ltp domain.LTP) {
 	_m.Called(pair, ltp)
 }
+
+// SetLTPs provides a mock function with given fields: ltps
+func (_m *Repository) SetLTPs(ltps []domain.LTP) {
+	_m.Called(ltps)
+}
+
+// Stats provides a mock function with given fields:
+func (_m *Repository) Stats() domain.CacheStats {
+	ret := _m.Called()
+
+	var r0 domain.CacheStats
+	if rf, ok := ret.Get(0).(func() domain.CacheStats); ok {
+		return rf()
+	}
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).(domain.CacheStats)
+	}
+
+	return r0
+}
@@ -1,14 +1,42 @@
 package ports
 
-import "go-exercise/internal/domain"
+import (
+	"time"
+
+	"go-exercise/internal/domain"
+)
 
 // Repository defines the interface for LTP storage/cache
 type Repository interface {
 	// GetLTP retrieves a cached LTP for a given pair
 	GetLTP(pair domain.Pair) (*domain.CachedLTP, bool)
+	// GetStaleLTP retrieves a cached LTP for a given pair even if it has expired
+	GetStaleLTP(pair domain.Pair) (*domain.CachedLTP, bool)
 	// SetLTP stores an LTP in the cache
 	SetLTP(pair domain.Pair, ltp domain.LTP)
+	// GetOrFetch returns the cached LTP for a given pair or, when it is missing or expired,
+	// calls fetch and stores its result. Concurrent misses of the same pair share one fetch.
+	GetOrFetch(pair domain.Pair, fetch func() (domain.LTP, error)) (*domain.CachedLTP, error)
+	// SetLTPs stores several LTPs, keyed by their pairs, in a single operation
+	SetLTPs(ltps []domain.LTP)
+	// Invalidate removes the cached LTP of a single pair; its history is kept
+	Invalidate(pair domain.Pair)
 	// Clear removes all cached data
 	Clear()
+	// Stats reports entry counts and timestamp bounds of the cached data
+	Stats() domain.CacheStats
+	// GetHistory returns up to limit of the newest samples of a pair stored strictly before the
+	// given time, oldest first, and whether older samples remain. A zero before means no upper
+	// bound and a limit <= 0 means no limit. Backends without history return an empty page.
+	GetHistory(pair domain.Pair, before time.Time, limit int) domain.HistoryPage
+	// GetEarliestSince returns the oldest stored sample of a pair taken at or after since,
+	// if any. Backends without history report none.
+	GetEarliestSince(pair domain.Pair, since time.Time) (domain.CachedLTP, bool)
 }
 
+// TTLSource is implemented by repositories that can report the time-to-live they currently
+// apply to a pair, which may change at runtime
+type TTLSource interface {
+	// TTLFor returns the effective TTL of the given pair
+	TTLFor(pair domain.Pair) time.Duration
+}
@@ -1,11 +1,77 @@
 package ports
 
-import "go-exercise/internal/domain"
+import (
+	"context"
+	"time"
+
+	"go-exercise/internal/domain"
+)
+
+// Order controls how GetLTPs orders its results
+type Order string
+
+const (
+	// OrderSorted sorts results by pair name (the default)
+	OrderSorted Order = "sorted"
+	// OrderRequested keeps results in the order the pairs were requested
+	OrderRequested Order = "requested"
+)
+
+// LTPOptions holds per-request options for GetLTPs
+type LTPOptions struct {
+	// ForceRefresh skips the cache lookup and always fetches from the external service
+	ForceRefresh bool
+	// Order controls result ordering; the zero value means OrderSorted
+	Order Order
+	// AcceptStale overrides the server default for serving expired cached values when
+	// the external service fails; nil keeps the server default
+	AcceptStale *bool
+	// CheckSLA evaluates each result against its pair's freshness SLA, if one is configured
+	CheckSLA bool
+	// IncludeChange computes each result's 24-hour change from stored history
+	IncludeChange bool
+	// Provider selects the price provider by name; prices from a provider other than the
+	// primary one bypass the cache. Empty uses the primary provider.
+	Provider string
+	// Quote requests every pair quoted in the currency instead of a list of pairs. The
+	// limit on how many pairs a request may name does not apply to it.
+	Quote string
+}
 
 // LTPService defines the interface for LTP service operations
 type LTPService interface {
 	// GetLTPs retrieves LTPs for the requested pairs
-	// If pairs is empty, returns all valid pairs
-	GetLTPs(pairsStr string) ([]domain.LTP, error)
+	// If pairs is empty, returns the default pairs (all valid pairs unless configured)
+	// Pairs without a price are left out, also when a single pair is requested
+	GetLTPs(ctx context.Context, pairsStr string, opts LTPOptions) ([]domain.LTP, error)
+	// GetLTP retrieves the LTP of a single pair with the default options, without the
+	// parsing, ordering and allocation overhead of GetLTPs
+	GetLTP(ctx context.Context, pairStr string) (domain.LTP, error)
+	// RefreshLTPs fetches the given pairs from the external service and stores them in the cache
+	RefreshLTPs(ctx context.Context, pairs []domain.Pair) error
+	// ProviderName names the external price provider, or is empty when not configured
+	ProviderName() string
+	// GetCacheStats reports statistics about the underlying LTP cache
+	GetCacheStats() domain.CacheStats
+	// UpstreamErrors returns the last error of each pair whose latest fetch failed, sorted by pair
+	UpstreamErrors() []domain.PairError
+	// SlowUpstreamTotal counts the upstream calls that exceeded the slow upstream threshold
+	SlowUpstreamTotal() int64
+	// GetHistory returns up to limit of the newest stored prices of a pair from before the
+	// given time (zero for no bound), oldest first, and whether older prices remain
+	GetHistory(pairStr string, before time.Time, limit int) (domain.HistoryPage, error)
+	// GetTWAP returns the time-weighted average price of a pair over the window ending now
+	GetTWAP(pairStr string, window time.Duration) (float64, error)
+	// GetOHLC retrieves the recent candles of a pair for the given interval in minutes, oldest first
+	GetOHLC(ctx context.Context, pairStr string, interval int) ([]domain.Candle, error)
+	// ValidatePairs reports which entries of a pairs string are valid and supported,
+	// without accessing the cache or the external service
+	ValidatePairs(pairsStr string) (domain.PairValidation, error)
+	// PreloadLTPs stores the given prices in the cache without calling the external service,
+	// storing none of them when any is outside the configured price bounds
+	PreloadLTPs(ltps []domain.LTP) error
+	// InvalidateLTP drops the cached price of a single pair
+	InvalidateLTP(pair domain.Pair)
+	// ClearCache drops every cached price
+	ClearCache()
 }
-